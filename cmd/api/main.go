@@ -1,10 +1,13 @@
 package main
 
 import (
+	"context"
 	"log"
+	"os"
 
 	"github.com/elokanugrah/go-financing-btpns/internal/config"
 	"github.com/elokanugrah/go-financing-btpns/internal/database"
+	"github.com/elokanugrah/go-financing-btpns/internal/domain"
 	"github.com/elokanugrah/go-financing-btpns/internal/repository/postgres"
 	"github.com/elokanugrah/go-financing-btpns/internal/usecase"
 
@@ -22,16 +25,94 @@ func main() {
 
 	// Initialize Repository Layer
 	tenorRepo := postgres.NewTenorRepository(db)
+	productRepo := postgres.NewProductRepository(db)
 	facilityDetail := postgres.NewUserFacilityDetailRepository(db)
 	facilityRepo := postgres.NewUserFacilityRepository(db)
+	statusHistoryRepo := postgres.NewFacilityStatusHistoryRepository(db)
+	paymentRepo := postgres.NewPaymentRepository(db)
+	settlementRepo := postgres.NewSettlementRepository(db)
+	collectibilityRepo := postgres.NewCollectibilityRepository(db)
+	lateChargeRepo := postgres.NewLateChargeRepository(db)
+	restructuringRepo := postgres.NewRestructuringRepository(db)
+	holidayRepo := postgres.NewHolidayRepository(db)
+	userRepo := postgres.NewUserRepository(db)
 	facilityLimit := postgres.NewUserFacilityLimitRepository(db)
+	limitHistoryRepo := postgres.NewFacilityLimitHistoryRepository(db)
+	idempotencyKeyRepo := postgres.NewIdempotencyKeyRepository(db)
 	txManager := postgres.NewTransactionManager(db)
 
+	if err := importHolidays(context.Background(), holidayRepo, cfg.HolidayCalendarFile); err != nil {
+		log.Fatalf("Failed to import holiday calendar: %v", err)
+	}
+	holidayCalendar := usecase.NewHolidayCache(holidayRepo)
+	if err := holidayCalendar.Reload(context.Background()); err != nil {
+		log.Fatalf("Failed to load holiday calendar: %v", err)
+	}
+
+	schedulePolicy := domain.SchedulePolicy{
+		RoundingUnit:      domain.NewMoney(cfg.InstallmentRoundingUnit),
+		ResidualPlacement: domain.ResidualPlacement(cfg.InstallmentResidualPlacement),
+		DueDates: domain.DueDatePolicy{
+			DueDay:           cfg.InstallmentDueDay,
+			BusinessDaysOnly: cfg.InstallmentBusinessDaysOnly,
+			Holidays:         holidayCalendar,
+		},
+	}
+	if err := schedulePolicy.Validate(); err != nil {
+		log.Fatalf("Invalid installment schedule configuration: %v", err)
+	}
+
+	marginRebate, err := domain.ParseRate(cfg.SettlementMarginRebate)
+	if err != nil {
+		log.Fatalf("Invalid settlement configuration: %v", err)
+	}
+	settlementPolicy := domain.SettlementPolicy{MarginRebate: marginRebate}
+	if err := settlementPolicy.Validate(); err != nil {
+		log.Fatalf("Invalid settlement configuration: %v", err)
+	}
+
+	lateChargePolicy, err := loadLateChargePolicy(cfg)
+	if err != nil {
+		log.Fatalf("Invalid late charge configuration: %v", err)
+	}
+
+	submissionPolicy := domain.SubmissionPolicy{
+		StartDateMaxDaysBack:  cfg.SubmissionStartDateMaxDaysBack,
+		StartDateMaxDaysAhead: cfg.SubmissionStartDateMaxDaysAhead,
+		MaxActiveFacilities:   cfg.SubmissionMaxActiveFacilities,
+	}
+	if err := submissionPolicy.Validate(); err != nil {
+		log.Fatalf("Invalid submission configuration: %v", err)
+	}
+
 	// Initialize Usecase Layer
-	financingUsecase := usecase.NewFinancingUsecase(tenorRepo, facilityDetail, facilityRepo, facilityLimit, txManager)
+	financingUsecase := usecase.NewFinancingUsecase(usecase.FinancingDeps{
+		TenorRepo:              tenorRepo,
+		ProductRepo:            productRepo,
+		UserFacilityDetailRepo: facilityDetail,
+		UserFacilityRepo:       facilityRepo,
+		StatusHistoryRepo:      statusHistoryRepo,
+		PaymentRepo:            paymentRepo,
+		SettlementRepo:         settlementRepo,
+		CollectibilityRepo:     collectibilityRepo,
+		LateChargeRepo:         lateChargeRepo,
+		RestructuringRepo:      restructuringRepo,
+		UserRepo:               userRepo,
+		FacilityLimitRepo:      facilityLimit,
+		IdempotencyKeyRepo:     idempotencyKeyRepo,
+		TxManager:              txManager,
+		SchedulePolicy:         schedulePolicy,
+		SettlementPolicy:       settlementPolicy,
+		LateChargePolicy:       lateChargePolicy,
+		SubmissionPolicy:       submissionPolicy,
+	})
+
+	holidayUsecase := usecase.NewHolidayUsecase(holidayRepo, holidayCalendar, txManager)
+	userUsecase := usecase.NewUserUsecase(userRepo, txManager)
+	facilityLimitUsecase := usecase.NewFacilityLimitUsecase(facilityLimit, limitHistoryRepo, userRepo, txManager)
 
 	// Initialize Delivery Layer (Handler)
-	apiHandler := httpDelivery.NewHandler(financingUsecase)
+	apiHandler := httpDelivery.NewHandler(financingUsecase, holidayUsecase, userUsecase, facilityLimitUsecase)
 
 	// Setup Router and Start Server
 	router := httpDelivery.SetupRouter(apiHandler)
@@ -41,3 +122,59 @@ func main() {
 		log.Fatalf("Failed to start server: %v", err)
 	}
 }
+
+// importHolidays seeds an empty holidays table from the calendar CSV at path. Once the table
+// holds a holiday it is managed through /admin/holidays only, so holidays removed there are
+// not brought back on the next start.
+func importHolidays(ctx context.Context, repo domain.HolidayRepository, path string) error {
+	if path == "" {
+		return nil
+	}
+	existing, err := repo.List(ctx, 0)
+	if err != nil {
+		return err
+	}
+	if len(existing) > 0 {
+		log.Printf("INFO: holidays table is not empty, %s is not imported", path)
+		return nil
+	}
+
+	holidays, err := loadHolidays(path)
+	if err != nil {
+		return err
+	}
+	if err := holidays.Validate(); err != nil {
+		return err
+	}
+	return repo.Save(ctx, holidays.Holidays())
+}
+
+// loadHolidays reads the holiday calendar CSV at path.
+func loadHolidays(path string) (domain.HolidayList, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return domain.ParseHolidayCSV(f)
+}
+
+func loadLateChargePolicy(cfg *config.Config) (domain.LateChargePolicy, error) {
+	policy := domain.LateChargePolicy{GraceDays: cfg.LateChargeGraceDays}
+
+	var err error
+	if policy.DailyAmount, err = domain.ParseMoney(cfg.LateChargeDailyAmount); err != nil {
+		return policy, err
+	}
+	if policy.OverdueRate, err = domain.ParseRate(cfg.LateChargeOverdueRate); err != nil {
+		return policy, err
+	}
+	if policy.MaxAmount, err = domain.ParseMoney(cfg.LateChargeMaxAmount); err != nil {
+		return policy, err
+	}
+	if policy.MaxRate, err = domain.ParseRate(cfg.LateChargeMaxRate); err != nil {
+		return policy, err
+	}
+	return policy, policy.Validate()
+}
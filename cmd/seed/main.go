@@ -7,6 +7,7 @@ import (
 
 	"github.com/elokanugrah/go-financing-btpns/internal/config"
 	"github.com/elokanugrah/go-financing-btpns/internal/database"
+	"github.com/elokanugrah/go-financing-btpns/internal/domain"
 
 	_ "github.com/lib/pq"
 )
@@ -21,6 +22,14 @@ func main() {
 		log.Fatalf("FATAL: Failed to seed tenors: %v", err)
 	}
 
+	if err := seedProducts(db); err != nil {
+		log.Fatalf("FATAL: Failed to seed products: %v", err)
+	}
+
+	if err := seedTenorMarginRates(db); err != nil {
+		log.Fatalf("FATAL: Failed to seed tenor margin rates: %v", err)
+	}
+
 	if err := seedUsers(db); err != nil {
 		log.Fatalf("FATAL: Failed to seed users: %v", err)
 	}
@@ -69,6 +78,46 @@ func seedTenors(db *sql.DB) error {
 	return tx.Commit()
 }
 
+// seedProducts clears the products table and inserts a flat and an annuity product.
+func seedProducts(db *sql.DB) error {
+	log.Println("Clearing products table...")
+	_, err := db.Exec(`TRUNCATE TABLE products RESTART IDENTITY CASCADE`)
+	if err != nil {
+		return fmt.Errorf("error truncating products table: %w", err)
+	}
+
+	_, err = db.Exec(`
+		INSERT INTO products (product_code, name, calculation_method)
+		VALUES ($1, 'Regular Financing', $2), ('EFEKTIF', 'Effective Rate Financing', $3)`,
+		domain.DefaultProductCode, domain.CalculationFlat, domain.CalculationAnnuity,
+	)
+	if err != nil {
+		return fmt.Errorf("error inserting products: %w", err)
+	}
+	return nil
+}
+
+// seedTenorMarginRates prices every tenor of the flat product at 20% and of the annuity product at 18%.
+func seedTenorMarginRates(db *sql.DB) error {
+	log.Println("Clearing tenor_margin_rates table...")
+	_, err := db.Exec(`TRUNCATE TABLE tenor_margin_rates RESTART IDENTITY`)
+	if err != nil {
+		return fmt.Errorf("error truncating tenor_margin_rates table: %w", err)
+	}
+
+	_, err = db.Exec(`
+		INSERT INTO tenor_margin_rates (tenor_id, product_code, margin_rate, effective_from)
+		SELECT tenor_id, $1, $2, DATE '2000-01-01' FROM tenors
+		UNION ALL
+		SELECT tenor_id, 'EFEKTIF', $3, DATE '2000-01-01' FROM tenors`,
+		domain.DefaultProductCode, domain.Rate(2000), domain.Rate(1800),
+	)
+	if err != nil {
+		return fmt.Errorf("error inserting tenor margin rates: %w", err)
+	}
+	return nil
+}
+
 // seedUsers clears the users table and inserts dummy users.
 func seedUsers(db *sql.DB) error {
 	log.Println("Clearing users table...")
@@ -77,19 +126,23 @@ func seedUsers(db *sql.DB) error {
 		return fmt.Errorf("error truncating users table: %w", err)
 	}
 
-	stmt, err := db.Prepare(`INSERT INTO users (name, phone) VALUES ($1, $2)`)
+	// Seeded users have passed KYC so they can submit financing right away
+	stmt, err := db.Prepare(`INSERT INTO users (name, phone, nik, date_of_birth, address, kyc_status) VALUES ($1, $2, $3, $4, $5, 'VERIFIED')`)
 	if err != nil {
 		return fmt.Errorf("error preparing insert statement: %w", err)
 	}
 	defer stmt.Close()
 
 	users := []struct {
-		Name  string
-		Phone string
+		Name        string
+		Phone       string
+		NIK         string
+		DateOfBirth string
+		Address     string
 	}{
-		{"Budi Santoso", "081234567890"},
-		{"Siti Aminah", "081987654321"},
-		{"Andi Wijaya", "081223344556"},
+		{"Budi Santoso", "+6281234567890", "3171011508900001", "1990-08-15", "Jl. Merdeka No. 1, Jakarta Pusat"},
+		{"Siti Aminah", "+6281987654321", "3273015502040123", "2004-02-15", "Jl. Asia Afrika No. 8, Bandung"},
+		{"Andi Wijaya", "+6281223344556", "3578012003850002", "1985-03-20", "Jl. Pemuda No. 17, Surabaya"},
 	}
 
 	tx, err := db.Begin()
@@ -98,7 +151,7 @@ func seedUsers(db *sql.DB) error {
 	}
 
 	for _, user := range users {
-		if _, err := tx.Stmt(stmt).Exec(user.Name, user.Phone); err != nil {
+		if _, err := tx.Stmt(stmt).Exec(user.Name, user.Phone, user.NIK, user.DateOfBirth, user.Address); err != nil {
 			if rbErr := tx.Rollback(); rbErr != nil {
 				return fmt.Errorf("error executing insert and rolling back transaction: %w, %w", err, rbErr)
 			}
@@ -118,7 +171,7 @@ func seedUserFacilityLimits(db *sql.DB) error {
 		return fmt.Errorf("error truncating user_facility_limits table: %w", err)
 	}
 
-	stmt, err := db.Prepare(`INSERT INTO user_facility_limits (user_id, limit_amount) VALUES ($1, $2)`)
+	stmt, err := db.Prepare(`INSERT INTO user_facility_limits (user_id, limit_amount, limit_type) VALUES ($1, $2, $3)`)
 	if err != nil {
 		return fmt.Errorf("error preparing insert statement: %w", err)
 	}
@@ -126,11 +179,12 @@ func seedUserFacilityLimits(db *sql.DB) error {
 
 	userLimits := []struct {
 		UserID      int64
-		LimitAmount float64
+		LimitAmount domain.Money
+		LimitType   domain.LimitType
 	}{
-		{1, 10000000},
-		{2, 5000000},
-		{3, 15000000},
+		{1, domain.NewMoney(10000000), domain.LimitTypeNonRevolving},
+		{2, domain.NewMoney(5000000), domain.LimitTypeNonRevolving},
+		{3, domain.NewMoney(15000000), domain.LimitTypeRevolving},
 	}
 
 	tx, err := db.Begin()
@@ -139,7 +193,7 @@ func seedUserFacilityLimits(db *sql.DB) error {
 	}
 
 	for _, user := range userLimits {
-		if _, err := tx.Stmt(stmt).Exec(user.UserID, user.LimitAmount); err != nil {
+		if _, err := tx.Stmt(stmt).Exec(user.UserID, user.LimitAmount, user.LimitType); err != nil {
 			if rbErr := tx.Rollback(); rbErr != nil {
 				return fmt.Errorf("error executing insert and rolling back transaction: %w, %w", err, rbErr)
 			}
@@ -147,6 +201,19 @@ func seedUserFacilityLimits(db *sql.DB) error {
 		}
 	}
 
+	// The seeded limits start their history as granted
+	_, err = tx.Exec(`
+		INSERT INTO facility_limit_histories (facility_limit_id, action, amount, limit_before, limit_after, actor, reason)
+		SELECT facility_limit_id, $1, limit_amount, 0, limit_amount, 'seed', 'seeded limit' FROM user_facility_limits`,
+		domain.LimitActionGrant,
+	)
+	if err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			return fmt.Errorf("error inserting limit histories and rolling back transaction: %w, %w", err, rbErr)
+		}
+		return fmt.Errorf("error inserting limit histories: %w", err)
+	}
+
 	log.Println("Committing transaction for users...")
 	return tx.Commit()
 }
@@ -18,6 +18,39 @@ type Config struct {
 	DBUser     string `env:"DB_USER,required"`
 	DBPassword string `env:"DB_PASSWORD,required"`
 	DBName     string `env:"DB_NAME,required"`
+
+	// InstallmentRoundingUnit is the rupiah unit every installment is rounded to.
+	InstallmentRoundingUnit int64 `env:"INSTALLMENT_ROUNDING_UNIT" envDefault:"1"`
+	// InstallmentResidualPlacement is either "last" or "first".
+	InstallmentResidualPlacement string `env:"INSTALLMENT_RESIDUAL_PLACEMENT" envDefault:"last"`
+	// InstallmentDueDay fixes the day of the month installments fall due on, 0 keeps the start date's day.
+	InstallmentDueDay int `env:"INSTALLMENT_DUE_DAY" envDefault:"0"`
+	// InstallmentBusinessDaysOnly moves due dates on weekends and holidays to the next business day.
+	InstallmentBusinessDaysOnly bool `env:"INSTALLMENT_BUSINESS_DAYS_ONLY" envDefault:"false"`
+	// HolidayCalendarFile is a CSV of "date,name" public holidays imported at startup while the holidays table is empty, empty for none.
+	HolidayCalendarFile string `env:"HOLIDAY_CALENDAR_FILE"`
+
+	// SubmissionStartDateMaxDaysBack is how many days before the submission day a start date may be.
+	SubmissionStartDateMaxDaysBack int `env:"SUBMISSION_START_DATE_MAX_DAYS_BACK" envDefault:"0"`
+	// SubmissionStartDateMaxDaysAhead is how many days after the submission day a start date may be,
+	// the start date is not checked when both bounds are 0.
+	SubmissionStartDateMaxDaysAhead int `env:"SUBMISSION_START_DATE_MAX_DAYS_AHEAD" envDefault:"0"`
+	// SubmissionMaxActiveFacilities caps the submitted and not yet closed facilities of a user, 0 for no cap.
+	SubmissionMaxActiveFacilities int `env:"SUBMISSION_MAX_ACTIVE_FACILITIES" envDefault:"0"`
+
+	// SettlementMarginRebate is the share of the unearned margin waived on early settlement, e.g. "0.5".
+	SettlementMarginRebate string `env:"SETTLEMENT_MARGIN_REBATE" envDefault:"0"`
+
+	// LateChargeGraceDays is the number of days after the due date before a late charge accrues.
+	LateChargeGraceDays int `env:"LATE_CHARGE_GRACE_DAYS" envDefault:"0"`
+	// LateChargeDailyAmount is the rupiah charged per day late, e.g. "5000".
+	LateChargeDailyAmount string `env:"LATE_CHARGE_DAILY_AMOUNT" envDefault:"0"`
+	// LateChargeOverdueRate is the share of the overdue installment charged, e.g. "0.01".
+	LateChargeOverdueRate string `env:"LATE_CHARGE_OVERDUE_RATE" envDefault:"0"`
+	// LateChargeMaxAmount caps the charge of one installment in rupiah, "0" for no cap.
+	LateChargeMaxAmount string `env:"LATE_CHARGE_MAX_AMOUNT" envDefault:"0"`
+	// LateChargeMaxRate caps the charge of one installment as a share of it, "0" for no cap.
+	LateChargeMaxRate string `env:"LATE_CHARGE_MAX_RATE" envDefault:"0"`
 }
 
 func (c *Config) DSN() string {
@@ -0,0 +1,58 @@
+package http
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/elokanugrah/go-financing-btpns/internal/domain"
+	"github.com/gin-gonic/gin"
+)
+
+// errorKinds maps each domain error kind to its response status and default error code.
+var errorKinds = []struct {
+	kind   error
+	status int
+	code   string
+}{
+	{domain.ErrNotFound, http.StatusNotFound, "NOT_FOUND"},
+	{domain.ErrForbidden, http.StatusForbidden, "FORBIDDEN"},
+	{domain.ErrInsufficientLimit, http.StatusConflict, "INSUFFICIENT_LIMIT"},
+	{domain.ErrConflict, http.StatusConflict, "CONFLICT"},
+	{domain.ErrValidation, http.StatusUnprocessableEntity, "VALIDATION_ERROR"},
+}
+
+// ErrorHandler renders the last error a handler attached with c.Error as
+// {"code": ..., "error": ...}. Errors that are not domain errors are logged and
+// reported as 500 without leaking their message.
+func ErrorHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		if len(c.Errors) == 0 || c.Writer.Written() {
+			return
+		}
+		err := c.Errors.Last().Err
+
+		for _, k := range errorKinds {
+			if !errors.Is(err, k.kind) {
+				continue
+			}
+			code := k.code
+			var domainErr *domain.Error
+			if errors.As(err, &domainErr) && domainErr.Code != "" {
+				code = domainErr.Code
+			}
+			c.JSON(k.status, gin.H{"code": code, "error": err.Error()})
+			return
+		}
+
+		log.Printf("%s %s: %v", c.Request.Method, c.Request.URL.Path, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"code": "INTERNAL_ERROR", "error": "internal server error"})
+	}
+}
+
+// invalidRequest wraps a binding error so it is reported as a validation failure.
+func invalidRequest(err error) error {
+	return domain.ValidationError("INVALID_REQUEST", err.Error())
+}
@@ -0,0 +1,108 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/elokanugrah/go-financing-btpns/internal/domain"
+	"github.com/elokanugrah/go-financing-btpns/internal/dto"
+	"github.com/gin-gonic/gin"
+)
+
+func (h *Handler) GrantLimit(c *gin.Context) {
+	var req dto.GrantLimitRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(invalidRequest(err))
+		return
+	}
+
+	resp, err := h.limitUsecase.GrantLimit(c.Request.Context(), req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, resp)
+}
+
+func (h *Handler) GetLimit(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.Error(domain.ValidationError("INVALID_FACILITY_LIMIT_ID", "invalid facility limit id"))
+		return
+	}
+
+	resp, err := h.limitUsecase.GetLimit(c.Request.Context(), id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h *Handler) TopUpLimit(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.Error(domain.ValidationError("INVALID_FACILITY_LIMIT_ID", "invalid facility limit id"))
+		return
+	}
+
+	var req dto.LimitAdjustmentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(invalidRequest(err))
+		return
+	}
+
+	resp, err := h.limitUsecase.TopUpLimit(c.Request.Context(), id, req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h *Handler) ReduceLimit(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.Error(domain.ValidationError("INVALID_FACILITY_LIMIT_ID", "invalid facility limit id"))
+		return
+	}
+
+	var req dto.LimitAdjustmentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(invalidRequest(err))
+		return
+	}
+
+	resp, err := h.limitUsecase.ReduceLimit(c.Request.Context(), id, req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h *Handler) ExpireLimit(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.Error(domain.ValidationError("INVALID_FACILITY_LIMIT_ID", "invalid facility limit id"))
+		return
+	}
+
+	var req dto.LimitExpiryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(invalidRequest(err))
+		return
+	}
+
+	resp, err := h.limitUsecase.ExpireLimit(c.Request.Context(), id, req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
@@ -1,8 +1,11 @@
 package http
 
 import (
+	"context"
 	"net/http"
+	"strconv"
 
+	"github.com/elokanugrah/go-financing-btpns/internal/domain"
 	"github.com/elokanugrah/go-financing-btpns/internal/dto"
 	"github.com/elokanugrah/go-financing-btpns/internal/usecase"
 	"github.com/gin-gonic/gin"
@@ -10,41 +13,279 @@ import (
 
 type Handler struct {
 	financingUsecase usecase.FinancingUsecase
+	holidayUsecase   usecase.HolidayUsecase
+	userUsecase      usecase.UserUsecase
+	limitUsecase     usecase.FacilityLimitUsecase
 }
 
-func NewHandler(fuc usecase.FinancingUsecase) *Handler {
+func NewHandler(fuc usecase.FinancingUsecase, huc usecase.HolidayUsecase, uuc usecase.UserUsecase, fluc usecase.FacilityLimitUsecase) *Handler {
 	return &Handler{
 		financingUsecase: fuc,
+		holidayUsecase:   huc,
+		userUsecase:      uuc,
+		limitUsecase:     fluc,
 	}
 }
 
 func (h *Handler) Calculate(c *gin.Context) {
 	var req dto.CalculateRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(invalidRequest(err))
 		return
 	}
 
-	resp, err := h.financingUsecase.CalculateAllTenors(c.Request.Context(), req.Amount)
+	resp, err := h.financingUsecase.CalculateAllTenors(c.Request.Context(), req)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 	c.JSON(http.StatusOK, resp)
 }
 
+// maxIdempotencyKeyLength matches the idempotency_keys.idempotency_key column.
+const maxIdempotencyKeyLength = 255
+
 func (h *Handler) SubmitFinancing(c *gin.Context) {
 	var req dto.SubmitFinancingRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(invalidRequest(err))
+		return
+	}
+
+	req.IdempotencyKey = c.GetHeader("Idempotency-Key")
+	if len(req.IdempotencyKey) > maxIdempotencyKeyLength {
+		c.Error(domain.ValidationError("INVALID_IDEMPOTENCY_KEY", "Idempotency-Key must not be longer than 255 characters"))
 		return
 	}
 
 	resp, err := h.financingUsecase.SubmitFinancing(c.Request.Context(), req)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// CheckEligibility answers 200 whether or not the submission would be accepted, the failing rules
+// are in the body.
+func (h *Handler) CheckEligibility(c *gin.Context) {
+	var req dto.SubmitFinancingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(invalidRequest(err))
+		return
+	}
+
+	resp, err := h.financingUsecase.CheckEligibility(c.Request.Context(), req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h *Handler) GetFacility(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.Error(domain.ValidationError("INVALID_FACILITY_ID", "invalid facility id"))
+		return
+	}
+
+	resp, err := h.financingUsecase.GetFacility(c.Request.Context(), id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h *Handler) ListUserFacilities(c *gin.Context) {
+	userID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.Error(domain.ValidationError("INVALID_USER_ID", "invalid user id"))
+		return
+	}
+
+	var req dto.ListFacilitiesRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.Error(invalidRequest(err))
+		return
+	}
+
+	resp, err := h.financingUsecase.ListUserFacilities(c.Request.Context(), userID, req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h *Handler) ApproveFacility(c *gin.Context) {
+	h.transitionFacility(c, h.financingUsecase.ApproveFacility)
+}
+
+func (h *Handler) RejectFacility(c *gin.Context) {
+	h.transitionFacility(c, h.financingUsecase.RejectFacility)
+}
+
+func (h *Handler) DisburseFacility(c *gin.Context) {
+	h.transitionFacility(c, h.financingUsecase.DisburseFacility)
+}
+
+func (h *Handler) CancelFacility(c *gin.Context) {
+	h.transitionFacility(c, h.financingUsecase.CancelFacility)
+}
+
+// transitionFacility binds the facility id and transition body shared by the lifecycle endpoints.
+func (h *Handler) transitionFacility(c *gin.Context, transition func(ctx context.Context, id int64, req dto.FacilityTransitionRequest) (dto.FacilityResponse, error)) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.Error(domain.ValidationError("INVALID_FACILITY_ID", "invalid facility id"))
+		return
+	}
+
+	var req dto.FacilityTransitionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(invalidRequest(err))
+		return
+	}
+
+	resp, err := transition(c.Request.Context(), id, req)
+	if err != nil {
+		c.Error(err)
 		return
 	}
 
 	c.JSON(http.StatusOK, resp)
 }
+
+func (h *Handler) RecordPayment(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.Error(domain.ValidationError("INVALID_FACILITY_ID", "invalid facility id"))
+		return
+	}
+
+	var req dto.PaymentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(invalidRequest(err))
+		return
+	}
+
+	resp, err := h.financingUsecase.RecordPayment(c.Request.Context(), id, req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, resp)
+}
+
+func (h *Handler) QuoteSettlement(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.Error(domain.ValidationError("INVALID_FACILITY_ID", "invalid facility id"))
+		return
+	}
+
+	var req dto.SettlementQuoteRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.Error(invalidRequest(err))
+		return
+	}
+
+	resp, err := h.financingUsecase.QuoteSettlement(c.Request.Context(), id, req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h *Handler) SettleFacility(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.Error(domain.ValidationError("INVALID_FACILITY_ID", "invalid facility id"))
+		return
+	}
+
+	var req dto.SettlementRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(invalidRequest(err))
+		return
+	}
+
+	resp, err := h.financingUsecase.SettleFacility(c.Request.Context(), id, req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, resp)
+}
+
+func (h *Handler) GetCollectibility(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.Error(domain.ValidationError("INVALID_FACILITY_ID", "invalid facility id"))
+		return
+	}
+
+	var req dto.CollectibilityRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.Error(invalidRequest(err))
+		return
+	}
+
+	resp, err := h.financingUsecase.GetCollectibility(c.Request.Context(), id, req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h *Handler) RunCollectibilityBatch(c *gin.Context) {
+	var req dto.CollectibilityBatchRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.Error(invalidRequest(err))
+			return
+		}
+	}
+
+	resp, err := h.financingUsecase.RunCollectibilityBatch(c.Request.Context(), req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h *Handler) RestructureFacility(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.Error(domain.ValidationError("INVALID_FACILITY_ID", "invalid facility id"))
+		return
+	}
+
+	var req dto.RestructureRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(invalidRequest(err))
+		return
+	}
+
+	resp, err := h.financingUsecase.RestructureFacility(c.Request.Context(), id, req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, resp)
+}
@@ -0,0 +1,77 @@
+package http
+
+import (
+	"mime"
+	"net/http"
+
+	"github.com/elokanugrah/go-financing-btpns/internal/dto"
+	"github.com/gin-gonic/gin"
+)
+
+// maxHolidayFileSize bounds the holiday files accepted for import.
+const maxHolidayFileSize = 1 << 20
+
+// holidayFormats maps the content types a holiday file can be imported from to its format.
+var holidayFormats = map[string]string{
+	"text/csv":      dto.HolidayFormatCSV,
+	"text/calendar": dto.HolidayFormatICS,
+}
+
+func (h *Handler) ListHolidays(c *gin.Context) {
+	var req dto.ListHolidaysRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.Error(invalidRequest(err))
+		return
+	}
+
+	resp, err := h.holidayUsecase.ListHolidays(c.Request.Context(), req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// CreateHolidays adds one holiday from a JSON body, or imports a whole text/csv or
+// text/calendar file sent as the body.
+func (h *Handler) CreateHolidays(c *gin.Context) {
+	mediaType, _, _ := mime.ParseMediaType(c.ContentType())
+	if mediaType == "" || mediaType == "application/json" {
+		var req dto.HolidayRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.Error(invalidRequest(err))
+			return
+		}
+
+		resp, err := h.holidayUsecase.SaveHoliday(c.Request.Context(), req)
+		if err != nil {
+			c.Error(err)
+			return
+		}
+
+		c.JSON(http.StatusCreated, resp)
+		return
+	}
+
+	req := dto.HolidayImportRequest{
+		Format: holidayFormats[mediaType],
+		File:   http.MaxBytesReader(c.Writer, c.Request.Body, maxHolidayFileSize),
+	}
+	resp, err := h.holidayUsecase.ImportHolidays(c.Request.Context(), req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, resp)
+}
+
+func (h *Handler) DeleteHoliday(c *gin.Context) {
+	if err := h.holidayUsecase.DeleteHoliday(c.Request.Context(), c.Param("date")); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -4,9 +4,36 @@ import "github.com/gin-gonic/gin"
 
 func SetupRouter(h *Handler) *gin.Engine {
 	router := gin.Default()
+	router.Use(ErrorHandler())
 
 	router.POST("/calculate-installments", h.Calculate)
 	router.POST("/submit-financing", h.SubmitFinancing)
+	router.POST("/financing/eligibility", h.CheckEligibility)
+	router.GET("/facilities/:id", h.GetFacility)
+	router.POST("/facilities/:id/approve", h.ApproveFacility)
+	router.POST("/facilities/:id/reject", h.RejectFacility)
+	router.POST("/facilities/:id/disburse", h.DisburseFacility)
+	router.POST("/facilities/:id/cancel", h.CancelFacility)
+	router.POST("/facilities/:id/payments", h.RecordPayment)
+	router.GET("/facilities/:id/settlement-quote", h.QuoteSettlement)
+	router.POST("/facilities/:id/settlement", h.SettleFacility)
+	router.POST("/facilities/:id/restructure", h.RestructureFacility)
+	router.GET("/facilities/:id/collectibility", h.GetCollectibility)
+	router.POST("/users", h.CreateUser)
+	router.GET("/users", h.SearchUsers)
+	router.GET("/users/:id", h.GetUser)
+	router.PATCH("/users/:id", h.UpdateUser)
+	router.POST("/users/:id/kyc", h.UpdateKYC)
+	router.GET("/users/:id/facilities", h.ListUserFacilities)
+	router.POST("/facility-limits", h.GrantLimit)
+	router.GET("/facility-limits/:id", h.GetLimit)
+	router.POST("/facility-limits/:id/top-up", h.TopUpLimit)
+	router.POST("/facility-limits/:id/reduce", h.ReduceLimit)
+	router.POST("/facility-limits/:id/expire", h.ExpireLimit)
+	router.POST("/admin/collectibility/batch", h.RunCollectibilityBatch)
+	router.GET("/admin/holidays", h.ListHolidays)
+	router.POST("/admin/holidays", h.CreateHolidays)
+	router.DELETE("/admin/holidays/:date", h.DeleteHoliday)
 
 	return router
 }
@@ -0,0 +1,105 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// Collectibility is the OJK quality classification (kolektibilitas) of a facility, 1 to 5.
+type Collectibility int
+
+const (
+	CollectibilityCurrent        Collectibility = 1 // Lancar
+	CollectibilitySpecialMention Collectibility = 2 // Dalam Perhatian Khusus
+	CollectibilitySubstandard    Collectibility = 3 // Kurang Lancar
+	CollectibilityDoubtful       Collectibility = 4 // Diragukan
+	CollectibilityLoss           Collectibility = 5 // Macet
+)
+
+// CollectibilityForDPD maps days past due to the collectibility bucket:
+// 0 is Kol 1, 1-90 Kol 2, 91-120 Kol 3, 121-180 Kol 4 and more than 180 Kol 5.
+func CollectibilityForDPD(dpd int) Collectibility {
+	switch {
+	case dpd <= 0:
+		return CollectibilityCurrent
+	case dpd <= 90:
+		return CollectibilitySpecialMention
+	case dpd <= 120:
+		return CollectibilitySubstandard
+	case dpd <= 180:
+		return CollectibilityDoubtful
+	default:
+		return CollectibilityLoss
+	}
+}
+
+// Label returns the OJK name of the bucket.
+func (c Collectibility) Label() string {
+	switch c {
+	case CollectibilityCurrent:
+		return "Lancar"
+	case CollectibilitySpecialMention:
+		return "Dalam Perhatian Khusus"
+	case CollectibilitySubstandard:
+		return "Kurang Lancar"
+	case CollectibilityDoubtful:
+		return "Diragukan"
+	case CollectibilityLoss:
+		return "Macet"
+	default:
+		return ""
+	}
+}
+
+// Aging is the delinquency of a facility on AsOf.
+type Aging struct {
+	UserFacilityID      int64
+	AsOf                time.Time
+	DaysPastDue         int
+	Collectibility      Collectibility
+	OverdueInstallments int
+	OverdueAmount       Money
+	OldestDueDate       *time.Time // due date of the oldest overdue installment
+}
+
+// AgeSchedule computes the days past due of a schedule on asOf, counted from the oldest installment
+// due before asOf that was not closed by then. paidAsOf holds, per detail id, what was paid on the
+// installment up to asOf. An installment is closed once its PaidAt is on or before asOf. An
+// installment is only overdue after the last day dates lets it be paid on.
+func AgeSchedule(details []UserFacilityDetail, paidAsOf map[int64]Money, asOf time.Time, dates DueDatePolicy) Aging {
+	aging := Aging{AsOf: asOf}
+	var oldestPayableUntil time.Time
+	for _, d := range details {
+		payableUntil := dates.PayableUntil(d.DueDate)
+		if !payableUntil.Before(asOf) {
+			continue
+		}
+		if d.PaidAt != nil && !d.PaidAt.After(asOf) {
+			continue
+		}
+		unpaid := d.InstallmentAmount - paidAsOf[d.DetailID]
+		if unpaid <= 0 {
+			continue
+		}
+
+		aging.OverdueInstallments++
+		aging.OverdueAmount += unpaid
+		if aging.OldestDueDate == nil || d.DueDate.Before(*aging.OldestDueDate) {
+			due := d.DueDate
+			aging.OldestDueDate = &due
+			oldestPayableUntil = payableUntil
+		}
+	}
+
+	if aging.OldestDueDate != nil {
+		aging.DaysPastDue = int(asOf.Sub(oldestPayableUntil).Hours() / 24)
+	}
+	aging.Collectibility = CollectibilityForDPD(aging.DaysPastDue)
+	return aging
+}
+
+// CollectibilityRepository keeps the classification of every facility per as-of date.
+type CollectibilityRepository interface {
+	// Save stores the aging, replacing an earlier one of the same facility and date.
+	Save(ctx context.Context, a Aging) error
+}
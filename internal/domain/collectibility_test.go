@@ -0,0 +1,87 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/elokanugrah/go-financing-btpns/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCollectibilityForDPD(t *testing.T) {
+	tests := []struct {
+		dpd  int
+		want domain.Collectibility
+	}{
+		{0, domain.CollectibilityCurrent},
+		{1, domain.CollectibilitySpecialMention},
+		{90, domain.CollectibilitySpecialMention},
+		{91, domain.CollectibilitySubstandard},
+		{120, domain.CollectibilitySubstandard},
+		{121, domain.CollectibilityDoubtful},
+		{180, domain.CollectibilityDoubtful},
+		{181, domain.CollectibilityLoss},
+		{1000, domain.CollectibilityLoss},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, domain.CollectibilityForDPD(tt.dpd), "dpd %d", tt.dpd)
+	}
+}
+
+func TestAgeSchedule(t *testing.T) {
+	start := time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC)
+	paidOn := func(y int, m time.Month, d int) *time.Time {
+		at := time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+		return &at
+	}
+	details := []domain.UserFacilityDetail{
+		{DetailID: 1, DueDate: start.AddDate(0, 1, 0), InstallmentAmount: domain.NewMoney(1000), PaidAmount: domain.NewMoney(1000), PaidAt: paidOn(2025, 2, 10)},
+		{DetailID: 2, DueDate: start.AddDate(0, 2, 0), InstallmentAmount: domain.NewMoney(1000), PaidAmount: domain.NewMoney(1000), PaidAt: paidOn(2025, 4, 20)},
+		{DetailID: 3, DueDate: start.AddDate(0, 3, 0), InstallmentAmount: domain.NewMoney(1000), PaidAmount: domain.NewMoney(300)},
+		{DetailID: 4, DueDate: start.AddDate(0, 4, 0), InstallmentAmount: domain.NewMoney(1000)},
+	}
+	paid := map[int64]domain.Money{1: domain.NewMoney(1000), 2: domain.NewMoney(1000), 3: domain.NewMoney(300)}
+
+	t.Run("nothing overdue on a due date", func(t *testing.T) {
+		aging := domain.AgeSchedule(details, map[int64]domain.Money{}, start.AddDate(0, 1, 0), domain.DueDatePolicy{})
+
+		assert.Equal(t, 0, aging.DaysPastDue)
+		assert.Equal(t, domain.CollectibilityCurrent, aging.Collectibility)
+		assert.Nil(t, aging.OldestDueDate)
+	})
+
+	t.Run("counts from the oldest installment not paid by the as-of date", func(t *testing.T) {
+		// Installment 2 was only paid on 20 April, so on 15 April it is 36 days late.
+		paidByApril := map[int64]domain.Money{1: domain.NewMoney(1000), 3: domain.NewMoney(300)}
+
+		aging := domain.AgeSchedule(details, paidByApril, time.Date(2025, 4, 15, 0, 0, 0, 0, time.UTC), domain.DueDatePolicy{})
+
+		assert.Equal(t, 36, aging.DaysPastDue)
+		assert.Equal(t, domain.CollectibilitySpecialMention, aging.Collectibility)
+		assert.Equal(t, 2, aging.OverdueInstallments)
+		assert.Equal(t, domain.NewMoney(1700), aging.OverdueAmount)
+	})
+
+	t.Run("later payments close the oldest installments", func(t *testing.T) {
+		aging := domain.AgeSchedule(details, paid, time.Date(2025, 8, 20, 0, 0, 0, 0, time.UTC), domain.DueDatePolicy{})
+
+		assert.Equal(t, 2, aging.OverdueInstallments)
+		assert.Equal(t, time.Date(2025, 4, 10, 0, 0, 0, 0, time.UTC), *aging.OldestDueDate)
+		assert.Equal(t, 132, aging.DaysPastDue)
+		assert.Equal(t, domain.CollectibilityDoubtful, aging.Collectibility)
+	})
+
+	t.Run("counts from the next business day when the due date is a holiday", func(t *testing.T) {
+		// 17 August 2025 is a Sunday and the Monday after it a holiday, so it can be paid until Tuesday.
+		dates := domain.DueDatePolicy{BusinessDaysOnly: true, Holidays: domain.HolidayList{"2025-08-18": "Cuti Bersama"}}
+		due := []domain.UserFacilityDetail{{DetailID: 9, DueDate: time.Date(2025, 8, 17, 0, 0, 0, 0, time.UTC), InstallmentAmount: domain.NewMoney(1000)}}
+
+		aging := domain.AgeSchedule(due, map[int64]domain.Money{}, time.Date(2025, 8, 19, 0, 0, 0, 0, time.UTC), dates)
+		assert.Equal(t, 0, aging.OverdueInstallments)
+
+		aging = domain.AgeSchedule(due, map[int64]domain.Money{}, time.Date(2025, 8, 22, 0, 0, 0, 0, time.UTC), dates)
+		assert.Equal(t, 3, aging.DaysPastDue)
+		assert.Equal(t, due[0].DueDate, *aging.OldestDueDate)
+	})
+}
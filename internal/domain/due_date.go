@@ -0,0 +1,59 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// DueDatePolicy places the installment due dates of a schedule. The zero DueDatePolicy keeps the
+// day of the month of the start date, clamped to the last day of shorter months.
+type DueDatePolicy struct {
+	// DueDay fixes the day of the month installments fall due on, 0 keeps the start date's day.
+	DueDay int
+	// BusinessDaysOnly moves due dates on a weekend or holiday to the next business day.
+	BusinessDaysOnly bool
+	// Holidays are the public holidays skipped when BusinessDaysOnly is set, nil only skips weekends.
+	Holidays HolidayCalendar
+}
+
+func (p DueDatePolicy) Validate() error {
+	if p.DueDay < 0 || p.DueDay > 31 {
+		return fmt.Errorf("due day must be between 1 and 31, or 0 for the start date's day")
+	}
+	return nil
+}
+
+// DueDate returns the due date of the installment period months after startDate. Unlike
+// time.AddDate it never spills into the following month: Jan 31 plus one month is Feb 28 (or 29).
+func (p DueDatePolicy) DueDate(startDate time.Time, period int) time.Time {
+	day := p.DueDay
+	if day == 0 {
+		day = startDate.Day()
+	}
+
+	// The first of the target month is always safe to normalize
+	month := time.Date(startDate.Year(), startDate.Month()+time.Month(period), 1,
+		startDate.Hour(), startDate.Minute(), startDate.Second(), startDate.Nanosecond(), startDate.Location())
+	if last := daysIn(month); day > last {
+		day = last
+	}
+	return p.PayableUntil(month.AddDate(0, 0, day-1))
+}
+
+// PayableUntil returns the last day an installment due on due can be paid without being late.
+// With BusinessDaysOnly a due date that became a holiday after the schedule was made still
+// gives the customer until the next business day.
+func (p DueDatePolicy) PayableUntil(due time.Time) time.Time {
+	if !p.BusinessDaysOnly {
+		return due
+	}
+	for !IsBusinessDay(due, p.Holidays) {
+		due = due.AddDate(0, 0, 1)
+	}
+	return due
+}
+
+// daysIn returns the number of days in the month of t.
+func daysIn(t time.Time) int {
+	return time.Date(t.Year(), t.Month()+1, 0, 0, 0, 0, 0, t.Location()).Day()
+}
@@ -0,0 +1,50 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/elokanugrah/go-financing-btpns/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestDueDatePolicy_DueDate(t *testing.T) {
+	holidays := domain.HolidayList{"2025-12-25": "Hari Raya Natal", "2025-12-26": "Cuti Bersama Natal"}
+
+	tests := []struct {
+		name   string
+		policy domain.DueDatePolicy
+		start  time.Time
+		period int
+		want   time.Time
+	}{
+		{"keeps the start date's day", domain.DueDatePolicy{}, date(2025, 8, 10), 1, date(2025, 9, 10)},
+		{"clamps to the end of February", domain.DueDatePolicy{}, date(2025, 1, 31), 1, date(2025, 2, 28)},
+		{"clamps to a leap day", domain.DueDatePolicy{}, date(2024, 1, 31), 1, date(2024, 2, 29)},
+		{"returns to the start date's day after a short month", domain.DueDatePolicy{}, date(2025, 1, 31), 2, date(2025, 3, 31)},
+		{"crosses the year", domain.DueDatePolicy{}, date(2025, 11, 30), 3, date(2026, 2, 28)},
+		{"uses a fixed due day", domain.DueDatePolicy{DueDay: 25}, date(2025, 1, 31), 1, date(2025, 2, 25)},
+		{"clamps a fixed due day", domain.DueDatePolicy{DueDay: 31}, date(2025, 3, 5), 1, date(2025, 4, 30)},
+		{"ignores weekends unless asked", domain.DueDatePolicy{}, date(2025, 4, 30), 1, date(2025, 5, 30)},
+		{"moves a Sunday to Monday", domain.DueDatePolicy{BusinessDaysOnly: true}, date(2025, 7, 31), 1, date(2025, 9, 1)},
+		{"moves a weekend into the next month", domain.DueDatePolicy{DueDay: 31, BusinessDaysOnly: true}, date(2025, 4, 1), 1, date(2025, 6, 2)},
+		{"skips holidays and the weekend after them", domain.DueDatePolicy{BusinessDaysOnly: true, Holidays: holidays}, date(2025, 11, 25), 1, date(2025, 12, 29)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.policy.DueDate(tt.start, tt.period))
+		})
+	}
+}
+
+func TestDueDatePolicy_Validate(t *testing.T) {
+	assert.NoError(t, domain.DueDatePolicy{}.Validate())
+	assert.NoError(t, domain.DueDatePolicy{DueDay: 31}.Validate())
+	assert.Error(t, domain.DueDatePolicy{DueDay: 32}.Validate())
+	assert.Error(t, domain.DueDatePolicy{DueDay: -1}.Validate())
+}
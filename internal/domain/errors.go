@@ -0,0 +1,54 @@
+package domain
+
+import "errors"
+
+// Error kinds. Repositories and usecases return errors that match one of these with errors.Is,
+// the delivery layer uses the kind to pick the response status.
+var (
+	ErrNotFound          = errors.New("not found")
+	ErrForbidden         = errors.New("forbidden")
+	ErrInsufficientLimit = errors.New("insufficient limit")
+	ErrValidation        = errors.New("validation failed")
+	ErrConflict          = errors.New("conflict")
+)
+
+// Error is a failure with a stable, machine-readable code that clients can rely on,
+// and a human-readable message. It matches its Kind with errors.Is.
+type Error struct {
+	Kind    error
+	Code    string
+	Message string
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+func (e *Error) Unwrap() error {
+	return e.Kind
+}
+
+// NewError returns an Error of the given kind.
+func NewError(kind error, code, message string) *Error {
+	return &Error{Kind: kind, Code: code, Message: message}
+}
+
+// NotFoundError returns an ErrNotFound error.
+func NotFoundError(code, message string) *Error {
+	return NewError(ErrNotFound, code, message)
+}
+
+// ForbiddenError returns an ErrForbidden error.
+func ForbiddenError(code, message string) *Error {
+	return NewError(ErrForbidden, code, message)
+}
+
+// ValidationError returns an ErrValidation error.
+func ValidationError(code, message string) *Error {
+	return NewError(ErrValidation, code, message)
+}
+
+// ConflictError returns an ErrConflict error.
+func ConflictError(code, message string) *Error {
+	return NewError(ErrConflict, code, message)
+}
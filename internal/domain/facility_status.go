@@ -0,0 +1,75 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// FacilityStatus is the lifecycle state of a submitted facility.
+type FacilityStatus string
+
+const (
+	FacilityStatusSubmitted FacilityStatus = "SUBMITTED"
+	FacilityStatusApproved  FacilityStatus = "APPROVED"
+	FacilityStatusRejected  FacilityStatus = "REJECTED"
+	FacilityStatusDisbursed FacilityStatus = "DISBURSED"
+	FacilityStatusActive    FacilityStatus = "ACTIVE"
+	FacilityStatusPaidOff   FacilityStatus = "PAID_OFF"
+	FacilityStatusCancelled FacilityStatus = "CANCELLED"
+)
+
+// ActiveFacilityStatuses are the statuses of facilities that have been submitted and are not
+// closed yet, the ones counted against SubmissionPolicy.MaxActiveFacilities.
+var ActiveFacilityStatuses = []FacilityStatus{
+	FacilityStatusSubmitted, FacilityStatusApproved, FacilityStatusDisbursed, FacilityStatusActive,
+}
+
+// facilityTransitions lists, for every status, the statuses a facility may move to next.
+// Statuses without an entry are final.
+var facilityTransitions = map[FacilityStatus][]FacilityStatus{
+	FacilityStatusSubmitted: {FacilityStatusApproved, FacilityStatusRejected, FacilityStatusCancelled},
+	FacilityStatusApproved:  {FacilityStatusDisbursed, FacilityStatusCancelled},
+	FacilityStatusDisbursed: {FacilityStatusActive},
+	FacilityStatusActive:    {FacilityStatusPaidOff},
+}
+
+// IsValid reports whether s is a known status.
+func (s FacilityStatus) IsValid() bool {
+	switch s {
+	case FacilityStatusSubmitted, FacilityStatusApproved, FacilityStatusRejected, FacilityStatusDisbursed,
+		FacilityStatusActive, FacilityStatusPaidOff, FacilityStatusCancelled:
+		return true
+	}
+	return false
+}
+
+// CanTransitionTo reports whether a facility in status s may move to next.
+func (s FacilityStatus) CanTransitionTo(next FacilityStatus) bool {
+	for _, allowed := range facilityTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// ReleasesLimit reports whether entering s gives the reserved amount back to the facility limit.
+func (s FacilityStatus) ReleasesLimit() bool {
+	return s == FacilityStatusRejected || s == FacilityStatusCancelled
+}
+
+// FacilityStatusHistory records a single status change. FromStatus is empty for the submission.
+type FacilityStatusHistory struct {
+	HistoryID      int64
+	UserFacilityID int64
+	FromStatus     FacilityStatus
+	ToStatus       FacilityStatus
+	Actor          string
+	Reason         string
+	CreatedAt      time.Time
+}
+
+type FacilityStatusHistoryRepository interface {
+	Create(ctx context.Context, h *FacilityStatusHistory) error
+	ListByFacilityID(ctx context.Context, userFacilityID int64) ([]FacilityStatusHistory, error)
+}
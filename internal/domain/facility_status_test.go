@@ -0,0 +1,42 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/elokanugrah/go-financing-btpns/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFacilityStatus_CanTransitionTo(t *testing.T) {
+	tests := []struct {
+		from, to domain.FacilityStatus
+		want     bool
+	}{
+		{domain.FacilityStatusSubmitted, domain.FacilityStatusApproved, true},
+		{domain.FacilityStatusSubmitted, domain.FacilityStatusRejected, true},
+		{domain.FacilityStatusSubmitted, domain.FacilityStatusCancelled, true},
+		{domain.FacilityStatusSubmitted, domain.FacilityStatusDisbursed, false},
+		{domain.FacilityStatusApproved, domain.FacilityStatusDisbursed, true},
+		{domain.FacilityStatusApproved, domain.FacilityStatusCancelled, true},
+		{domain.FacilityStatusApproved, domain.FacilityStatusRejected, false},
+		{domain.FacilityStatusDisbursed, domain.FacilityStatusActive, true},
+		{domain.FacilityStatusDisbursed, domain.FacilityStatusCancelled, false},
+		{domain.FacilityStatusActive, domain.FacilityStatusPaidOff, true},
+		{domain.FacilityStatusRejected, domain.FacilityStatusApproved, false},
+		{domain.FacilityStatusCancelled, domain.FacilityStatusSubmitted, false},
+		{domain.FacilityStatusPaidOff, domain.FacilityStatusActive, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.from)+"->"+string(tt.to), func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.from.CanTransitionTo(tt.to))
+		})
+	}
+}
+
+func TestFacilityStatus_ReleasesLimit(t *testing.T) {
+	assert.True(t, domain.FacilityStatusRejected.ReleasesLimit())
+	assert.True(t, domain.FacilityStatusCancelled.ReleasesLimit())
+	assert.False(t, domain.FacilityStatusApproved.ReleasesLimit())
+	assert.False(t, domain.FacilityStatusPaidOff.ReleasesLimit())
+}
@@ -0,0 +1,43 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+)
+
+// GraceType tells what is paid during the grace months of a facility.
+type GraceType string
+
+const (
+	// GraceZero defers everything, nothing is due during the grace months.
+	GraceZero GraceType = "ZERO"
+	// GraceMarginOnly only collects the monthly margin during the grace months.
+	GraceMarginOnly GraceType = "MARGIN_ONLY"
+)
+
+// GracePeriod defers the principal of the first Months of the tenor, so seasonal businesses
+// start repaying once their income comes in. A zero GracePeriod has no grace months.
+type GracePeriod struct {
+	Months int
+	Type   GraceType
+}
+
+// Validate checks the grace period against the tenor it applies to, at least one month of the
+// tenor must be left to repay the principal.
+func (g GracePeriod) Validate(tenor int) error {
+	if g.Months < 0 {
+		return errors.New("grace_period_months must not be negative")
+	}
+	if g.Months == 0 {
+		return nil
+	}
+	if g.Months >= tenor {
+		return errors.New("grace_period_months must be shorter than the tenor")
+	}
+	switch g.Type {
+	case GraceZero, GraceMarginOnly:
+		return nil
+	default:
+		return fmt.Errorf("unknown grace_type %q", g.Type)
+	}
+}
@@ -0,0 +1,228 @@
+package domain
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Holiday is a bank holiday, a day no installment falls due on when due dates are kept to business days.
+type Holiday struct {
+	Date      time.Time
+	Name      string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// maxHolidayNameLength matches the holidays.name column.
+const maxHolidayNameLength = 255
+
+// ValidateHolidayName checks the name of a holiday, which is required and fits the holidays table.
+func ValidateHolidayName(name string) error {
+	if name == "" {
+		return ValidationError("INVALID_NAME", "name is required")
+	}
+	if len(name) > maxHolidayNameLength {
+		return ValidationError("INVALID_NAME", "name must not be longer than 255 characters")
+	}
+	return nil
+}
+
+// HolidayRepository keeps the holiday calendar.
+type HolidayRepository interface {
+	// List returns the holidays of year ordered by date, every holiday when year is 0.
+	List(ctx context.Context, year int) ([]Holiday, error)
+	// Save stores the holidays, renaming those already on the calendar.
+	Save(ctx context.Context, holidays []Holiday) error
+	Delete(ctx context.Context, date time.Time) error
+}
+
+// HolidayCalendar tells which dates are public holidays.
+type HolidayCalendar interface {
+	IsHoliday(date time.Time) bool
+}
+
+// HolidayList is a HolidayCalendar of holiday names keyed by their "2006-01-02" date.
+type HolidayList map[string]string
+
+func (l HolidayList) IsHoliday(date time.Time) bool {
+	_, ok := l[date.Format("2006-01-02")]
+	return ok
+}
+
+// Holidays returns the holidays of the list ordered by date.
+func (l HolidayList) Holidays() []Holiday {
+	holidays := make([]Holiday, 0, len(l))
+	for value, name := range l {
+		date, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			continue
+		}
+		holidays = append(holidays, Holiday{Date: date, Name: name})
+	}
+	sort.Slice(holidays, func(i, j int) bool { return holidays[i].Date.Before(holidays[j].Date) })
+	return holidays
+}
+
+// Validate checks the name of every holiday of the list, reporting the first invalid one with its date.
+func (l HolidayList) Validate() error {
+	for _, h := range l.Holidays() {
+		if err := ValidateHolidayName(h.Name); err != nil {
+			return ValidationError("INVALID_HOLIDAY_FILE", h.Date.Format("2006-01-02")+": "+err.Error())
+		}
+	}
+	return nil
+}
+
+// ParseHolidayCSV reads a holiday list with one "date,name" row per holiday, dates as
+// YYYY-MM-DD. A first row whose date column reads "date" is taken as a header, and a date
+// may only be listed once.
+func ParseHolidayCSV(r io.Reader) (HolidayList, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	reader.Comment = '#'
+
+	holidays := HolidayList{}
+	for first := true; ; first = false {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return holidays, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		value := strings.TrimSpace(record[0])
+		if first && strings.EqualFold(value, "date") {
+			continue
+		}
+		date, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			line, _ := reader.FieldPos(0)
+			return nil, fmt.Errorf("line %d: invalid holiday date %q", line, value)
+		}
+		key := date.Format("2006-01-02")
+		if _, ok := holidays[key]; ok {
+			line, _ := reader.FieldPos(0)
+			return nil, fmt.Errorf("line %d: duplicate holiday date %q", line, value)
+		}
+		name := ""
+		if len(record) > 1 {
+			name = strings.TrimSpace(record[1])
+		}
+		holidays[key] = name
+	}
+}
+
+// maxHolidayEventDays bounds how many days a single calendar event may mark as holidays.
+const maxHolidayEventDays = 31
+
+// ParseHolidayICS reads the all-day events of an iCalendar (.ics) file as holidays, named after
+// their SUMMARY. An event ending after the day it starts marks every day up to its DTEND, exclusive.
+func ParseHolidayICS(r io.Reader) (HolidayList, error) {
+	lines, err := unfoldICS(r)
+	if err != nil {
+		return nil, err
+	}
+
+	holidays := HolidayList{}
+	var inEvent bool
+	var start, end time.Time
+	var name string
+	for i, line := range lines {
+		property, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		key, _, _ := strings.Cut(property, ";")
+
+		switch strings.ToUpper(key) {
+		case "BEGIN":
+			if strings.EqualFold(value, "VEVENT") {
+				inEvent, start, end, name = true, time.Time{}, time.Time{}, ""
+			}
+		case "DTSTART", "DTEND":
+			if !inEvent {
+				continue
+			}
+			date, err := parseICSDate(value)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: invalid %s %q", i+1, key, value)
+			}
+			if strings.EqualFold(key, "DTSTART") {
+				start = date
+			} else {
+				end = date
+			}
+		case "SUMMARY":
+			if inEvent {
+				name = unescapeICS(value)
+			}
+		case "END":
+			if !inEvent || !strings.EqualFold(value, "VEVENT") {
+				continue
+			}
+			inEvent = false
+			if start.IsZero() {
+				return nil, fmt.Errorf("line %d: event without DTSTART", i+1)
+			}
+			if !end.After(start) {
+				end = start.AddDate(0, 0, 1)
+			}
+			if end.After(start.AddDate(0, 0, maxHolidayEventDays)) {
+				return nil, fmt.Errorf("line %d: event %q spans more than %d days", i+1, name, maxHolidayEventDays)
+			}
+			for d := start; d.Before(end); d = d.AddDate(0, 0, 1) {
+				holidays[d.Format("2006-01-02")] = name
+			}
+		}
+	}
+	return holidays, nil
+}
+
+// unfoldICS splits an iCalendar file into its logical lines, joining the continuation lines
+// that start with a space or a tab.
+func unfoldICS(r io.Reader) ([]string, error) {
+	var lines []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return lines, scanner.Err()
+}
+
+// parseICSDate reads the date of a DATE (20250817) or DATE-TIME (20250817T000000Z) value.
+func parseICSDate(value string) (time.Time, error) {
+	if len(value) < 8 {
+		return time.Time{}, fmt.Errorf("invalid date %q", value)
+	}
+	return time.Parse("20060102", value[:8])
+}
+
+var icsUnescaper = strings.NewReplacer(`\\`, `\`, `\,`, ",", `\;`, ";", `\n`, " ", `\N`, " ")
+
+func unescapeICS(value string) string {
+	return strings.TrimSpace(icsUnescaper.Replace(value))
+}
+
+// IsBusinessDay reports whether date is neither a weekend nor a holiday of the calendar.
+// A nil calendar only skips weekends.
+func IsBusinessDay(date time.Time, calendar HolidayCalendar) bool {
+	switch date.Weekday() {
+	case time.Saturday, time.Sunday:
+		return false
+	}
+	return calendar == nil || !calendar.IsHoliday(date)
+}
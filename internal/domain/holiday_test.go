@@ -0,0 +1,89 @@
+package domain_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/elokanugrah/go-financing-btpns/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseHolidayCSV(t *testing.T) {
+	t.Run("reads dates and names after the header", func(t *testing.T) {
+		holidays, err := domain.ParseHolidayCSV(strings.NewReader("date,name\n# 2025\n2025-03-31, Hari Raya Idul Fitri\n2025-08-17,Hari Kemerdekaan\n"))
+
+		require.NoError(t, err)
+		assert.Len(t, holidays, 2)
+		assert.Equal(t, "Hari Raya Idul Fitri", holidays["2025-03-31"])
+		assert.True(t, holidays.IsHoliday(date(2025, 8, 17)))
+		assert.False(t, holidays.IsHoliday(date(2025, 8, 18)))
+	})
+
+	t.Run("rejects an invalid date", func(t *testing.T) {
+		_, err := domain.ParseHolidayCSV(strings.NewReader("2025-08-17,Hari Kemerdekaan\n17/08/2025,Hari Kemerdekaan\n"))
+
+		assert.EqualError(t, err, `line 2: invalid holiday date "17/08/2025"`)
+	})
+
+	t.Run("rejects a duplicate date", func(t *testing.T) {
+		_, err := domain.ParseHolidayCSV(strings.NewReader("2025-08-17,Hari Kemerdekaan\n2025-08-17,Hari Proklamasi\n"))
+
+		assert.EqualError(t, err, `line 2: duplicate holiday date "2025-08-17"`)
+	})
+}
+
+func TestHolidayList_Validate(t *testing.T) {
+	assert.NoError(t, domain.HolidayList{"2025-08-17": "Hari Kemerdekaan"}.Validate())
+
+	err := domain.HolidayList{"2025-08-17": "Hari Kemerdekaan", "2025-03-31": ""}.Validate()
+	assert.ErrorIs(t, err, domain.ErrValidation)
+	assert.EqualError(t, err, "2025-03-31: name is required")
+}
+
+func TestParseHolidayICS(t *testing.T) {
+	t.Run("reads all-day events and spreads multi-day ones", func(t *testing.T) {
+		ics := strings.Join([]string{
+			"BEGIN:VCALENDAR",
+			"VERSION:2.0",
+			"BEGIN:VEVENT",
+			"DTSTART;VALUE=DATE:20250817",
+			"SUMMARY:Hari Kemerdekaan Republik Indonesia",
+			"END:VEVENT",
+			"BEGIN:VEVENT",
+			"DTSTART;VALUE=DATE:20250331",
+			"DTEND;VALUE=DATE:20250402",
+			"SUMMARY:Hari Raya Idul Fitri\\, 1446 H",
+			"END:VEVENT",
+			"BEGIN:VEVENT",
+			"DTSTART:20251225T000000Z",
+			"SUMMARY:Hari Raya",
+			"  Natal",
+			"END:VEVENT",
+			"END:VCALENDAR",
+		}, "\r\n")
+
+		holidays, err := domain.ParseHolidayICS(strings.NewReader(ics))
+
+		require.NoError(t, err)
+		assert.Len(t, holidays, 4)
+		assert.Equal(t, "Hari Kemerdekaan Republik Indonesia", holidays["2025-08-17"])
+		assert.Equal(t, "Hari Raya Idul Fitri, 1446 H", holidays["2025-03-31"])
+		assert.Equal(t, "Hari Raya Idul Fitri, 1446 H", holidays["2025-04-01"])
+		assert.Equal(t, "Hari Raya Natal", holidays["2025-12-25"])
+	})
+
+	t.Run("rejects an event without a start date", func(t *testing.T) {
+		_, err := domain.ParseHolidayICS(strings.NewReader("BEGIN:VEVENT\nSUMMARY:Libur\nEND:VEVENT\n"))
+
+		assert.EqualError(t, err, "line 3: event without DTSTART")
+	})
+}
+
+func TestHolidayList_Holidays(t *testing.T) {
+	holidays := domain.HolidayList{"2025-12-25": "Hari Raya Natal", "2025-08-17": "Hari Kemerdekaan"}.Holidays()
+
+	require.Len(t, holidays, 2)
+	assert.Equal(t, date(2025, 8, 17), holidays[0].Date)
+	assert.Equal(t, "Hari Raya Natal", holidays[1].Name)
+}
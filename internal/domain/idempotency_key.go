@@ -0,0 +1,24 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// IdempotencyKey records a request a client sent with an Idempotency-Key header, so a retry
+// of the same request can be answered with the original response instead of running it twice.
+type IdempotencyKey struct {
+	UserID      int64
+	Key         string
+	RequestHash string
+	Response    []byte
+	CreatedAt   time.Time
+}
+
+type IdempotencyKeyRepository interface {
+	// Reserve stores the key and reports true, or returns the key stored earlier and false.
+	// A key reserved by a concurrent transaction is only returned once that transaction commits.
+	// It returns ErrNotFound when the user does not exist.
+	Reserve(ctx context.Context, key IdempotencyKey) (IdempotencyKey, bool, error)
+	SaveResponse(ctx context.Context, userID int64, key string, response []byte) error
+}
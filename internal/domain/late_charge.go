@@ -0,0 +1,139 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// LateChargePolicy decides the late charge (ta'widh) accrued on an installment that is not paid
+// by its due date. A zero policy charges nothing.
+type LateChargePolicy struct {
+	// GraceDays is the number of days after the due date before a charge accrues.
+	GraceDays int
+	// DailyAmount is charged for every day late after the grace days.
+	DailyAmount Money
+	// OverdueRate is the share of the overdue installment amount charged once the grace days are over.
+	OverdueRate Rate
+	// MaxAmount caps the charge of one installment, 0 for no cap.
+	MaxAmount Money
+	// MaxRate caps the charge of one installment as a share of its amount, 0 for no cap.
+	MaxRate Rate
+}
+
+func (p LateChargePolicy) Validate() error {
+	if p.GraceDays < 0 {
+		return fmt.Errorf("grace days must not be negative")
+	}
+	if p.DailyAmount < 0 || p.MaxAmount < 0 {
+		return fmt.Errorf("late charge amounts must not be negative")
+	}
+	if p.OverdueRate < 0 || p.OverdueRate > rateScale || p.MaxRate < 0 || p.MaxRate > rateScale {
+		return fmt.Errorf("late charge rates must be between 0 and 1")
+	}
+	return nil
+}
+
+// Charge returns the days an installment is late on asOf and the late charge it has accrued by
+// then. The days are counted from the last day dates lets the installment be paid on, and the
+// percentage part is taken on what is still unpaid of the installment.
+func (p LateChargePolicy) Charge(d UserFacilityDetail, asOf time.Time, dates DueDatePolicy) (int, Money) {
+	daysLate := int(asOf.Sub(dates.PayableUntil(d.DueDate)).Hours() / 24)
+	unpaid := d.Unpaid()
+	if daysLate <= p.GraceDays || unpaid <= 0 {
+		return max(daysLate, 0), 0
+	}
+
+	charge := p.DailyAmount*Money(daysLate-p.GraceDays) + unpaid.MulRat(p.OverdueRate.Rat(), RoundDown)
+	if p.MaxAmount > 0 {
+		charge = min(charge, p.MaxAmount)
+	}
+	if p.MaxRate > 0 {
+		charge = min(charge, d.InstallmentAmount.MulRat(p.MaxRate.Rat(), RoundDown))
+	}
+	return daysLate, charge
+}
+
+// Assess brings the late charges of a schedule up to date on asOf. charges holds the ledger items
+// already recorded, at most one per installment. A charge only ever grows: paying part of the
+// installment lowers the percentage part, but what was charged before is kept. Assess returns
+// the charges with new and updated items applied, and the indexes of the ones that changed.
+func (p LateChargePolicy) Assess(details []UserFacilityDetail, charges []LateCharge, asOf time.Time, dates DueDatePolicy) ([]LateCharge, []int) {
+	byDetail := make(map[int64]int, len(charges))
+	for i, c := range charges {
+		byDetail[c.DetailID] = i
+	}
+
+	var changed []int
+	for _, d := range details {
+		daysLate, amount := p.Charge(d, asOf, dates)
+		if amount <= 0 {
+			continue
+		}
+
+		i, ok := byDetail[d.DetailID]
+		if !ok {
+			charges = append(charges, LateCharge{UserFacilityID: d.UserFacilityID, DetailID: d.DetailID, DueDate: d.DueDate})
+			i = len(charges) - 1
+			byDetail[d.DetailID] = i
+		} else if amount <= charges[i].Amount {
+			continue
+		}
+
+		charges[i].Amount = amount
+		charges[i].DaysLate = daysLate
+		charges[i].AssessedOn = asOf
+		charges[i].Status = InstallmentUnpaid
+		if charges[i].PaidAmount > 0 {
+			charges[i].Status = InstallmentPartiallyPaid
+		}
+		charges[i].PaidAt = nil
+		changed = append(changed, i)
+	}
+	return charges, changed
+}
+
+// LateCharge is the ledger item holding the late charge of one installment.
+type LateCharge struct {
+	LateChargeID   int64
+	UserFacilityID int64
+	DetailID       int64
+	DueDate        time.Time // due date of the installment, charges are paid in the same order
+	DaysLate       int
+	Amount         Money
+	PaidAmount     Money
+	Status         InstallmentStatus
+	AssessedOn     time.Time  // date the amount was last brought up to date
+	PaidAt         *time.Time // set once the charge is paid in full
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+// Unpaid returns the part of the charge that has not been paid yet.
+func (c LateCharge) Unpaid() Money {
+	return c.Amount - c.PaidAmount
+}
+
+// Pay applies up to amount to the charge and returns the part it used.
+func (c *LateCharge) Pay(amount Money, at time.Time) Money {
+	applied := min(amount, c.Unpaid())
+	if applied <= 0 {
+		return 0
+	}
+
+	c.PaidAmount += applied
+	if c.Unpaid() == 0 {
+		c.Status = InstallmentPaid
+		c.PaidAt = &at
+	} else {
+		c.Status = InstallmentPartiallyPaid
+	}
+	return applied
+}
+
+type LateChargeRepository interface {
+	ListByFacilityID(ctx context.Context, userFacilityID int64) ([]LateCharge, error)
+	// Save creates the charge of an installment or updates its assessed amount.
+	Save(ctx context.Context, c *LateCharge) error
+	UpdatePayment(ctx context.Context, c LateCharge) error
+}
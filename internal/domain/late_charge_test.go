@@ -0,0 +1,106 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/elokanugrah/go-financing-btpns/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLateChargePolicy_Charge(t *testing.T) {
+	due := time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)
+	installment := domain.UserFacilityDetail{DueDate: due, InstallmentAmount: domain.NewMoney(1000000), PaidAmount: domain.NewMoney(200000)}
+
+	tests := []struct {
+		name         string
+		policy       domain.LateChargePolicy
+		detail       domain.UserFacilityDetail
+		asOf         time.Time
+		wantDaysLate int
+		wantCharge   domain.Money
+	}{
+		{name: "not due yet", policy: domain.LateChargePolicy{DailyAmount: domain.NewMoney(1000)}, detail: installment, asOf: due.AddDate(0, 0, -1), wantDaysLate: 0, wantCharge: 0},
+		{name: "fixed per day", policy: domain.LateChargePolicy{DailyAmount: domain.NewMoney(1000)}, detail: installment, asOf: due.AddDate(0, 0, 10), wantDaysLate: 10, wantCharge: domain.NewMoney(10000)},
+		{name: "within grace days", policy: domain.LateChargePolicy{GraceDays: 5, DailyAmount: domain.NewMoney(1000)}, detail: installment, asOf: due.AddDate(0, 0, 5), wantDaysLate: 5, wantCharge: 0},
+		{name: "days after the grace days", policy: domain.LateChargePolicy{GraceDays: 5, DailyAmount: domain.NewMoney(1000)}, detail: installment, asOf: due.AddDate(0, 0, 10), wantDaysLate: 10, wantCharge: domain.NewMoney(5000)},
+		{name: "percentage of the unpaid installment", policy: domain.LateChargePolicy{OverdueRate: 100}, detail: installment, asOf: due.AddDate(0, 0, 1), wantDaysLate: 1, wantCharge: domain.NewMoney(8000)},
+		{name: "capped by amount", policy: domain.LateChargePolicy{DailyAmount: domain.NewMoney(1000), MaxAmount: domain.NewMoney(25000)}, detail: installment, asOf: due.AddDate(0, 0, 40), wantDaysLate: 40, wantCharge: domain.NewMoney(25000)},
+		{name: "capped by share of the installment", policy: domain.LateChargePolicy{DailyAmount: domain.NewMoney(1000), MaxRate: 200}, detail: installment, asOf: due.AddDate(0, 0, 40), wantDaysLate: 40, wantCharge: domain.NewMoney(20000)},
+		{name: "paid installment accrues nothing", policy: domain.LateChargePolicy{DailyAmount: domain.NewMoney(1000)}, detail: domain.UserFacilityDetail{DueDate: due, InstallmentAmount: domain.NewMoney(1000), PaidAmount: domain.NewMoney(1000)}, asOf: due.AddDate(0, 0, 10), wantDaysLate: 10, wantCharge: 0},
+		{name: "zero policy", policy: domain.LateChargePolicy{}, detail: installment, asOf: due.AddDate(0, 0, 10), wantDaysLate: 10, wantCharge: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			daysLate, charge := tt.policy.Charge(tt.detail, tt.asOf, domain.DueDatePolicy{})
+
+			assert.Equal(t, tt.wantDaysLate, daysLate)
+			assert.Equal(t, tt.wantCharge, charge)
+		})
+	}
+
+	t.Run("counted from the next business day when due on a holiday", func(t *testing.T) {
+		// Due on Sunday 17 Aug 2025, followed by the Monday holiday: payable until Tuesday 19 Aug
+		dates := domain.DueDatePolicy{BusinessDaysOnly: true, Holidays: domain.HolidayList{"2025-08-18": "Cuti Bersama"}}
+		detail := domain.UserFacilityDetail{DueDate: time.Date(2025, 8, 17, 0, 0, 0, 0, time.UTC), InstallmentAmount: domain.NewMoney(1000000)}
+		policy := domain.LateChargePolicy{DailyAmount: domain.NewMoney(1000)}
+
+		daysLate, charge := policy.Charge(detail, time.Date(2025, 8, 19, 0, 0, 0, 0, time.UTC), dates)
+		assert.Equal(t, 0, daysLate)
+		assert.Equal(t, domain.Money(0), charge)
+
+		daysLate, charge = policy.Charge(detail, time.Date(2025, 8, 29, 0, 0, 0, 0, time.UTC), dates)
+		assert.Equal(t, 10, daysLate)
+		assert.Equal(t, domain.NewMoney(10000), charge)
+	})
+}
+
+func TestLateChargePolicy_Assess(t *testing.T) {
+	start := time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC)
+	policy := domain.LateChargePolicy{DailyAmount: domain.NewMoney(100)}
+	details := []domain.UserFacilityDetail{
+		{DetailID: 1, UserFacilityID: 7, DueDate: start.AddDate(0, 1, 0), InstallmentAmount: domain.NewMoney(1000)},
+		{DetailID: 2, UserFacilityID: 7, DueDate: start.AddDate(0, 2, 0), InstallmentAmount: domain.NewMoney(1000)},
+		{DetailID: 3, UserFacilityID: 7, DueDate: start.AddDate(0, 3, 0), InstallmentAmount: domain.NewMoney(1000)},
+	}
+	asOf := time.Date(2025, 3, 20, 0, 0, 0, 0, time.UTC)
+
+	t.Run("adds a charge per overdue installment", func(t *testing.T) {
+		charges, changed := policy.Assess(details, nil, asOf, domain.DueDatePolicy{})
+
+		assert.Equal(t, []int{0, 1}, changed)
+		assert.Len(t, charges, 2)
+		assert.Equal(t, int64(1), charges[0].DetailID)
+		assert.Equal(t, int64(7), charges[0].UserFacilityID)
+		assert.Equal(t, 38, charges[0].DaysLate)
+		assert.Equal(t, domain.NewMoney(3800), charges[0].Amount)
+		assert.Equal(t, domain.InstallmentUnpaid, charges[0].Status)
+		assert.Equal(t, domain.NewMoney(1000), charges[1].Amount)
+	})
+
+	t.Run("updates the recorded charges that grew", func(t *testing.T) {
+		paidAt := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+		recorded := []domain.LateCharge{
+			{LateChargeID: 50, DetailID: 1, Amount: domain.NewMoney(1900), PaidAmount: domain.NewMoney(1900), Status: domain.InstallmentPaid, PaidAt: &paidAt},
+			{LateChargeID: 51, DetailID: 2, Amount: domain.NewMoney(5000)},
+		}
+
+		charges, changed := policy.Assess(details, recorded, asOf, domain.DueDatePolicy{})
+
+		assert.Equal(t, []int{0}, changed)
+		assert.Len(t, charges, 2)
+		assert.Equal(t, domain.NewMoney(3800), charges[0].Amount)
+		assert.Equal(t, domain.InstallmentPartiallyPaid, charges[0].Status)
+		assert.Nil(t, charges[0].PaidAt)
+		assert.Equal(t, domain.NewMoney(5000), charges[1].Amount)
+	})
+}
+
+func TestLateChargePolicy_Validate(t *testing.T) {
+	assert.NoError(t, domain.LateChargePolicy{}.Validate())
+	assert.NoError(t, domain.LateChargePolicy{GraceDays: 3, DailyAmount: domain.NewMoney(1000), OverdueRate: 100, MaxAmount: domain.NewMoney(50000), MaxRate: 1000}.Validate())
+	assert.Error(t, domain.LateChargePolicy{GraceDays: -1}.Validate())
+	assert.Error(t, domain.LateChargePolicy{DailyAmount: -1}.Validate())
+	assert.Error(t, domain.LateChargePolicy{MaxRate: 10001}.Validate())
+}
@@ -0,0 +1,259 @@
+package domain
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// Money is an amount of rupiah expressed in sen (1/100 rupiah), the same precision
+// as the decimal(10, 2) columns it is stored in. Use NewMoney to build it from whole rupiah.
+type Money int64
+
+// moneyScale is the number of sen in one rupiah.
+const moneyScale = 100
+
+// RoundingMode decides how an exact result is brought back to whole sen.
+type RoundingMode int
+
+const (
+	// RoundHalfUp rounds to the nearest sen, ties away from zero.
+	RoundHalfUp RoundingMode = iota
+	// RoundHalfEven rounds to the nearest sen, ties to the even neighbour.
+	RoundHalfEven
+	// RoundDown truncates towards zero.
+	RoundDown
+	// RoundUp rounds away from zero.
+	RoundUp
+)
+
+// NewMoney returns the Money value of a whole rupiah amount.
+func NewMoney(rupiah int64) Money {
+	return Money(rupiah * moneyScale)
+}
+
+// ParseMoney parses a decimal string such as "1833333.33". Values with more than two
+// fraction digits are rejected instead of being rounded silently.
+func ParseMoney(s string) (Money, error) {
+	r, ok := new(big.Rat).SetString(strings.TrimSpace(s))
+	if !ok {
+		return 0, fmt.Errorf("invalid money value %q", s)
+	}
+	r.Mul(r, big.NewRat(moneyScale, 1))
+	if !r.IsInt() {
+		return 0, fmt.Errorf("money value %q has more than 2 decimal places", s)
+	}
+	if !r.Num().IsInt64() {
+		return 0, fmt.Errorf("money value %q is out of range", s)
+	}
+	return Money(r.Num().Int64()), nil
+}
+
+// MulRat multiplies m by an exact fraction and rounds the result with the given mode.
+func (m Money) MulRat(r *big.Rat, mode RoundingMode) Money {
+	x := new(big.Rat).Mul(new(big.Rat).SetInt64(int64(m)), r)
+	return Money(roundRat(x, mode))
+}
+
+// Div divides m into n parts and rounds the result with the given mode.
+// Dividing into zero parts gives zero.
+func (m Money) Div(n int64, mode RoundingMode) Money {
+	if n == 0 {
+		return 0
+	}
+	return Money(roundRat(big.NewRat(int64(m), n), mode))
+}
+
+// RoundTo rounds m to a multiple of unit, e.g. NewMoney(1) for whole rupiah.
+// A unit of zero or less leaves m untouched.
+func (m Money) RoundTo(unit Money, mode RoundingMode) Money {
+	if unit <= 0 {
+		return m
+	}
+	return Money(roundRat(big.NewRat(int64(m), int64(unit)), mode)) * unit
+}
+
+// String formats m as a plain decimal with two fraction digits, e.g. "1833333.33".
+func (m Money) String() string {
+	sign := ""
+	v := int64(m)
+	if v < 0 {
+		sign = "-"
+		v = -v
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, v/moneyScale, v%moneyScale)
+}
+
+// MarshalJSON encodes m as a JSON number with two fraction digits.
+func (m Money) MarshalJSON() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+// UnmarshalJSON accepts both JSON numbers and quoted decimal strings.
+func (m *Money) UnmarshalJSON(data []byte) error {
+	s := string(data)
+	if s == "null" {
+		return nil
+	}
+	if unquoted, err := strconv.Unquote(s); err == nil {
+		s = unquoted
+	}
+	v, err := ParseMoney(s)
+	if err != nil {
+		return err
+	}
+	*m = v
+	return nil
+}
+
+// Value implements driver.Valuer so Money is sent to Postgres as an exact numeric literal.
+func (m Money) Value() (driver.Value, error) {
+	return m.String(), nil
+}
+
+// Scan implements sql.Scanner for numeric columns.
+func (m *Money) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case []byte:
+		return m.scanString(string(v))
+	case string:
+		return m.scanString(v)
+	case int64:
+		*m = NewMoney(v)
+		return nil
+	case float64:
+		return m.scanString(strconv.FormatFloat(v, 'f', -1, 64))
+	case nil:
+		return errors.New("cannot scan NULL into Money")
+	default:
+		return fmt.Errorf("cannot scan %T into Money", src)
+	}
+}
+
+func (m *Money) scanString(s string) error {
+	v, err := ParseMoney(s)
+	if err != nil {
+		return err
+	}
+	*m = v
+	return nil
+}
+
+// Rate is a yearly rate expressed in basis points, e.g. 2000 for 20%.
+type Rate int64
+
+// rateScale is the number of basis points in a rate of 1 (100%).
+const rateScale = 10000
+
+// ParseRate parses a decimal fraction such as "0.2" or "0.1875" into a Rate.
+func ParseRate(s string) (Rate, error) {
+	r, ok := new(big.Rat).SetString(strings.TrimSpace(s))
+	if !ok {
+		return 0, fmt.Errorf("invalid rate value %q", s)
+	}
+	r.Mul(r, big.NewRat(rateScale, 1))
+	if !r.IsInt() || !r.Num().IsInt64() {
+		return 0, fmt.Errorf("rate value %q has more than 4 decimal places", s)
+	}
+	return Rate(r.Num().Int64()), nil
+}
+
+// Rat returns the rate as an exact fraction, e.g. 1/5 for 20%.
+func (r Rate) Rat() *big.Rat {
+	return big.NewRat(int64(r), rateScale)
+}
+
+// String formats r as a decimal fraction with four digits, e.g. "0.2000".
+func (r Rate) String() string {
+	sign := ""
+	v := int64(r)
+	if v < 0 {
+		sign = "-"
+		v = -v
+	}
+	return fmt.Sprintf("%s%d.%04d", sign, v/rateScale, v%rateScale)
+}
+
+// MarshalJSON encodes r as a JSON number.
+func (r Rate) MarshalJSON() ([]byte, error) {
+	return []byte(r.String()), nil
+}
+
+// UnmarshalJSON accepts both JSON numbers and quoted decimal strings.
+func (r *Rate) UnmarshalJSON(data []byte) error {
+	s := string(data)
+	if s == "null" {
+		return nil
+	}
+	if unquoted, err := strconv.Unquote(s); err == nil {
+		s = unquoted
+	}
+	v, err := ParseRate(s)
+	if err != nil {
+		return err
+	}
+	*r = v
+	return nil
+}
+
+// Value implements driver.Valuer.
+func (r Rate) Value() (driver.Value, error) {
+	return r.String(), nil
+}
+
+// Scan implements sql.Scanner for numeric columns.
+func (r *Rate) Scan(src interface{}) error {
+	var s string
+	switch v := src.(type) {
+	case []byte:
+		s = string(v)
+	case string:
+		s = v
+	case float64:
+		s = strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return fmt.Errorf("cannot scan %T into Rate", src)
+	}
+	v, err := ParseRate(s)
+	if err != nil {
+		return err
+	}
+	*r = v
+	return nil
+}
+
+// roundRat rounds an exact fraction to an integer using the given mode.
+func roundRat(x *big.Rat, mode RoundingMode) int64 {
+	num, den := x.Num(), x.Denom()
+	quo, rem := new(big.Int).QuoRem(num, den, new(big.Int))
+	if rem.Sign() == 0 {
+		return quo.Int64()
+	}
+
+	// away is +1 or -1: the direction that increases the magnitude of quo.
+	away := int64(num.Sign())
+	twiceRem := new(big.Int).Abs(rem)
+	twiceRem.Lsh(twiceRem, 1)
+	cmp := twiceRem.Cmp(den)
+
+	q := quo.Int64()
+	switch mode {
+	case RoundDown:
+		return q
+	case RoundUp:
+		return q + away
+	case RoundHalfEven:
+		if cmp > 0 || (cmp == 0 && q%2 != 0) {
+			return q + away
+		}
+		return q
+	default: // RoundHalfUp
+		if cmp >= 0 {
+			return q + away
+		}
+		return q
+	}
+}
@@ -0,0 +1,97 @@
+package domain_test
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/elokanugrah/go-financing-btpns/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseMoney(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    domain.Money
+		wantErr bool
+	}{
+		{input: "1833333.33", want: 183333333},
+		{input: "10000000", want: domain.NewMoney(10000000)},
+		{input: "0.5", want: 50},
+		{input: "1e7", want: domain.NewMoney(10000000)},
+		{input: "-12.30", want: -1230},
+		{input: "1833333.333", wantErr: true},
+		{input: "abc", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := domain.ParseMoney(tt.input)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestMoney_Rounding(t *testing.T) {
+	tests := []struct {
+		name   string
+		amount domain.Money
+		parts  int64
+		mode   domain.RoundingMode
+		want   domain.Money
+	}{
+		{name: "half up rounds tie away from zero", amount: 5, parts: 2, mode: domain.RoundHalfUp, want: 3},
+		{name: "half up negative", amount: -5, parts: 2, mode: domain.RoundHalfUp, want: -3},
+		{name: "half even rounds tie to even", amount: 5, parts: 2, mode: domain.RoundHalfEven, want: 2},
+		{name: "half even rounds odd tie up", amount: 7, parts: 2, mode: domain.RoundHalfEven, want: 4},
+		{name: "down truncates", amount: 1100000000, parts: 6, mode: domain.RoundDown, want: 183333333},
+		{name: "up rounds away from zero", amount: 1100000000, parts: 6, mode: domain.RoundUp, want: 183333334},
+		{name: "exact division", amount: 1200, parts: 12, mode: domain.RoundUp, want: 100},
+		{name: "zero parts gives zero", amount: 1200, parts: 0, mode: domain.RoundHalfUp, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.amount.Div(tt.parts, tt.mode))
+		})
+	}
+
+	assert.Equal(t, domain.Money(33), domain.Money(100).MulRat(big.NewRat(1, 3), domain.RoundHalfUp))
+}
+
+func TestMoney_JSON(t *testing.T) {
+	var req struct {
+		Amount domain.Money `json:"amount"`
+	}
+
+	assert.NoError(t, json.Unmarshal([]byte(`{"amount": 1833333.33}`), &req))
+	assert.Equal(t, domain.Money(183333333), req.Amount)
+
+	assert.NoError(t, json.Unmarshal([]byte(`{"amount": "500000"}`), &req))
+	assert.Equal(t, domain.NewMoney(500000), req.Amount)
+
+	assert.Error(t, json.Unmarshal([]byte(`{"amount": 0.001}`), &req))
+
+	out, err := json.Marshal(req)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"amount": 500000.00}`, string(out))
+}
+
+func TestMoney_Scan(t *testing.T) {
+	var m domain.Money
+	assert.NoError(t, m.Scan([]byte("1833333.33")))
+	assert.Equal(t, domain.Money(183333333), m)
+
+	v, err := m.Value()
+	assert.NoError(t, err)
+	assert.Equal(t, "1833333.33", v)
+
+	var r domain.Rate
+	assert.NoError(t, r.Scan([]byte("0.1875")))
+	assert.Equal(t, domain.Rate(1875), r)
+}
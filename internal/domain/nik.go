@@ -0,0 +1,84 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// nikProvinces are the province codes a NIK can start with, as assigned by Kemendagri.
+var nikProvinces = map[string]bool{
+	"11": true, "12": true, "13": true, "14": true, "15": true, "16": true, "17": true, "18": true, "19": true,
+	"21": true,
+	"31": true, "32": true, "33": true, "34": true, "35": true, "36": true,
+	"51": true, "52": true, "53": true,
+	"61": true, "62": true, "63": true, "64": true, "65": true,
+	"71": true, "72": true, "73": true, "74": true, "75": true, "76": true,
+	"81": true, "82": true,
+	"91": true, "92": true, "93": true, "94": true, "95": true, "96": true,
+}
+
+// NIK is a parsed Nomor Induk Kependudukan, the 16-digit number of an Indonesian identity card:
+// province, regency and district of registration (2 digits each), date of birth as DDMMYY with
+// 40 added to the day for women, and a 4-digit serial number.
+type NIK struct {
+	Value        string
+	ProvinceCode string
+	BirthDay     int
+	BirthMonth   time.Month
+	BirthYear    int // last two digits only
+	Female       bool
+}
+
+// ParseNIK checks the structure of a NIK: its length, its province code, that its regency and
+// district codes and serial are not zero, and that it encodes a possible date of birth.
+func ParseNIK(value string) (NIK, error) {
+	if len(value) != 16 {
+		return NIK{}, errors.New("nik must have 16 digits")
+	}
+	for _, r := range value {
+		if r < '0' || r > '9' {
+			return NIK{}, errors.New("nik must have 16 digits")
+		}
+	}
+
+	if !nikProvinces[value[0:2]] {
+		return NIK{}, fmt.Errorf("nik has an unknown province code %s", value[0:2])
+	}
+	if value[2:4] == "00" || value[4:6] == "00" {
+		return NIK{}, errors.New("nik has an invalid regency or district code")
+	}
+	if value[12:16] == "0000" {
+		return NIK{}, errors.New("nik has an invalid serial number")
+	}
+
+	n := NIK{Value: value, ProvinceCode: value[0:2]}
+	n.BirthDay = digits(value[6:8])
+	if n.BirthDay > 40 {
+		n.BirthDay -= 40
+		n.Female = true
+	}
+	n.BirthMonth = time.Month(digits(value[8:10]))
+	n.BirthYear = digits(value[10:12])
+
+	// 2000 is a leap year, so 29 February is possible for every two-digit year divisible by 4
+	if n.BirthMonth < time.January || n.BirthMonth > time.December || n.BirthDay < 1 ||
+		n.BirthDay > daysIn(time.Date(2000+n.BirthYear, n.BirthMonth, 1, 0, 0, 0, 0, time.UTC)) {
+		return NIK{}, errors.New("nik has an invalid date of birth")
+	}
+	return n, nil
+}
+
+// MatchesBirthDate reports whether the NIK encodes dateOfBirth.
+func (n NIK) MatchesBirthDate(dateOfBirth time.Time) bool {
+	return dateOfBirth.Day() == n.BirthDay && dateOfBirth.Month() == n.BirthMonth && dateOfBirth.Year()%100 == n.BirthYear
+}
+
+// digits reads a string of decimal digits.
+func digits(s string) int {
+	v := 0
+	for _, r := range s {
+		v = v*10 + int(r-'0')
+	}
+	return v
+}
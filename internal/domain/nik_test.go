@@ -0,0 +1,70 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/elokanugrah/go-financing-btpns/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseNIK(t *testing.T) {
+	t.Run("reads the date of birth of a man", func(t *testing.T) {
+		nik, err := domain.ParseNIK("3171011508900001")
+
+		require.NoError(t, err)
+		assert.Equal(t, "31", nik.ProvinceCode)
+		assert.Equal(t, 15, nik.BirthDay)
+		assert.Equal(t, time.August, nik.BirthMonth)
+		assert.Equal(t, 90, nik.BirthYear)
+		assert.False(t, nik.Female)
+		assert.True(t, nik.MatchesBirthDate(time.Date(1990, 8, 15, 0, 0, 0, 0, time.UTC)))
+		assert.False(t, nik.MatchesBirthDate(time.Date(1991, 8, 15, 0, 0, 0, 0, time.UTC)))
+	})
+
+	t.Run("reads the day of a woman with 40 added", func(t *testing.T) {
+		nik, err := domain.ParseNIK("3273015502040123")
+
+		require.NoError(t, err)
+		assert.True(t, nik.Female)
+		assert.Equal(t, 15, nik.BirthDay)
+		assert.True(t, nik.MatchesBirthDate(time.Date(2004, 2, 15, 0, 0, 0, 0, time.UTC)))
+	})
+
+	tests := []struct {
+		name    string
+		nik     string
+		wantErr string
+	}{
+		{"too short", "317101150890001", "nik must have 16 digits"},
+		{"not only digits", "31710115089O0001", "nik must have 16 digits"},
+		{"unknown province", "2071011508900001", "nik has an unknown province code 20"},
+		{"zero regency", "3100011508900001", "nik has an invalid regency or district code"},
+		{"zero district", "3171001508900001", "nik has an invalid regency or district code"},
+		{"zero serial", "3171011508900000", "nik has an invalid serial number"},
+		{"month 13", "3171011513900001", "nik has an invalid date of birth"},
+		{"day 32", "3171013208900001", "nik has an invalid date of birth"},
+		{"day 40 is neither a man nor a woman", "3171014008900001", "nik has an invalid date of birth"},
+		{"30 February", "3171017002900001", "nik has an invalid date of birth"},
+	}
+	for _, tt := range tests {
+		t.Run("Failure - "+tt.name, func(t *testing.T) {
+			_, err := domain.ParseNIK(tt.nik)
+
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestUser_CheckIdentity(t *testing.T) {
+	dob := time.Date(1990, 8, 15, 0, 0, 0, 0, time.UTC)
+	user := domain.User{NIK: "3171011508900001", DateOfBirth: &dob, Address: "Jl. Merdeka No. 1"}
+	assert.NoError(t, user.CheckIdentity())
+
+	other := dob.AddDate(0, 0, 1)
+	user.DateOfBirth = &other
+	assert.EqualError(t, user.CheckIdentity(), "date_of_birth does not match the nik")
+
+	assert.Error(t, domain.User{NIK: "3171011508900001", DateOfBirth: &dob}.CheckIdentity())
+}
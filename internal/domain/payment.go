@@ -0,0 +1,103 @@
+package domain
+
+import (
+	"context"
+	"sort"
+	"time"
+)
+
+// Payment is money received for a facility. Allocations tell which installments it paid,
+// CreditAmount is the part left after every installment was paid, held as credit on the facility.
+// A settlement that uses up credit held earlier records it as a negative CreditAmount, so Amount
+// is always the allocations plus CreditAmount.
+type Payment struct {
+	PaymentID      int64
+	UserFacilityID int64
+	Amount         Money
+	CreditAmount   Money
+	Reference      string
+	PaidAt         time.Time
+	Allocations    []PaymentAllocation
+	CreatedAt      time.Time
+}
+
+// PaymentAllocation is the part of a payment applied to a single installment, or to its late
+// charge when LateChargeID is set.
+type PaymentAllocation struct {
+	AllocationID int64
+	PaymentID    int64
+	DetailID     int64
+	LateChargeID int64
+	Amount       Money
+}
+
+// AllocatePayment applies amount to the installments due by paidAt, then to the late charges and
+// then to the installments not due yet, each oldest due date first. An installment is due once
+// the last day dates lets it be paid on is reached. It returns the allocations it made together
+// with the part of amount left once everything is paid. The paid amount and status of the
+// installments and charges are updated in place.
+func AllocatePayment(details []UserFacilityDetail, charges []LateCharge, amount Money, paidAt time.Time, dates DueDatePolicy) ([]PaymentAllocation, Money) {
+	order := make([]int, len(details))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		da, db := details[order[a]], details[order[b]]
+		if !da.DueDate.Equal(db.DueDate) {
+			return da.DueDate.Before(db.DueDate)
+		}
+		return da.DetailID < db.DetailID
+	})
+	due := sort.Search(len(order), func(i int) bool { return dates.PayableUntil(details[order[i]].DueDate).After(paidAt) })
+
+	chargeOrder := make([]int, len(charges))
+	for i := range chargeOrder {
+		chargeOrder[i] = i
+	}
+	sort.SliceStable(chargeOrder, func(a, b int) bool {
+		ca, cb := charges[chargeOrder[a]], charges[chargeOrder[b]]
+		if !ca.DueDate.Equal(cb.DueDate) {
+			return ca.DueDate.Before(cb.DueDate)
+		}
+		return ca.DetailID < cb.DetailID
+	})
+
+	var allocations []PaymentAllocation
+	remaining := amount
+	payInstallments := func(order []int) {
+		for _, i := range order {
+			if remaining <= 0 {
+				return
+			}
+			applied := details[i].Pay(remaining, paidAt)
+			if applied == 0 {
+				continue
+			}
+			allocations = append(allocations, PaymentAllocation{DetailID: details[i].DetailID, Amount: applied})
+			remaining -= applied
+		}
+	}
+
+	payInstallments(order[:due])
+	for _, i := range chargeOrder {
+		if remaining <= 0 {
+			break
+		}
+		applied := charges[i].Pay(remaining, paidAt)
+		if applied == 0 {
+			continue
+		}
+		allocations = append(allocations, PaymentAllocation{DetailID: charges[i].DetailID, LateChargeID: charges[i].LateChargeID, Amount: applied})
+		remaining -= applied
+	}
+	payInstallments(order[due:])
+
+	return allocations, remaining
+}
+
+type PaymentRepository interface {
+	// Create stores the payment together with its allocations.
+	Create(ctx context.Context, p *Payment) error
+	// PaidByDetail sums, per detail id, the installment allocations of the facility's payments made up to asOf.
+	PaidByDetail(ctx context.Context, userFacilityID int64, asOf time.Time) (map[int64]Money, error)
+}
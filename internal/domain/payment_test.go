@@ -0,0 +1,98 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/elokanugrah/go-financing-btpns/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAllocatePayment(t *testing.T) {
+	start := time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC)
+	paidAt := time.Date(2025, 3, 12, 0, 0, 0, 0, time.UTC)
+
+	schedule := func() []domain.UserFacilityDetail {
+		// Listed out of order on purpose, allocation must follow the due dates.
+		return []domain.UserFacilityDetail{
+			{DetailID: 3, DueDate: start.AddDate(0, 3, 0), InstallmentAmount: domain.NewMoney(1000), Status: domain.InstallmentUnpaid},
+			{DetailID: 1, DueDate: start.AddDate(0, 1, 0), InstallmentAmount: domain.NewMoney(1000), PaidAmount: domain.NewMoney(1000), Status: domain.InstallmentPaid},
+			{DetailID: 2, DueDate: start.AddDate(0, 2, 0), InstallmentAmount: domain.NewMoney(1000), PaidAmount: domain.NewMoney(400), Status: domain.InstallmentPartiallyPaid},
+		}
+	}
+
+	t.Run("pays the oldest unpaid installment first", func(t *testing.T) {
+		details := schedule()
+
+		allocations, credit := domain.AllocatePayment(details, nil, domain.NewMoney(800), paidAt, domain.DueDatePolicy{})
+
+		assert.Equal(t, []domain.PaymentAllocation{
+			{DetailID: 2, Amount: domain.NewMoney(600)},
+			{DetailID: 3, Amount: domain.NewMoney(200)},
+		}, allocations)
+		assert.Equal(t, domain.Money(0), credit)
+		assert.Equal(t, domain.InstallmentPaid, details[2].Status)
+		assert.Equal(t, paidAt, *details[2].PaidAt)
+		assert.Equal(t, domain.InstallmentPartiallyPaid, details[0].Status)
+		assert.Equal(t, domain.NewMoney(800), details[0].Unpaid())
+		assert.Nil(t, details[0].PaidAt)
+	})
+
+	t.Run("holds the amount left after every installment is paid as credit", func(t *testing.T) {
+		details := schedule()
+
+		allocations, credit := domain.AllocatePayment(details, nil, domain.NewMoney(2000), paidAt, domain.DueDatePolicy{})
+
+		assert.Len(t, allocations, 2)
+		assert.Equal(t, domain.NewMoney(400), credit)
+		for _, d := range details {
+			assert.Equal(t, domain.InstallmentPaid, d.Status)
+		}
+	})
+
+	t.Run("pays late charges after the due installments and before the ones not due yet", func(t *testing.T) {
+		details := schedule()
+		charges := []domain.LateCharge{
+			{LateChargeID: 9, DetailID: 2, DueDate: start.AddDate(0, 2, 0), Amount: domain.NewMoney(100)},
+		}
+
+		allocations, credit := domain.AllocatePayment(details, charges, domain.NewMoney(800), paidAt, domain.DueDatePolicy{})
+
+		assert.Equal(t, []domain.PaymentAllocation{
+			{DetailID: 2, Amount: domain.NewMoney(600)},
+			{DetailID: 2, LateChargeID: 9, Amount: domain.NewMoney(100)},
+			{DetailID: 3, Amount: domain.NewMoney(100)},
+		}, allocations)
+		assert.Equal(t, domain.Money(0), credit)
+		assert.Equal(t, domain.InstallmentPaid, charges[0].Status)
+		assert.Equal(t, paidAt, *charges[0].PaidAt)
+		assert.Equal(t, domain.NewMoney(900), details[0].Unpaid())
+	})
+
+	t.Run("an installment due on a holiday is not due before the next business day", func(t *testing.T) {
+		// Due on Sunday 17 Aug 2025, followed by the Monday holiday: payable until Tuesday 19 Aug
+		dates := domain.DueDatePolicy{BusinessDaysOnly: true, Holidays: domain.HolidayList{"2025-08-18": "Cuti Bersama"}}
+		details := []domain.UserFacilityDetail{
+			{DetailID: 4, DueDate: time.Date(2025, 8, 17, 0, 0, 0, 0, time.UTC), InstallmentAmount: domain.NewMoney(1000), Status: domain.InstallmentUnpaid},
+		}
+		charges := []domain.LateCharge{
+			{LateChargeID: 9, DetailID: 3, DueDate: time.Date(2025, 7, 17, 0, 0, 0, 0, time.UTC), Amount: domain.NewMoney(100)},
+		}
+
+		allocations, _ := domain.AllocatePayment(details, charges, domain.NewMoney(500), time.Date(2025, 8, 18, 0, 0, 0, 0, time.UTC), dates)
+
+		assert.Equal(t, []domain.PaymentAllocation{
+			{DetailID: 3, LateChargeID: 9, Amount: domain.NewMoney(100)},
+			{DetailID: 4, Amount: domain.NewMoney(400)},
+		}, allocations)
+	})
+
+	t.Run("nothing to pay", func(t *testing.T) {
+		details := []domain.UserFacilityDetail{{DetailID: 1, InstallmentAmount: domain.NewMoney(1000), PaidAmount: domain.NewMoney(1000), Status: domain.InstallmentPaid}}
+
+		allocations, credit := domain.AllocatePayment(details, nil, domain.NewMoney(50), paidAt, domain.DueDatePolicy{})
+
+		assert.Empty(t, allocations)
+		assert.Equal(t, domain.NewMoney(50), credit)
+	})
+}
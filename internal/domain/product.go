@@ -0,0 +1,32 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// CalculationMethod selects how the margin of a product is charged.
+type CalculationMethod string
+
+const (
+	// CalculationFlat charges the margin on the original amount for the whole tenor.
+	CalculationFlat CalculationMethod = "FLAT"
+	// CalculationAnnuity charges the margin on the outstanding balance (effective rate)
+	// with equal installments, so the margin part declines every period.
+	CalculationAnnuity CalculationMethod = "ANNUITY"
+)
+
+type Product struct {
+	ProductID         int64 `gorm:"primaryKey"`
+	ProductCode       string
+	Name              string
+	CalculationMethod CalculationMethod
+	// GracePeriod is offered by default when a request does not ask for its own.
+	GracePeriod GracePeriod
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+type ProductRepository interface {
+	GetByCode(ctx context.Context, productCode string) (Product, error)
+}
@@ -0,0 +1,41 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// Restructuring records a facility whose unpaid installments were replaced by a new schedule.
+// The replaced installments are kept, superseded, under FromVersion.
+type Restructuring struct {
+	RestructuringID      int64
+	UserFacilityID       int64
+	FromVersion          int
+	ToVersion            int
+	OutstandingPrincipal Money
+	RemainingMargin      Money
+	Tenor                int
+	StartDate            time.Time
+	MonthlyInstallment   Money
+	Reason               string
+	Actor                string
+	CreatedAt            time.Time
+}
+
+// Outstanding returns the unpaid principal and margin of a schedule, payments made on an
+// installment are taken to cover its margin before its principal.
+func Outstanding(details []UserFacilityDetail) (principal, margin Money) {
+	for _, d := range details {
+		unpaid := d.Unpaid()
+		if unpaid <= 0 {
+			continue
+		}
+		principal += unpaid - d.UnpaidMargin()
+		margin += d.UnpaidMargin()
+	}
+	return principal, margin
+}
+
+type RestructuringRepository interface {
+	Create(ctx context.Context, r *Restructuring) error
+}
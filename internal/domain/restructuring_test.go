@@ -0,0 +1,42 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/elokanugrah/go-financing-btpns/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOutstanding(t *testing.T) {
+	details := []domain.UserFacilityDetail{
+		{InstallmentAmount: domain.NewMoney(1200), PrincipalAmount: domain.NewMoney(1000), MarginAmount: domain.NewMoney(200), PaidAmount: domain.NewMoney(1200)},
+		// the payment covered the margin and 100 of principal
+		{InstallmentAmount: domain.NewMoney(1200), PrincipalAmount: domain.NewMoney(1000), MarginAmount: domain.NewMoney(200), PaidAmount: domain.NewMoney(300)},
+		{InstallmentAmount: domain.NewMoney(1200), PrincipalAmount: domain.NewMoney(1000), MarginAmount: domain.NewMoney(200)},
+	}
+
+	principal, margin := domain.Outstanding(details)
+
+	assert.Equal(t, domain.NewMoney(1900), principal)
+	assert.Equal(t, domain.NewMoney(200), margin)
+}
+
+func TestSchedulePolicy_Reschedule(t *testing.T) {
+	plan := domain.DefaultSchedulePolicy().Reschedule(domain.NewMoney(1900), domain.NewMoney(200), 4)
+
+	assert.Equal(t, domain.CalculationFlat, plan.Method)
+	assert.Equal(t, domain.NewMoney(200), plan.TotalMargin)
+	assert.Equal(t, domain.NewMoney(2100), plan.TotalPayment)
+	assert.Equal(t, domain.NewMoney(525), plan.MonthlyInstallment)
+	assert.Len(t, plan.Installments, 4)
+
+	var principal, total domain.Money
+	for _, in := range plan.Installments {
+		principal += in.Principal
+		total += in.Amount
+		assert.Equal(t, in.Amount, in.Principal+in.Margin)
+	}
+	assert.Equal(t, domain.NewMoney(1900), principal)
+	assert.Equal(t, domain.NewMoney(2100), total)
+	assert.Equal(t, domain.Money(0), plan.Installments[3].OutstandingAfter)
+}
@@ -0,0 +1,252 @@
+package domain
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+)
+
+// ResidualPlacement tells on which installment the rounding residual is booked.
+type ResidualPlacement string
+
+const (
+	ResidualOnLast  ResidualPlacement = "last"
+	ResidualOnFirst ResidualPlacement = "first"
+)
+
+// SchedulePolicy controls how the total payment of a facility is split into installments.
+type SchedulePolicy struct {
+	// RoundingUnit is the unit every regular installment is rounded to, e.g. NewMoney(1) for whole rupiah.
+	RoundingUnit      Money
+	ResidualPlacement ResidualPlacement
+	// DueDates places the due date of every installment.
+	DueDates DueDatePolicy
+}
+
+// DefaultSchedulePolicy rounds installments to whole rupiah and books the residual on the last one.
+func DefaultSchedulePolicy() SchedulePolicy {
+	return SchedulePolicy{RoundingUnit: NewMoney(1), ResidualPlacement: ResidualOnLast}
+}
+
+func (p SchedulePolicy) Validate() error {
+	if p.RoundingUnit < 0 {
+		return fmt.Errorf("rounding unit must not be negative")
+	}
+	if err := p.DueDates.Validate(); err != nil {
+		return err
+	}
+	switch p.ResidualPlacement {
+	case ResidualOnLast, ResidualOnFirst, "":
+		return nil
+	default:
+		return fmt.Errorf("unknown residual placement %q", p.ResidualPlacement)
+	}
+}
+
+// RegularInstallment returns the rounded installment paid on every period that does not carry the residual.
+// When the rounding unit is too large next to total / n, rounding would leave the installment
+// carrying the residual at zero or below, so the installment is kept to the sen instead.
+func (p SchedulePolicy) RegularInstallment(total Money, n int) Money {
+	exact := total.Div(int64(n), RoundHalfUp)
+	regular := exact.RoundTo(p.RoundingUnit, RoundHalfUp)
+	if regular <= 0 || total-regular*Money(n-1) <= 0 {
+		return exact
+	}
+	return regular
+}
+
+// Installments splits total into n rounded installments. The difference between the
+// rounded installments and total is put on the first or last one, so they always sum to total.
+func (p SchedulePolicy) Installments(total Money, n int) []Money {
+	if n <= 0 {
+		return nil
+	}
+
+	regular := p.RegularInstallment(total, n)
+	amounts := make([]Money, n)
+	for i := range amounts {
+		amounts[i] = regular
+	}
+
+	residual := total - regular*Money(n)
+	if p.ResidualPlacement == ResidualOnFirst {
+		amounts[0] += residual
+	} else {
+		amounts[n-1] += residual
+	}
+	return amounts
+}
+
+// Installment is one period of a repayment plan. Amount is always Principal + Margin and
+// OutstandingAfter is the principal still owed once the installment is paid.
+type Installment struct {
+	Period           int
+	Amount           Money
+	Principal        Money
+	Margin           Money
+	OutstandingAfter Money
+}
+
+// RepaymentPlan is the outcome of pricing an amount over a tenor.
+type RepaymentPlan struct {
+	Method             CalculationMethod
+	MonthlyInstallment Money
+	TotalMargin        Money
+	TotalPayment       Money
+	Installments       []Installment
+}
+
+// Plan prices amount over the tenor with the given calculation method and splits it into
+// installments under the policy. Flat installments carry the rounding residual as configured;
+// annuity installments always settle the remaining balance on the last period. With a grace
+// period the principal is only repaid over the months after it, see gracePlan.
+func (p SchedulePolicy) Plan(method CalculationMethod, tenor Tenor, amount Money, marginRate Rate, grace GracePeriod) RepaymentPlan {
+	if grace.Months > 0 {
+		return p.gracePlan(method, tenor, amount, marginRate, grace)
+	}
+
+	plan := RepaymentPlan{Method: method}
+
+	switch method {
+	case CalculationAnnuity:
+		plan.Installments = tenor.AnnuitySchedule(amount, marginRate, p.RoundingUnit)
+		for _, in := range plan.Installments {
+			plan.TotalMargin += in.Margin
+		}
+		plan.TotalPayment = amount + plan.TotalMargin
+		if len(plan.Installments) > 0 {
+			plan.MonthlyInstallment = plan.Installments[0].Amount
+		}
+	default:
+		_, totalMargin, _ := tenor.Calculate(amount, marginRate)
+		plan = p.flatPlan(amount, totalMargin, tenor.TenorValue)
+	}
+	return plan
+}
+
+// gracePlan prices a facility whose first grace.Months carry no principal. The monthly margin of
+// a grace month is the flat margin spread over the whole tenor, or for annuity the margin on the
+// full amount. It is collected during the grace months when the grace is MARGIN_ONLY and added to
+// the installments after the grace when it is ZERO, where no installment is due during the grace.
+// The principal is then repaid over the remaining months the way the method does without grace.
+func (p SchedulePolicy) gracePlan(method CalculationMethod, tenor Tenor, amount Money, marginRate Rate, grace GracePeriod) RepaymentPlan {
+	g := grace.Months
+	n := tenor.TenorValue - g
+
+	var (
+		graceMargin Money
+		rest        []Installment
+	)
+	switch method {
+	case CalculationAnnuity:
+		graceMargin = amount.MulRat(new(big.Rat).Mul(marginRate.Rat(), big.NewRat(1, 12)), RoundHalfUp)
+		rest = Tenor{TenorValue: n}.AnnuitySchedule(amount, marginRate, p.RoundingUnit)
+	default:
+		method = CalculationFlat
+		_, totalMargin, _ := tenor.Calculate(amount, marginRate)
+		graceMargin = p.RegularInstallment(totalMargin, tenor.TenorValue)
+		rest = p.flatPlan(amount, totalMargin-graceMargin*Money(g), n).Installments
+	}
+
+	plan := RepaymentPlan{Method: method}
+	if grace.Type == GraceMarginOnly {
+		for period := 1; period <= g; period++ {
+			plan.Installments = append(plan.Installments, Installment{
+				Period:           period,
+				Amount:           graceMargin,
+				Margin:           graceMargin,
+				OutstandingAfter: amount,
+			})
+		}
+	} else {
+		for i, deferred := range p.Installments(graceMargin*Money(g), n) {
+			rest[i].Amount += deferred
+			rest[i].Margin += deferred
+		}
+	}
+	for _, in := range rest {
+		in.Period += g
+		plan.Installments = append(plan.Installments, in)
+	}
+
+	for _, in := range plan.Installments {
+		plan.TotalMargin += in.Margin
+	}
+	plan.TotalPayment = amount + plan.TotalMargin
+	if len(rest) > 0 {
+		plan.MonthlyInstallment = rest[0].Amount
+	}
+	return plan
+}
+
+// Reschedule spreads an outstanding principal and margin over n new installments the flat way,
+// without adding margin, as done when a facility is restructured.
+func (p SchedulePolicy) Reschedule(principal, margin Money, n int) RepaymentPlan {
+	return p.flatPlan(principal, margin, n)
+}
+
+func (p SchedulePolicy) flatPlan(principal, margin Money, n int) RepaymentPlan {
+	plan := RepaymentPlan{
+		Method:       CalculationFlat,
+		TotalMargin:  margin,
+		TotalPayment: principal + margin,
+	}
+	plan.MonthlyInstallment = p.RegularInstallment(plan.TotalPayment, n)
+
+	// Principal is split the same way as the installments, the margin part is what is left.
+	amounts := p.Installments(plan.TotalPayment, n)
+	principals := clampPrincipals(p.Installments(principal, n), amounts)
+	balance := principal
+	for i, installment := range amounts {
+		balance -= principals[i]
+		plan.Installments = append(plan.Installments, Installment{
+			Period:           i + 1,
+			Amount:           installment,
+			Principal:        principals[i],
+			Margin:           installment - principals[i],
+			OutstandingAfter: balance,
+		})
+	}
+	return plan
+}
+
+// clampPrincipals caps every principal at its installment amount, so no margin part goes negative
+// when the margin is smaller than the rounding residuals, and moves what was cut to the earliest
+// installments that still have a margin part. The principals keep their sum.
+func clampPrincipals(principals, amounts []Money) []Money {
+	var cut Money
+	for i := range principals {
+		if principals[i] > amounts[i] {
+			cut += principals[i] - amounts[i]
+			principals[i] = amounts[i]
+		}
+	}
+	for i := range principals {
+		if cut <= 0 {
+			break
+		}
+		moved := min(cut, amounts[i]-principals[i])
+		principals[i] += moved
+		cut -= moved
+	}
+	return principals
+}
+
+// Details turns the plan into installment rows of the facility's current schedule version,
+// one per month after startDate, due on the dates placed by dates.
+func (plan RepaymentPlan) Details(uf UserFacility, startDate time.Time, dates DueDatePolicy) []UserFacilityDetail {
+	details := make([]UserFacilityDetail, 0, len(plan.Installments))
+	for _, in := range plan.Installments {
+		details = append(details, UserFacilityDetail{
+			UserFacilityID:    uf.UserFacilityID,
+			ScheduleVersion:   uf.ScheduleVersion,
+			DueDate:           dates.DueDate(startDate, in.Period),
+			InstallmentAmount: in.Amount,
+			PrincipalAmount:   in.Principal,
+			MarginAmount:      in.Margin,
+			OutstandingAfter:  in.OutstandingAfter,
+			Status:            InstallmentUnpaid,
+		})
+	}
+	return details
+}
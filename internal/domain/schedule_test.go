@@ -0,0 +1,262 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/elokanugrah/go-financing-btpns/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSchedulePolicy_Installments(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy domain.SchedulePolicy
+		total  domain.Money
+		tenor  int
+		want   []domain.Money
+	}{
+		{
+			name:   "residual on last installment, whole rupiah",
+			policy: domain.DefaultSchedulePolicy(),
+			total:  domain.NewMoney(11000000),
+			tenor:  6,
+			want: []domain.Money{
+				domain.NewMoney(1833333), domain.NewMoney(1833333), domain.NewMoney(1833333),
+				domain.NewMoney(1833333), domain.NewMoney(1833333), domain.NewMoney(1833335),
+			},
+		},
+		{
+			name:   "residual on first installment",
+			policy: domain.SchedulePolicy{RoundingUnit: domain.NewMoney(1), ResidualPlacement: domain.ResidualOnFirst},
+			total:  domain.NewMoney(100),
+			tenor:  3,
+			want:   []domain.Money{domain.NewMoney(34), domain.NewMoney(33), domain.NewMoney(33)},
+		},
+		{
+			name:   "rounding to thousands books the residual on the last installment",
+			policy: domain.SchedulePolicy{RoundingUnit: domain.NewMoney(1000), ResidualPlacement: domain.ResidualOnLast},
+			total:  domain.NewMoney(5750000),
+			tenor:  12,
+			want: []domain.Money{
+				domain.NewMoney(479000), domain.NewMoney(479000), domain.NewMoney(479000), domain.NewMoney(479000),
+				domain.NewMoney(479000), domain.NewMoney(479000), domain.NewMoney(479000), domain.NewMoney(479000),
+				domain.NewMoney(479000), domain.NewMoney(479000), domain.NewMoney(479000), domain.NewMoney(481000),
+			},
+		},
+		{
+			name:   "rounding unit too large for the installment keeps sen precision",
+			policy: domain.SchedulePolicy{RoundingUnit: domain.NewMoney(1000), ResidualPlacement: domain.ResidualOnLast},
+			total:  domain.NewMoney(2000),
+			tenor:  3,
+			// 1000 each is a residual of -1000, leaving the last installment at 0
+			want: []domain.Money{66667, 66667, 66666},
+		},
+		{
+			name:   "installment rounding to zero keeps sen precision",
+			policy: domain.SchedulePolicy{RoundingUnit: domain.NewMoney(1000), ResidualPlacement: domain.ResidualOnFirst},
+			total:  domain.NewMoney(1000),
+			tenor:  3,
+			// 333.33 rounds to 0, which would put the whole total on the first installment
+			want: []domain.Money{33334, 33333, 33333},
+		},
+		{
+			name:   "zero policy keeps sen precision",
+			policy: domain.SchedulePolicy{},
+			total:  domain.NewMoney(5750000),
+			tenor:  12,
+			want: []domain.Money{
+				47916667, 47916667, 47916667, 47916667, 47916667, 47916667,
+				47916667, 47916667, 47916667, 47916667, 47916667, 47916663,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.policy.Installments(tt.total, tt.tenor)
+
+			assert.Equal(t, tt.want, got)
+
+			var sum domain.Money
+			for _, amount := range got {
+				sum += amount
+			}
+			assert.Equal(t, tt.total, sum, "Jumlah angsuran harus sama dengan total pembayaran")
+		})
+	}
+}
+
+func TestSchedulePolicy_Plan(t *testing.T) {
+	tenor := domain.Tenor{TenorValue: 12}
+	amount := domain.NewMoney(12000000)
+
+	t.Run("flat plan keeps Calculate totals", func(t *testing.T) {
+		plan := domain.DefaultSchedulePolicy().Plan(domain.CalculationFlat, tenor, amount, 2000, domain.GracePeriod{})
+
+		assert.Equal(t, domain.CalculationFlat, plan.Method)
+		assert.Equal(t, domain.NewMoney(2400000), plan.TotalMargin)
+		assert.Equal(t, domain.NewMoney(14400000), plan.TotalPayment)
+		assert.Equal(t, domain.NewMoney(1200000), plan.MonthlyInstallment)
+		assert.Len(t, plan.Installments, 12)
+
+		first := plan.Installments[0]
+		assert.Equal(t, domain.NewMoney(1000000), first.Principal)
+		assert.Equal(t, domain.NewMoney(200000), first.Margin)
+		assert.Equal(t, domain.NewMoney(11000000), first.OutstandingAfter)
+		assert.Equal(t, domain.Money(0), plan.Installments[11].OutstandingAfter)
+	})
+
+	t.Run("flat split keeps principal and margin totals when rounding", func(t *testing.T) {
+		plan := domain.DefaultSchedulePolicy().Plan(domain.CalculationFlat, domain.Tenor{TenorValue: 6}, domain.NewMoney(10000000), 2000, domain.GracePeriod{})
+
+		var principal, margin domain.Money
+		for _, in := range plan.Installments {
+			principal += in.Principal
+			margin += in.Margin
+			assert.Equal(t, in.Principal+in.Margin, in.Amount)
+		}
+		assert.Equal(t, domain.NewMoney(10000000), principal)
+		assert.Equal(t, plan.TotalMargin, margin)
+
+		last := plan.Installments[5]
+		assert.Equal(t, domain.NewMoney(1833335), last.Amount)
+		assert.Equal(t, domain.NewMoney(1666665), last.Principal)
+		assert.Equal(t, domain.NewMoney(166670), last.Margin)
+	})
+
+	t.Run("flat split never gives an installment a negative margin", func(t *testing.T) {
+		// Rp101 over 3 months is paid 34, 34, 33 while Rp100 of principal splits 33, 33, 34
+		plan := domain.DefaultSchedulePolicy().Reschedule(domain.NewMoney(100), domain.NewMoney(1), 3)
+
+		var principal, margin domain.Money
+		for _, in := range plan.Installments {
+			assert.GreaterOrEqual(t, in.Margin, domain.Money(0), in.Period)
+			assert.Equal(t, in.Principal+in.Margin, in.Amount)
+			principal += in.Principal
+			margin += in.Margin
+		}
+		assert.Equal(t, domain.NewMoney(100), principal)
+		assert.Equal(t, domain.NewMoney(1), margin)
+		assert.Equal(t, []domain.Money{domain.NewMoney(34), domain.NewMoney(34), domain.NewMoney(33)},
+			[]domain.Money{plan.Installments[0].Amount, plan.Installments[1].Amount, plan.Installments[2].Amount})
+		assert.Equal(t, domain.Money(0), plan.Installments[2].OutstandingAfter)
+	})
+
+	t.Run("annuity plan rounds the regular installment and settles the balance last", func(t *testing.T) {
+		plan := domain.DefaultSchedulePolicy().Plan(domain.CalculationAnnuity, tenor, amount, 1200, domain.GracePeriod{})
+
+		assert.Equal(t, domain.CalculationAnnuity, plan.Method)
+		assert.Equal(t, domain.NewMoney(1066185), plan.MonthlyInstallment)
+		assert.Equal(t, domain.Money(79422589), plan.TotalMargin)
+		assert.Equal(t, domain.Money(1279422589), plan.TotalPayment)
+		assert.Equal(t, domain.Money(106619089), plan.Installments[11].Amount)
+
+		var sum domain.Money
+		for _, in := range plan.Installments {
+			sum += in.Amount
+		}
+		assert.Equal(t, plan.TotalPayment, sum)
+	})
+
+	t.Run("unknown method falls back to flat", func(t *testing.T) {
+		plan := domain.DefaultSchedulePolicy().Plan("", tenor, amount, 2000, domain.GracePeriod{})
+
+		assert.Equal(t, domain.CalculationFlat, plan.Method)
+	})
+}
+
+func TestSchedulePolicy_PlanWithGracePeriod(t *testing.T) {
+	tenor := domain.Tenor{TenorValue: 12}
+	amount := domain.NewMoney(12000000)
+
+	sum := func(plan domain.RepaymentPlan) (principal, total domain.Money) {
+		for _, in := range plan.Installments {
+			principal += in.Principal
+			total += in.Amount
+		}
+		return principal, total
+	}
+
+	t.Run("flat margin only collects the monthly margin during the grace months", func(t *testing.T) {
+		plan := domain.DefaultSchedulePolicy().Plan(domain.CalculationFlat, tenor, amount, 2000, domain.GracePeriod{Months: 3, Type: domain.GraceMarginOnly})
+
+		assert.Len(t, plan.Installments, 12)
+		for _, in := range plan.Installments[:3] {
+			assert.Equal(t, domain.NewMoney(200000), in.Amount)
+			assert.Equal(t, domain.Money(0), in.Principal)
+			assert.Equal(t, amount, in.OutstandingAfter)
+		}
+		assert.Equal(t, 4, plan.Installments[3].Period)
+		assert.Equal(t, domain.NewMoney(1533333), plan.MonthlyInstallment)
+		assert.Equal(t, domain.NewMoney(2400000), plan.TotalMargin)
+
+		principal, total := sum(plan)
+		assert.Equal(t, amount, principal)
+		assert.Equal(t, plan.TotalPayment, total)
+	})
+
+	t.Run("flat zero grace has no installment due and defers the margin", func(t *testing.T) {
+		plan := domain.DefaultSchedulePolicy().Plan(domain.CalculationFlat, tenor, amount, 2000, domain.GracePeriod{Months: 3, Type: domain.GraceZero})
+
+		assert.Len(t, plan.Installments, 9)
+		assert.Equal(t, 4, plan.Installments[0].Period)
+		assert.Equal(t, 12, plan.Installments[8].Period)
+		assert.Equal(t, domain.NewMoney(1600000), plan.MonthlyInstallment)
+		assert.Equal(t, domain.NewMoney(2400000), plan.TotalMargin)
+
+		principal, total := sum(plan)
+		assert.Equal(t, amount, principal)
+		assert.Equal(t, domain.NewMoney(14400000), total)
+		assert.Equal(t, domain.Money(0), plan.Installments[8].OutstandingAfter)
+	})
+
+	t.Run("annuity margin only charges the margin on the full amount during the grace months", func(t *testing.T) {
+		plan := domain.DefaultSchedulePolicy().Plan(domain.CalculationAnnuity, tenor, amount, 1200, domain.GracePeriod{Months: 3, Type: domain.GraceMarginOnly})
+
+		assert.Equal(t, domain.CalculationAnnuity, plan.Method)
+		assert.Len(t, plan.Installments, 12)
+		assert.Equal(t, domain.NewMoney(120000), plan.Installments[0].Amount)
+		assert.Equal(t, domain.NewMoney(120000), plan.Installments[3].Margin)
+		assert.Equal(t, plan.Installments[3].Amount, plan.MonthlyInstallment)
+
+		principal, total := sum(plan)
+		assert.Equal(t, amount, principal)
+		assert.Equal(t, plan.TotalPayment, total)
+	})
+}
+
+func TestGracePeriod_Validate(t *testing.T) {
+	assert.NoError(t, domain.GracePeriod{}.Validate(6))
+	assert.NoError(t, domain.GracePeriod{Months: 3, Type: domain.GraceZero}.Validate(6))
+	assert.Error(t, domain.GracePeriod{Months: -1}.Validate(6))
+	assert.Error(t, domain.GracePeriod{Months: 6, Type: domain.GraceZero}.Validate(6))
+	assert.Error(t, domain.GracePeriod{Months: 2, Type: "HALF"}.Validate(6))
+}
+
+func TestRepaymentPlan_Details(t *testing.T) {
+	startDate := time.Date(2025, 8, 10, 0, 0, 0, 0, time.UTC)
+	uf := domain.UserFacility{
+		UserFacilityID: 7,
+		Tenor:          6,
+		StartDate:      startDate,
+	}
+	plan := domain.DefaultSchedulePolicy().Plan(domain.CalculationFlat, domain.Tenor{TenorValue: 6}, domain.NewMoney(10000000), 2000, domain.GracePeriod{})
+
+	details := plan.Details(uf, uf.StartDate, domain.DueDatePolicy{})
+
+	assert.Len(t, details, 6)
+	assert.Equal(t, int64(7), details[0].UserFacilityID)
+	assert.Equal(t, startDate.AddDate(0, 1, 0), details[0].DueDate)
+	assert.Equal(t, startDate.AddDate(0, 6, 0), details[5].DueDate)
+	assert.Equal(t, domain.NewMoney(1833335), details[5].InstallmentAmount)
+	assert.Equal(t, domain.NewMoney(1666667), details[0].PrincipalAmount)
+	assert.Equal(t, domain.NewMoney(166666), details[0].MarginAmount)
+	assert.Equal(t, domain.NewMoney(8333333), details[0].OutstandingAfter)
+}
+
+func TestSchedulePolicy_Validate(t *testing.T) {
+	assert.NoError(t, domain.DefaultSchedulePolicy().Validate())
+	assert.Error(t, domain.SchedulePolicy{ResidualPlacement: "middle"}.Validate())
+	assert.Error(t, domain.SchedulePolicy{RoundingUnit: -1}.Validate())
+}
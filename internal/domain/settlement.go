@@ -0,0 +1,69 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// SettlementPolicy decides how much margin is waived when a facility is paid off early.
+type SettlementPolicy struct {
+	// MarginRebate is the share of the unearned margin, the margin of installments due after
+	// the settlement date, that is given back as rebate (muqasah), e.g. 5000 for half of it.
+	MarginRebate Rate
+}
+
+func (p SettlementPolicy) Validate() error {
+	if p.MarginRebate < 0 || p.MarginRebate > rateScale {
+		return fmt.Errorf("margin rebate must be between 0 and 1")
+	}
+	return nil
+}
+
+// SettlementQuote is the amount needed to pay a facility off on SettlementDate.
+type SettlementQuote struct {
+	SettlementDate       time.Time
+	OutstandingPrincipal Money
+	RemainingMargin      Money
+	UnearnedMargin       Money // part of RemainingMargin on installments due after SettlementDate
+	Rebate               Money
+	LateCharges          Money // unpaid late charges, they are not rebated
+	CreditApplied        Money // credit balance of the facility used towards the payoff
+	PayoffAmount         Money // OutstandingPrincipal + RemainingMargin - Rebate + LateCharges - CreditApplied
+}
+
+// Quote computes the payoff of the unpaid installments on the given date. Payments made on an
+// installment are taken to cover its margin before its principal.
+func (p SettlementPolicy) Quote(details []UserFacilityDetail, settlementDate time.Time) SettlementQuote {
+	q := SettlementQuote{SettlementDate: settlementDate}
+	for _, d := range details {
+		unpaid := d.Unpaid()
+		if unpaid <= 0 {
+			continue
+		}
+		margin := d.UnpaidMargin()
+		q.OutstandingPrincipal += unpaid - margin
+		q.RemainingMargin += margin
+		if d.DueDate.After(settlementDate) {
+			q.UnearnedMargin += margin
+		}
+	}
+
+	q.Rebate = q.UnearnedMargin.MulRat(p.MarginRebate.Rat(), RoundDown)
+	q.PayoffAmount = q.OutstandingPrincipal + q.RemainingMargin - q.Rebate
+	return q
+}
+
+// Settlement records a facility paid off early and the quote it was settled on.
+type Settlement struct {
+	SettlementID   int64
+	UserFacilityID int64
+	PaymentID      int64
+	SettlementQuote
+	Actor     string
+	CreatedAt time.Time
+}
+
+type SettlementRepository interface {
+	Create(ctx context.Context, s *Settlement) error
+}
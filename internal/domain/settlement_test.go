@@ -0,0 +1,54 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/elokanugrah/go-financing-btpns/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSettlementPolicy_Quote(t *testing.T) {
+	start := time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC)
+	details := []domain.UserFacilityDetail{
+		// paid in full, ignored
+		{DueDate: start.AddDate(0, 1, 0), InstallmentAmount: domain.NewMoney(1200), PrincipalAmount: domain.NewMoney(1000), MarginAmount: domain.NewMoney(200), PaidAmount: domain.NewMoney(1200)},
+		// due before the settlement date, partly paid: the payment covered the margin and 100 of principal
+		{DueDate: start.AddDate(0, 2, 0), InstallmentAmount: domain.NewMoney(1200), PrincipalAmount: domain.NewMoney(1000), MarginAmount: domain.NewMoney(200), PaidAmount: domain.NewMoney(300)},
+		// not due yet
+		{DueDate: start.AddDate(0, 3, 0), InstallmentAmount: domain.NewMoney(1200), PrincipalAmount: domain.NewMoney(1000), MarginAmount: domain.NewMoney(200)},
+		{DueDate: start.AddDate(0, 4, 0), InstallmentAmount: domain.NewMoney(1200), PrincipalAmount: domain.NewMoney(1000), MarginAmount: domain.NewMoney(200)},
+	}
+	settlementDate := time.Date(2025, 3, 20, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		rebate     domain.Rate
+		wantRebate domain.Money
+		wantPayoff domain.Money
+	}{
+		{name: "no rebate", rebate: 0, wantRebate: 0, wantPayoff: domain.NewMoney(3300)},
+		{name: "half of the unearned margin", rebate: 5000, wantRebate: domain.NewMoney(200), wantPayoff: domain.NewMoney(3100)},
+		{name: "all unearned margin", rebate: 10000, wantRebate: domain.NewMoney(400), wantPayoff: domain.NewMoney(2900)},
+		{name: "rebate is rounded down to the sen", rebate: 3333, wantRebate: 13332, wantPayoff: domain.NewMoney(3300) - 13332},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := domain.SettlementPolicy{MarginRebate: tt.rebate}.Quote(details, settlementDate)
+
+			assert.Equal(t, domain.NewMoney(2900), q.OutstandingPrincipal)
+			assert.Equal(t, domain.NewMoney(400), q.RemainingMargin)
+			assert.Equal(t, domain.NewMoney(400), q.UnearnedMargin)
+			assert.Equal(t, tt.wantRebate, q.Rebate)
+			assert.Equal(t, tt.wantPayoff, q.PayoffAmount)
+		})
+	}
+}
+
+func TestSettlementPolicy_Validate(t *testing.T) {
+	assert.NoError(t, domain.SettlementPolicy{MarginRebate: 0}.Validate())
+	assert.NoError(t, domain.SettlementPolicy{MarginRebate: 10000}.Validate())
+	assert.Error(t, domain.SettlementPolicy{MarginRebate: 10001}.Validate())
+	assert.Error(t, domain.SettlementPolicy{MarginRebate: -1}.Validate())
+}
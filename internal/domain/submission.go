@@ -0,0 +1,50 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// SubmissionPolicy holds the limits on new financing submissions. The zero SubmissionPolicy
+// accepts any start date and any number of active facilities.
+type SubmissionPolicy struct {
+	// StartDateMaxDaysBack is how many days before the submission day the start date may be.
+	StartDateMaxDaysBack int
+	// StartDateMaxDaysAhead is how many days after the submission day the start date may be.
+	// The start date is not checked when both bounds are 0.
+	StartDateMaxDaysAhead int
+	// MaxActiveFacilities caps the facilities a user can hold in one of ActiveFacilityStatuses, 0 for no cap.
+	MaxActiveFacilities int
+}
+
+func (p SubmissionPolicy) Validate() error {
+	if p.StartDateMaxDaysBack < 0 || p.StartDateMaxDaysAhead < 0 {
+		return fmt.Errorf("start date window must not be negative")
+	}
+	if p.MaxActiveFacilities < 0 {
+		return fmt.Errorf("max active facilities must not be negative")
+	}
+	return nil
+}
+
+// CheckStartDate reports why startDate falls outside the window around today, nil when it does not.
+func (p SubmissionPolicy) CheckStartDate(startDate, today time.Time) error {
+	if p.StartDateMaxDaysBack == 0 && p.StartDateMaxDaysAhead == 0 {
+		return nil
+	}
+	earliest := today.AddDate(0, 0, -p.StartDateMaxDaysBack)
+	latest := today.AddDate(0, 0, p.StartDateMaxDaysAhead)
+	if startDate.Before(earliest) || startDate.After(latest) {
+		return fmt.Errorf("start_date must be between %s and %s", earliest.Format("2006-01-02"), latest.Format("2006-01-02"))
+	}
+	return nil
+}
+
+// CheckActiveFacilities reports why a user holding active facilities cannot submit another one,
+// nil when they can.
+func (p SubmissionPolicy) CheckActiveFacilities(active int) error {
+	if p.MaxActiveFacilities > 0 && active >= p.MaxActiveFacilities {
+		return fmt.Errorf("user already has %d active facilities, the maximum is %d", active, p.MaxActiveFacilities)
+	}
+	return nil
+}
@@ -0,0 +1,35 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/elokanugrah/go-financing-btpns/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSubmissionPolicy_CheckStartDate(t *testing.T) {
+	today := time.Date(2025, 8, 10, 0, 0, 0, 0, time.UTC)
+	policy := domain.SubmissionPolicy{StartDateMaxDaysBack: 2, StartDateMaxDaysAhead: 30}
+
+	assert.NoError(t, policy.CheckStartDate(today.AddDate(0, 0, -2), today))
+	assert.NoError(t, policy.CheckStartDate(today.AddDate(0, 0, 30), today))
+	assert.EqualError(t, policy.CheckStartDate(today.AddDate(0, 0, -3), today), "start_date must be between 2025-08-08 and 2025-09-09")
+	assert.Error(t, policy.CheckStartDate(today.AddDate(0, 0, 31), today))
+
+	// The zero policy accepts any start date
+	assert.NoError(t, domain.SubmissionPolicy{}.CheckStartDate(today.AddDate(-1, 0, 0), today))
+}
+
+func TestSubmissionPolicy_CheckActiveFacilities(t *testing.T) {
+	assert.NoError(t, domain.SubmissionPolicy{MaxActiveFacilities: 2}.CheckActiveFacilities(1))
+	assert.EqualError(t, domain.SubmissionPolicy{MaxActiveFacilities: 2}.CheckActiveFacilities(2), "user already has 2 active facilities, the maximum is 2")
+	assert.NoError(t, domain.SubmissionPolicy{}.CheckActiveFacilities(100))
+}
+
+func TestSubmissionPolicy_Validate(t *testing.T) {
+	assert.NoError(t, domain.SubmissionPolicy{}.Validate())
+	assert.NoError(t, domain.SubmissionPolicy{StartDateMaxDaysAhead: 30, MaxActiveFacilities: 3}.Validate())
+	assert.Error(t, domain.SubmissionPolicy{StartDateMaxDaysBack: -1}.Validate())
+	assert.Error(t, domain.SubmissionPolicy{MaxActiveFacilities: -1}.Validate())
+}
@@ -2,23 +2,116 @@ package domain
 
 import (
 	"context"
+	"math/big"
 	"time"
 )
 
+// DefaultProductCode is used when a request does not name a product.
+const DefaultProductCode = "REGULAR"
+
 type Tenor struct {
 	TenorID    int64 `gorm:"primaryKey"`
 	TenorValue int
+	// IsActive tells whether the tenor can still be offered and submitted.
+	IsActive bool
+	// MarginRate is only filled when the tenor is loaded together with a product's pricing.
+	MarginRate Rate
 	CreatedAt  time.Time
 	UpdatedAt  time.Time
 }
 
-func (t Tenor) Calculate(amount float64, marginRate float64) (monthlyInstallment, totalMargin, totalPayment float64) {
-	totalMargin = (amount * marginRate * float64(t.TenorValue)) / 12
+// Calculate applies a flat yearly margin rate over the tenor. The margin and the
+// monthly installment are computed exactly and rounded half up to whole sen.
+func (t Tenor) Calculate(amount Money, marginRate Rate) (monthlyInstallment, totalMargin, totalPayment Money) {
+	months := big.NewRat(int64(t.TenorValue), 12)
+	totalMargin = amount.MulRat(new(big.Rat).Mul(marginRate.Rat(), months), RoundHalfUp)
 	totalPayment = amount + totalMargin
-	monthlyInstallment = totalPayment / float64(t.TenorValue)
+	monthlyInstallment = totalPayment.Div(int64(t.TenorValue), RoundHalfUp)
 	return
 }
 
+// CalculateAnnuity charges the yearly margin rate on the outstanding balance with equal
+// monthly installments. It keeps the (monthly, margin, total) contract of Calculate;
+// AnnuitySchedule gives the per-period breakdown behind the totals.
+func (t Tenor) CalculateAnnuity(amount Money, marginRate Rate) (monthlyInstallment, totalMargin, totalPayment Money) {
+	installments := t.AnnuitySchedule(amount, marginRate, 0)
+	if len(installments) == 0 {
+		return 0, 0, amount
+	}
+	for _, in := range installments {
+		totalMargin += in.Margin
+	}
+	return installments[0].Amount, totalMargin, amount + totalMargin
+}
+
+// AnnuitySchedule returns the annuity breakdown of amount over the tenor. The monthly rate
+// is marginRate / 12 and the regular installment P*r / (1 - (1+r)^-n) is rounded half up
+// to roundingUnit. Each period's margin is the rate applied to the outstanding balance,
+// rounded half up to whole sen, and the last installment settles whatever balance is left.
+func (t Tenor) AnnuitySchedule(amount Money, marginRate Rate, roundingUnit Money) []Installment {
+	n := t.TenorValue
+	if n <= 0 {
+		return nil
+	}
+
+	monthlyRate := new(big.Rat).Mul(marginRate.Rat(), big.NewRat(1, 12))
+	var payment Money
+	if monthlyRate.Sign() == 0 {
+		payment = amount.Div(int64(n), RoundHalfUp)
+	} else {
+		one := big.NewRat(1, 1)
+		growth := ratPow(new(big.Rat).Add(one, monthlyRate), n)
+		factor := new(big.Rat).Mul(monthlyRate, growth)
+		factor.Quo(factor, new(big.Rat).Sub(growth, one))
+		payment = amount.MulRat(factor, RoundHalfUp)
+	}
+	payment = payment.RoundTo(roundingUnit, RoundHalfUp)
+
+	installments := make([]Installment, 0, n)
+	balance := amount
+	for period := 1; period <= n; period++ {
+		margin := balance.MulRat(monthlyRate, RoundHalfUp)
+		principal := payment - margin
+		if period == n || principal > balance {
+			principal = balance
+		}
+		balance -= principal
+		installments = append(installments, Installment{
+			Period:           period,
+			Amount:           principal + margin,
+			Principal:        principal,
+			Margin:           margin,
+			OutstandingAfter: balance,
+		})
+	}
+	return installments
+}
+
+// ratPow raises x to a non-negative integer power exactly.
+func ratPow(x *big.Rat, n int) *big.Rat {
+	result := big.NewRat(1, 1)
+	for i := 0; i < n; i++ {
+		result.Mul(result, x)
+	}
+	return result
+}
+
+// TenorMarginRate is the yearly margin rate of a product for one tenor, valid from
+// EffectiveFrom up to but excluding EffectiveTo. A nil EffectiveTo means open ended.
+type TenorMarginRate struct {
+	MarginRateID  int64 `gorm:"primaryKey"`
+	TenorID       int64
+	ProductCode   string
+	MarginRate    Rate
+	EffectiveFrom time.Time
+	EffectiveTo   *time.Time
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
 type TenorRepository interface {
 	GetAll(ctx context.Context) ([]Tenor, error)
+	GetByValue(ctx context.Context, tenorValue int) (Tenor, error)
+	GetAllPriced(ctx context.Context, productCode string, at time.Time) ([]Tenor, error)
+	GetMarginRate(ctx context.Context, productCode string, tenorValue int, at time.Time) (Rate, error)
 }
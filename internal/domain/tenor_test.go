@@ -11,38 +11,47 @@ func TestTenor_Calculate(t *testing.T) {
 	tests := []struct {
 		name             string
 		tenorValue       int
-		amount           float64
-		marginRate       float64
-		wantMargin       float64
-		wantTotalPayment float64
-		wantInstallment  float64
+		amount           domain.Money
+		marginRate       domain.Rate
+		wantMargin       domain.Money
+		wantTotalPayment domain.Money
+		wantInstallment  domain.Money
 	}{
 		{
 			name:             "Tenor 6 bulan, margin 20%",
 			tenorValue:       6,
-			amount:           10000000,
-			marginRate:       0.2,
-			wantMargin:       1000000,    // (10jt * 0.2 * 6) / 12
-			wantTotalPayment: 11000000,   // 10jt + margin
-			wantInstallment:  1833333.33, // total / tenor
+			amount:           domain.NewMoney(10000000),
+			marginRate:       2000,
+			wantMargin:       domain.NewMoney(1000000),  // (10jt * 0.2 * 6) / 12
+			wantTotalPayment: domain.NewMoney(11000000), // 10jt + margin
+			wantInstallment:  183333333,                 // total / tenor = 1833333.33
 		},
 		{
 			name:             "Tenor 12 bulan, margin 15%",
 			tenorValue:       12,
-			amount:           5000000,
-			marginRate:       0.15,
-			wantMargin:       750000, // (5jt * 0.15 * 12) / 12
-			wantTotalPayment: 5750000,
-			wantInstallment:  479166.67,
+			amount:           domain.NewMoney(5000000),
+			marginRate:       1500,
+			wantMargin:       domain.NewMoney(750000), // (5jt * 0.15 * 12) / 12
+			wantTotalPayment: domain.NewMoney(5750000),
+			wantInstallment:  47916667, // 479166.67
 		},
 		{
 			name:             "Tenor 24 bulan, margin 10%",
 			tenorValue:       24,
-			amount:           20000000,
-			marginRate:       0.1,
-			wantMargin:       4000000, // (20jt * 0.1 * 24) / 12
-			wantTotalPayment: 24000000,
-			wantInstallment:  1000000,
+			amount:           domain.NewMoney(20000000),
+			marginRate:       1000,
+			wantMargin:       domain.NewMoney(4000000), // (20jt * 0.1 * 24) / 12
+			wantTotalPayment: domain.NewMoney(24000000),
+			wantInstallment:  domain.NewMoney(1000000),
+		},
+		{
+			name:             "Tenor 18 bulan, margin 18.75%, margin rounded to sen",
+			tenorValue:       18,
+			amount:           domain.NewMoney(3333333),
+			marginRate:       1875,
+			wantMargin:       93749991, // 3333333 * 0.1875 * 1.5 = 937499.90625
+			wantTotalPayment: 427083291,
+			wantInstallment:  23726850, // 4270832.91 / 18 = 237268.495 (tie rounds up)
 		},
 	}
 
@@ -52,9 +61,56 @@ func TestTenor_Calculate(t *testing.T) {
 
 			gotInstallment, gotMargin, gotTotal := tenor.Calculate(tt.amount, tt.marginRate)
 
-			assert.InDelta(t, tt.wantMargin, gotMargin, 0.01, "Margin tidak sesuai")
-			assert.InDelta(t, tt.wantTotalPayment, gotTotal, 0.01, "Total Payment tidak sesuai")
-			assert.InDelta(t, tt.wantInstallment, gotInstallment, 0.01, "Installment tidak sesuai")
+			assert.Equal(t, tt.wantMargin, gotMargin, "Margin tidak sesuai")
+			assert.Equal(t, tt.wantTotalPayment, gotTotal, "Total Payment tidak sesuai")
+			assert.Equal(t, tt.wantInstallment, gotInstallment, "Installment tidak sesuai")
 		})
 	}
 }
+
+func TestTenor_CalculateAnnuity(t *testing.T) {
+	tenor := domain.Tenor{TenorValue: 12}
+
+	gotInstallment, gotMargin, gotTotal := tenor.CalculateAnnuity(domain.NewMoney(12000000), 1200) // 12% p.a., 1% per month
+
+	assert.Equal(t, domain.Money(106618546), gotInstallment, "Installment tidak sesuai") // 1,066,185.46
+	assert.Equal(t, domain.Money(79422558), gotMargin, "Margin tidak sesuai")
+	assert.Equal(t, domain.Money(1279422558), gotTotal, "Total Payment tidak sesuai")
+}
+
+func TestTenor_AnnuitySchedule(t *testing.T) {
+	tenor := domain.Tenor{TenorValue: 12}
+	amount := domain.NewMoney(12000000)
+
+	installments := tenor.AnnuitySchedule(amount, 1200, 0)
+
+	assert.Len(t, installments, 12)
+
+	first := installments[0]
+	assert.Equal(t, 1, first.Period)
+	assert.Equal(t, domain.NewMoney(120000), first.Margin) // 1% of 12jt
+	assert.Equal(t, domain.Money(94618546), first.Principal)
+	assert.Equal(t, domain.Money(1105381454), first.OutstandingAfter)
+
+	last := installments[11]
+	assert.Equal(t, domain.Money(0), last.OutstandingAfter)
+	assert.Equal(t, domain.Money(105562923), last.Principal)
+
+	var principal domain.Money
+	for i, in := range installments {
+		principal += in.Principal
+		assert.Equal(t, in.Principal+in.Margin, in.Amount)
+		if i > 0 {
+			assert.Less(t, in.Margin, installments[i-1].Margin, "Margin harus menurun setiap periode")
+		}
+	}
+	assert.Equal(t, amount, principal)
+}
+
+func TestTenor_AnnuitySchedule_ZeroRate(t *testing.T) {
+	installments := domain.Tenor{TenorValue: 3}.AnnuitySchedule(domain.NewMoney(100), 0, 0)
+
+	assert.Equal(t, domain.Money(3333), installments[0].Amount)
+	assert.Equal(t, domain.Money(3334), installments[2].Amount)
+	assert.Equal(t, domain.Money(0), installments[2].Margin)
+}
@@ -1,11 +1,110 @@
 package domain
 
-import "time"
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+)
+
+// KYCStatus tells whether the identity of a user has been checked.
+type KYCStatus string
+
+const (
+	KYCPending  KYCStatus = "PENDING"
+	KYCVerified KYCStatus = "VERIFIED"
+	KYCRejected KYCStatus = "REJECTED"
+)
+
+var (
+	ErrPhoneRegistered = ConflictError("PHONE_ALREADY_REGISTERED", "phone is already registered to another user")
+	ErrNIKRegistered   = ConflictError("NIK_ALREADY_REGISTERED", "nik is already registered to another user")
+)
 
 type User struct {
-	UserID    int64 `gorm:"primaryKey"`
-	Name      string
-	Phone     string
-	CreatedAt time.Time
-	UpdatedAt time.Time
+	UserID      int64 `gorm:"primaryKey"`
+	Name        string
+	Phone       string // E.164, e.g. +6281234567890
+	NIK         string // empty until the identity card is provided
+	DateOfBirth *time.Time
+	Address     string
+	KYCStatus   KYCStatus
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// CheckIdentity reports what keeps the user's identity from being verified: a missing NIK, date of
+// birth or address, or a date of birth the NIK does not encode.
+func (u User) CheckIdentity() error {
+	if u.NIK == "" || u.DateOfBirth == nil || strings.TrimSpace(u.Address) == "" {
+		return errors.New("nik, date_of_birth and address are required")
+	}
+	nik, err := ParseNIK(u.NIK)
+	if err != nil {
+		return err
+	}
+	if !nik.MatchesBirthDate(*u.DateOfBirth) {
+		return errors.New("date_of_birth does not match the nik")
+	}
+	return nil
+}
+
+// UserFilter selects a page of users. Zero values mean "no filter".
+type UserFilter struct {
+	Name   string // part of the name, case-insensitive
+	Phone  string // the whole phone number in E.164
+	Limit  int
+	Offset int
+}
+
+type UserRepository interface {
+	// Create stores the user, returning ErrPhoneRegistered or ErrNIKRegistered when either is taken.
+	Create(ctx context.Context, u *User) error
+	GetByID(ctx context.Context, id int64) (User, error)
+	// GetByIDForUpdate reads the user and locks its row until the surrounding transaction ends.
+	GetByIDForUpdate(ctx context.Context, id int64) (User, error)
+	// Update stores the user's details and KYC status, returning ErrPhoneRegistered or ErrNIKRegistered
+	// when either belongs to another user.
+	Update(ctx context.Context, u *User) error
+	Search(ctx context.Context, filter UserFilter) ([]User, int64, error)
+}
+
+// indonesiaCallingCode is the E.164 country calling code of Indonesia.
+const indonesiaCallingCode = "62"
+
+// NormalizePhone returns an Indonesian phone number in E.164 format, e.g. +6281234567890. It accepts
+// the local 0812..., 62812... and +62812... forms, ignoring spaces, dashes, dots and parentheses.
+func NormalizePhone(raw string) (string, error) {
+	digits := strings.Map(func(r rune) rune {
+		switch r {
+		case ' ', '-', '.', '(', ')':
+			return -1
+		}
+		return r
+	}, strings.TrimSpace(raw))
+
+	var national string
+	switch {
+	case strings.HasPrefix(digits, "+"+indonesiaCallingCode):
+		national = digits[3:]
+	case strings.HasPrefix(digits, "+"):
+		return "", errors.New("only Indonesian (+62) phone numbers are supported")
+	case strings.HasPrefix(digits, indonesiaCallingCode):
+		national = digits[2:]
+	case strings.HasPrefix(digits, "0"):
+		national = digits[1:]
+	default:
+		national = digits
+	}
+
+	// Indonesian national numbers have 8 to 12 digits after the trunk prefix 0
+	if len(national) < 8 || len(national) > 12 || national[0] == '0' {
+		return "", errors.New("invalid phone number")
+	}
+	for _, r := range national {
+		if r < '0' || r > '9' {
+			return "", errors.New("invalid phone number")
+		}
+	}
+	return "+" + indonesiaCallingCode + national, nil
 }
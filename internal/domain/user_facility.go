@@ -9,16 +9,56 @@ type UserFacility struct {
 	UserFacilityID     int64 `gorm:"primaryKey"`
 	UserID             int64
 	FacilityLimitID    int64
-	Amount             float64
+	ProductCode        string
+	MarginRate         Rate
+	CalculationMethod  CalculationMethod
+	Amount             Money
 	Tenor              int
+	GracePeriod        GracePeriod
 	StartDate          time.Time
-	MonthlyInstallment float64
-	TotalMargin        float64
-	TotalPayment       float64
+	MonthlyInstallment Money
+	TotalMargin        Money
+	TotalPayment       Money
+	Status             FacilityStatus
+	CreditBalance      Money // overpayment held for the user
+	ScheduleVersion    int   // raised every time the remaining installments are restructured
 	CreatedAt          time.Time
 	UpdatedAt          time.Time
 }
 
+// Sort keys accepted by UserFacilityFilter.SortBy.
+const (
+	FacilitySortCreatedAt = "created_at"
+	FacilitySortStartDate = "start_date"
+	FacilitySortAmount    = "amount"
+	FacilitySortTenor     = "tenor"
+)
+
+// UserFacilityFilter selects a page of a user's facilities. Zero values mean "no filter".
+type UserFacilityFilter struct {
+	UserID        int64
+	Tenor         int
+	Status        FacilityStatus
+	StartDateFrom *time.Time
+	StartDateTo   *time.Time
+	SortBy        string
+	SortDesc      bool
+	Limit         int
+	Offset        int
+}
+
 type UserFacilityRepository interface {
 	Create(ctx context.Context, uf *UserFacility) error
+	GetByID(ctx context.Context, id int64) (UserFacility, error)
+	GetByIDForUpdate(ctx context.Context, id int64) (UserFacility, error)
+	UpdateStatus(ctx context.Context, id int64, status FacilityStatus) error
+	UpdateCreditBalance(ctx context.Context, id int64, creditBalance Money) error
+	UpdateScheduleVersion(ctx context.Context, id int64, version int) error
+	// UpdateTerms stores the tenor, monthly installment, total margin and total payment of the facility.
+	UpdateTerms(ctx context.Context, uf UserFacility) error
+	ListByUser(ctx context.Context, filter UserFacilityFilter) ([]UserFacility, int64, error)
+	// ListByStatus returns up to limit facilities in one of the statuses with an id above afterID, by id.
+	ListByStatus(ctx context.Context, statuses []FacilityStatus, afterID int64, limit int) ([]UserFacility, error)
+	// CountByUser returns how many of the user's facilities are in one of the statuses.
+	CountByUser(ctx context.Context, userID int64, statuses []FacilityStatus) (int, error)
 }
@@ -9,11 +9,80 @@ type UserFacilityDetail struct {
 	DetailID          int64 `gorm:"primaryKey"`
 	UserFacilityID    int64
 	DueDate           time.Time
-	InstallmentAmount float64
+	InstallmentAmount Money
+	PrincipalAmount   Money
+	MarginAmount      Money
+	OutstandingAfter  Money // principal still owed after this installment is paid
+	PaidAmount        Money
+	Status            InstallmentStatus
+	PaidAt            *time.Time // set once the installment is paid in full
+	ScheduleVersion   int        // version of the facility schedule the installment belongs to
 	CreatedAt         time.Time
 	UpdatedAt         time.Time
 }
 
+// InstallmentStatus tells how much of an installment has been paid.
+type InstallmentStatus string
+
+const (
+	InstallmentUnpaid        InstallmentStatus = "UNPAID"
+	InstallmentPartiallyPaid InstallmentStatus = "PARTIALLY_PAID"
+	InstallmentPaid          InstallmentStatus = "PAID"
+	// InstallmentSettled is closed by an early settlement, the unpaid part was waived as rebate.
+	InstallmentSettled InstallmentStatus = "SETTLED"
+)
+
+// Unpaid returns the part of the installment that has not been paid yet.
+func (d UserFacilityDetail) Unpaid() Money {
+	return d.InstallmentAmount - d.PaidAmount
+}
+
+// UnpaidMargin returns the margin part of what is unpaid, payments cover the margin of an
+// installment before its principal.
+func (d UserFacilityDetail) UnpaidMargin() Money {
+	return max(d.MarginAmount-d.PaidAmount, 0)
+}
+
+// RepaidPrincipal returns the part of the principal that no longer has to be paid: what the
+// payments covered beyond the margin, or all of it once the installment is settled.
+func (d UserFacilityDetail) RepaidPrincipal() Money {
+	if d.Status == InstallmentSettled {
+		return d.PrincipalAmount
+	}
+	return min(max(d.PaidAmount-d.MarginAmount, 0), d.PrincipalAmount)
+}
+
+// Pay applies up to amount to the installment and returns the part it used.
+func (d *UserFacilityDetail) Pay(amount Money, at time.Time) Money {
+	applied := min(amount, d.Unpaid())
+	if applied <= 0 {
+		return 0
+	}
+
+	d.PaidAmount += applied
+	if d.Unpaid() == 0 {
+		d.Status = InstallmentPaid
+		d.PaidAt = &at
+	} else {
+		d.Status = InstallmentPartiallyPaid
+	}
+	return applied
+}
+
+// Settle closes an installment that is not paid in full as part of an early settlement.
+func (d *UserFacilityDetail) Settle(at time.Time) {
+	if d.Unpaid() <= 0 {
+		return
+	}
+	d.Status = InstallmentSettled
+	d.PaidAt = &at
+}
+
 type UserFacilityDetailRepository interface {
 	BulkCreate(ctx context.Context, details []UserFacilityDetail) error
+	// ListByFacilityID returns the installments of the current schedule, superseded ones are left out.
+	ListByFacilityID(ctx context.Context, userFacilityID int64) ([]UserFacilityDetail, error)
+	UpdatePayment(ctx context.Context, d UserFacilityDetail) error
+	// Supersede takes installments out of the current schedule, they are kept for audit.
+	Supersede(ctx context.Context, detailIDs []int64) error
 }
@@ -0,0 +1,38 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/elokanugrah/go-financing-btpns/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUserFacilityDetail_RepaidPrincipal(t *testing.T) {
+	installment := func(paid domain.Money, status domain.InstallmentStatus) domain.UserFacilityDetail {
+		return domain.UserFacilityDetail{
+			InstallmentAmount: domain.NewMoney(1000000),
+			PrincipalAmount:   domain.NewMoney(800000),
+			MarginAmount:      domain.NewMoney(200000),
+			PaidAmount:        paid,
+			Status:            status,
+		}
+	}
+
+	tests := []struct {
+		name   string
+		detail domain.UserFacilityDetail
+		want   domain.Money
+	}{
+		{name: "unpaid", detail: installment(0, domain.InstallmentUnpaid), want: 0},
+		{name: "margin only", detail: installment(domain.NewMoney(150000), domain.InstallmentPartiallyPaid), want: 0},
+		{name: "margin and part of the principal", detail: installment(domain.NewMoney(500000), domain.InstallmentPartiallyPaid), want: domain.NewMoney(300000)},
+		{name: "paid in full", detail: installment(domain.NewMoney(1000000), domain.InstallmentPaid), want: domain.NewMoney(800000)},
+		{name: "settled with a rebate", detail: installment(domain.NewMoney(900000), domain.InstallmentSettled), want: domain.NewMoney(800000)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.detail.RepaidPrincipal())
+		})
+	}
+}
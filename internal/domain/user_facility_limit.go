@@ -8,11 +8,67 @@ import (
 type UserFacilityLimit struct {
 	FacilityLimitID int64 `gorm:"primaryKey"`
 	UserID          int64
-	LimitAmount     float64
+	LimitAmount     Money
+	UsedAmount      Money
+	LimitType       LimitType
+	ExpiresAt       *time.Time // last day the limit can be used, nil when it does not expire
 	CreatedAt       time.Time
 	UpdatedAt       time.Time
 }
 
+// LimitType tells whether repaying a facility makes its limit available again.
+type LimitType string
+
+const (
+	// LimitTypeRevolving gets back the principal repaid on its facilities.
+	LimitTypeRevolving LimitType = "REVOLVING"
+	// LimitTypeNonRevolving is only given back when a facility is rejected or cancelled.
+	LimitTypeNonRevolving LimitType = "NON_REVOLVING"
+)
+
+// AvailableAmount returns the part of the limit that is not yet reserved by submitted facilities.
+func (l UserFacilityLimit) AvailableAmount() Money {
+	return l.LimitAmount - l.UsedAmount
+}
+
+// IsExpired reports whether the limit can no longer be used on today.
+func (l UserFacilityLimit) IsExpired(today time.Time) bool {
+	return l.ExpiresAt != nil && today.After(*l.ExpiresAt)
+}
+
 type UserFacilityLimitRepository interface {
+	Create(ctx context.Context, l *UserFacilityLimit) error
 	GetByID(ctx context.Context, id int64) (UserFacilityLimit, error)
+	GetByIDForUpdate(ctx context.Context, id int64) (UserFacilityLimit, error)
+	UpdateUsedAmount(ctx context.Context, id int64, usedAmount Money) error
+	UpdateLimit(ctx context.Context, id int64, limitAmount Money, expiresAt *time.Time) error
+}
+
+// LimitAction is the kind of change recorded in a FacilityLimitHistory.
+type LimitAction string
+
+const (
+	LimitActionGrant  LimitAction = "GRANT"
+	LimitActionTopUp  LimitAction = "TOP_UP"
+	LimitActionReduce LimitAction = "REDUCE"
+	LimitActionExpire LimitAction = "EXPIRE"
+)
+
+// FacilityLimitHistory records a single change of a limit's amount or expiry. It is never updated.
+type FacilityLimitHistory struct {
+	HistoryID       int64
+	FacilityLimitID int64
+	Action          LimitAction
+	Amount          Money // by how much the limit changed, zero when only the expiry changed
+	LimitBefore     Money
+	LimitAfter      Money
+	ExpiresAt       *time.Time // expiry after the change
+	Actor           string
+	Reason          string
+	CreatedAt       time.Time
+}
+
+type FacilityLimitHistoryRepository interface {
+	Create(ctx context.Context, h *FacilityLimitHistory) error
+	ListByFacilityLimitID(ctx context.Context, facilityLimitID int64) ([]FacilityLimitHistory, error)
 }
@@ -0,0 +1,31 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/elokanugrah/go-financing-btpns/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUserFacilityLimit_IsExpired(t *testing.T) {
+	expiresAt := time.Date(2025, 8, 31, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name  string
+		limit domain.UserFacilityLimit
+		today time.Time
+		want  bool
+	}{
+		{name: "no expiry", limit: domain.UserFacilityLimit{}, today: time.Date(2099, 1, 1, 0, 0, 0, 0, time.UTC), want: false},
+		{name: "before the expiry date", limit: domain.UserFacilityLimit{ExpiresAt: &expiresAt}, today: time.Date(2025, 8, 30, 0, 0, 0, 0, time.UTC), want: false},
+		{name: "on the expiry date", limit: domain.UserFacilityLimit{ExpiresAt: &expiresAt}, today: expiresAt, want: false},
+		{name: "after the expiry date", limit: domain.UserFacilityLimit{ExpiresAt: &expiresAt}, today: time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC), want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.limit.IsExpired(tt.today))
+		})
+	}
+}
@@ -0,0 +1,41 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/elokanugrah/go-financing-btpns/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizePhone(t *testing.T) {
+	tests := []struct {
+		raw     string
+		want    string
+		wantErr bool
+	}{
+		{raw: "081234567890", want: "+6281234567890"},
+		{raw: "6281234567890", want: "+6281234567890"},
+		{raw: "+62 812-3456-7890", want: "+6281234567890"},
+		{raw: "81234567890", want: "+6281234567890"},
+		{raw: "(021) 555.1234", want: "+62215551234"},
+		{raw: " +6281234567890 ", want: "+6281234567890"},
+		{raw: "+6581234567", wantErr: true},
+		{raw: "0812345", wantErr: true},
+		{raw: "08123456789012", wantErr: true},
+		{raw: "0081234567890", wantErr: true},
+		{raw: "0812abc67890", wantErr: true},
+		{raw: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.raw, func(t *testing.T) {
+			got, err := domain.NormalizePhone(tt.raw)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
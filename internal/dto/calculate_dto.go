@@ -1,16 +1,27 @@
 package dto
 
+import "github.com/elokanugrah/go-financing-btpns/internal/domain"
+
 type CalculateRequest struct {
-	Amount float64 `json:"amount"`
+	Amount      domain.Money `json:"amount"`
+	ProductCode string       `json:"product_code"`
+	// GracePeriodMonths and GraceType override the product's grace period when set.
+	GracePeriodMonths *int   `json:"grace_period_months,omitempty"`
+	GraceType         string `json:"grace_type,omitempty"`
 }
 
 type CalculationResult struct {
-	Tenor              int     `json:"tenor"`
-	MonthlyInstallment float64 `json:"monthly_installment"`
-	TotalMargin        float64 `json:"total_margin"`
-	TotalPayment       float64 `json:"total_payment"`
+	Tenor              int          `json:"tenor"`
+	MarginRate         domain.Rate  `json:"margin_rate"`
+	MonthlyInstallment domain.Money `json:"monthly_installment"`
+	TotalMargin        domain.Money `json:"total_margin"`
+	TotalPayment       domain.Money `json:"total_payment"`
 }
 
 type CalculateResponse struct {
-	Calculations []CalculationResult `json:"calculations"`
+	ProductCode       string                   `json:"product_code"`
+	CalculationMethod domain.CalculationMethod `json:"calculation_method"`
+	GracePeriodMonths int                      `json:"grace_period_months,omitempty"`
+	GraceType         domain.GraceType         `json:"grace_type,omitempty"`
+	Calculations      []CalculationResult      `json:"calculations"`
 }
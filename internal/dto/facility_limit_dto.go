@@ -0,0 +1,53 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/elokanugrah/go-financing-btpns/internal/domain"
+)
+
+type GrantLimitRequest struct {
+	UserID      int64        `json:"user_id"`
+	LimitAmount domain.Money `json:"limit_amount"`
+	LimitType   string       `json:"limit_type"` // REVOLVING or NON_REVOLVING, defaults to NON_REVOLVING
+	ExpiresAt   string       `json:"expires_at"` // YYYY-MM-DD, optional: the limit does not expire when empty
+	Actor       string       `json:"actor"`
+	Reason      string       `json:"reason"`
+}
+
+// LimitAdjustmentRequest is the body of the top-up and reduce endpoints.
+type LimitAdjustmentRequest struct {
+	Amount domain.Money `json:"amount"`
+	Actor  string       `json:"actor"`
+	Reason string       `json:"reason"`
+}
+
+type LimitExpiryRequest struct {
+	Actor  string `json:"actor"`
+	Reason string `json:"reason"`
+}
+
+type FacilityLimitResponse struct {
+	FacilityLimitID int64              `json:"facility_limit_id"`
+	UserID          int64              `json:"user_id"`
+	LimitAmount     domain.Money       `json:"limit_amount"`
+	UsedAmount      domain.Money       `json:"used_amount"`
+	AvailableAmount domain.Money       `json:"available_amount"`
+	LimitType       domain.LimitType   `json:"limit_type"`
+	ExpiresAt       string             `json:"expires_at,omitempty"`
+	Expired         bool               `json:"expired"`
+	CreatedAt       time.Time          `json:"created_at"`
+	UpdatedAt       time.Time          `json:"updated_at"`
+	History         []LimitHistoryItem `json:"history"`
+}
+
+type LimitHistoryItem struct {
+	Action      domain.LimitAction `json:"action"`
+	Amount      domain.Money       `json:"amount"`
+	LimitBefore domain.Money       `json:"limit_before"`
+	LimitAfter  domain.Money       `json:"limit_after"`
+	ExpiresAt   string             `json:"expires_at,omitempty"`
+	Actor       string             `json:"actor"`
+	Reason      string             `json:"reason"`
+	CreatedAt   time.Time          `json:"created_at"`
+}
@@ -1,26 +1,244 @@
 package dto
 
+import (
+	"time"
+
+	"github.com/elokanugrah/go-financing-btpns/internal/domain"
+)
+
 type SubmitFinancingRequest struct {
-	UserID          int64   `json:"user_id"`
-	FacilityLimitID int64   `json:"facility_limit_id"`
-	Amount          float64 `json:"amount"`
-	Tenor           int     `json:"tenor"`
-	StartDate       string  `json:"start_date"`
+	UserID          int64        `json:"user_id"`
+	FacilityLimitID int64        `json:"facility_limit_id"`
+	ProductCode     string       `json:"product_code"`
+	Amount          domain.Money `json:"amount"`
+	Tenor           int          `json:"tenor"`
+	StartDate       string       `json:"start_date"`
+	// GracePeriodMonths and GraceType override the product's grace period when set.
+	GracePeriodMonths *int   `json:"grace_period_months,omitempty"`
+	GraceType         string `json:"grace_type,omitempty"`
+
+	// IdempotencyKey comes from the Idempotency-Key header, it is not part of the request body.
+	IdempotencyKey string `json:"-"`
 }
 
 type ScheduleItem struct {
-	DueDate           string  `json:"due_date"`
-	InstallmentAmount float64 `json:"installment_amount"`
+	DetailID          int64                    `json:"detail_id,omitempty"`
+	DueDate           string                   `json:"due_date"`
+	InstallmentAmount domain.Money             `json:"installment_amount"`
+	PrincipalAmount   domain.Money             `json:"principal_amount"`
+	MarginAmount      domain.Money             `json:"margin_amount"`
+	OutstandingAfter  domain.Money             `json:"outstanding_after"`
+	PaidAmount        domain.Money             `json:"paid_amount"`
+	Status            domain.InstallmentStatus `json:"status"`
 }
 
 type SubmitFinancingResponse struct {
-	UserID          int64          `json:"user_id"`
-	FacilityLimitID int64          `json:"facility_limit_id"`
-	Amount          float64        `json:"amount"`
-	Tenor           int            `json:"tenor"`
-	StartDate       string         `json:"start_date"`
-	MonthlyInstall  float64        `json:"monthly_installment"`
-	TotalMargin     float64        `json:"total_margin"`
-	TotalPayment    float64        `json:"total_payment"`
-	Schedule        []ScheduleItem `json:"schedule"`
+	UserFacilityID    int64                    `json:"user_facility_id"`
+	UserID            int64                    `json:"user_id"`
+	FacilityLimitID   int64                    `json:"facility_limit_id"`
+	ProductCode       string                   `json:"product_code"`
+	CalculationMethod domain.CalculationMethod `json:"calculation_method"`
+	Amount            domain.Money             `json:"amount"`
+	Tenor             int                      `json:"tenor"`
+	GracePeriodMonths int                      `json:"grace_period_months,omitempty"`
+	GraceType         domain.GraceType         `json:"grace_type,omitempty"`
+	MarginRate        domain.Rate              `json:"margin_rate"`
+	StartDate         string                   `json:"start_date"`
+	MonthlyInstall    domain.Money             `json:"monthly_installment"`
+	TotalMargin       domain.Money             `json:"total_margin"`
+	TotalPayment      domain.Money             `json:"total_payment"`
+	Status            domain.FacilityStatus    `json:"status"`
+	Schedule          []ScheduleItem           `json:"schedule"`
+}
+
+type FacilityResponse struct {
+	UserFacilityID    int64                    `json:"user_facility_id"`
+	UserID            int64                    `json:"user_id"`
+	FacilityLimitID   int64                    `json:"facility_limit_id"`
+	ProductCode       string                   `json:"product_code"`
+	CalculationMethod domain.CalculationMethod `json:"calculation_method"`
+	Amount            domain.Money             `json:"amount"`
+	Tenor             int                      `json:"tenor"`
+	GracePeriodMonths int                      `json:"grace_period_months,omitempty"`
+	GraceType         domain.GraceType         `json:"grace_type,omitempty"`
+	MarginRate        domain.Rate              `json:"margin_rate"`
+	StartDate         string                   `json:"start_date"`
+	MonthlyInstall    domain.Money             `json:"monthly_installment"`
+	TotalMargin       domain.Money             `json:"total_margin"`
+	TotalPayment      domain.Money             `json:"total_payment"`
+	Status            domain.FacilityStatus    `json:"status"`
+	CreditBalance     domain.Money             `json:"credit_balance"`
+	CreatedAt         time.Time                `json:"created_at"`
+	Schedule          []ScheduleItem           `json:"schedule,omitempty"`
+	StatusHistory     []StatusHistoryItem      `json:"status_history,omitempty"`
+}
+
+type StatusHistoryItem struct {
+	FromStatus domain.FacilityStatus `json:"from_status,omitempty"`
+	ToStatus   domain.FacilityStatus `json:"to_status"`
+	Actor      string                `json:"actor"`
+	Reason     string                `json:"reason,omitempty"`
+	CreatedAt  time.Time             `json:"created_at"`
+}
+
+// FacilityTransitionRequest is the body of the approve, reject, disburse and cancel endpoints.
+type FacilityTransitionRequest struct {
+	Actor  string `json:"actor"`
+	Reason string `json:"reason"`
+}
+
+type ListFacilitiesRequest struct {
+	Page          int    `form:"page"`
+	PageSize      int    `form:"page_size"`
+	Tenor         int    `form:"tenor"`
+	Status        string `form:"status"`
+	StartDateFrom string `form:"start_date_from"`
+	StartDateTo   string `form:"start_date_to"`
+	SortBy        string `form:"sort_by"`
+	Order         string `form:"order"`
+}
+
+type FacilityListResponse struct {
+	Facilities []FacilityResponse `json:"facilities"`
+	Page       int                `json:"page"`
+	PageSize   int                `json:"page_size"`
+	Total      int64              `json:"total"`
+	TotalPages int                `json:"total_pages"`
+}
+
+type PaymentRequest struct {
+	Amount    domain.Money `json:"amount"`
+	PaidAt    string       `json:"paid_at"` // optional, defaults to today
+	Reference string       `json:"reference"`
+}
+
+// PaymentAllocationItem is the part of a payment applied to an installment, or to its late charge
+// when LateChargeID is set. RemainingAmount is what is left of the installment or the charge.
+type PaymentAllocationItem struct {
+	DetailID          int64                    `json:"detail_id"`
+	LateChargeID      int64                    `json:"late_charge_id,omitempty"`
+	DueDate           string                   `json:"due_date"`
+	Amount            domain.Money             `json:"amount"`
+	InstallmentStatus domain.InstallmentStatus `json:"installment_status,omitempty"`
+	LateChargeStatus  domain.InstallmentStatus `json:"late_charge_status,omitempty"`
+	RemainingAmount   domain.Money             `json:"remaining_amount"`
+}
+
+type PaymentResponse struct {
+	PaymentID      int64                   `json:"payment_id"`
+	UserFacilityID int64                   `json:"user_facility_id"`
+	Amount         domain.Money            `json:"amount"`
+	Reference      string                  `json:"reference,omitempty"`
+	PaidAt         string                  `json:"paid_at"`
+	Allocations    []PaymentAllocationItem `json:"allocations"`
+	CreditAmount   domain.Money            `json:"credit_amount"`
+	CreditBalance  domain.Money            `json:"credit_balance"`
+	FacilityStatus domain.FacilityStatus   `json:"facility_status"`
+}
+
+type SettlementQuoteRequest struct {
+	SettlementDate string `form:"settlement_date"` // optional, defaults to today
+}
+
+type SettlementQuoteResponse struct {
+	UserFacilityID       int64        `json:"user_facility_id"`
+	SettlementDate       string       `json:"settlement_date"`
+	OutstandingPrincipal domain.Money `json:"outstanding_principal"`
+	RemainingMargin      domain.Money `json:"remaining_margin"`
+	UnearnedMargin       domain.Money `json:"unearned_margin"`
+	Rebate               domain.Money `json:"rebate"`
+	LateCharges          domain.Money `json:"late_charges"`
+	CreditApplied        domain.Money `json:"credit_applied"`
+	PayoffAmount         domain.Money `json:"payoff_amount"`
+}
+
+// SettlementRequest confirms a quote, PayoffAmount must match the quote for SettlementDate.
+type SettlementRequest struct {
+	SettlementDate string       `json:"settlement_date"` // optional, defaults to today
+	PayoffAmount   domain.Money `json:"payoff_amount"`
+	Actor          string       `json:"actor"`
+	Reference      string       `json:"reference"`
+}
+
+type SettlementResponse struct {
+	SettlementQuoteResponse
+	SettlementID   int64                 `json:"settlement_id"`
+	PaymentID      int64                 `json:"payment_id"`
+	FacilityStatus domain.FacilityStatus `json:"facility_status"`
+}
+
+// RestructureRequest reschedules the unpaid installments over Tenor months from StartDate.
+type RestructureRequest struct {
+	Tenor     int    `json:"tenor"`
+	StartDate string `json:"start_date"`
+	Reason    string `json:"reason"`
+	Actor     string `json:"actor"`
+}
+
+type RestructureResponse struct {
+	RestructuringID      int64          `json:"restructuring_id"`
+	UserFacilityID       int64          `json:"user_facility_id"`
+	ScheduleVersion      int            `json:"schedule_version"`
+	OutstandingPrincipal domain.Money   `json:"outstanding_principal"`
+	RemainingMargin      domain.Money   `json:"remaining_margin"`
+	Tenor                int            `json:"tenor"`
+	StartDate            string         `json:"start_date"`
+	MonthlyInstallment   domain.Money   `json:"monthly_installment"`
+	Reason               string         `json:"reason"`
+	Schedule             []ScheduleItem `json:"schedule"`
+}
+
+type CollectibilityRequest struct {
+	AsOf string `form:"as_of"` // optional, defaults to today
+}
+
+type CollectibilityResponse struct {
+	UserFacilityID      int64                 `json:"user_facility_id"`
+	AsOf                string                `json:"as_of"`
+	DaysPastDue         int                   `json:"days_past_due"`
+	Collectibility      domain.Collectibility `json:"collectibility"`
+	CollectibilityLabel string                `json:"collectibility_label"`
+	OverdueInstallments int                   `json:"overdue_installments"`
+	OverdueAmount       domain.Money          `json:"overdue_amount"`
+	OldestDueDate       string                `json:"oldest_due_date,omitempty"`
+	FacilityStatus      domain.FacilityStatus `json:"facility_status"`
+}
+
+type CollectibilityBatchRequest struct {
+	AsOf string `json:"as_of"` // optional, defaults to today
+}
+
+type CollectibilityBucket struct {
+	Collectibility      domain.Collectibility `json:"collectibility"`
+	CollectibilityLabel string                `json:"collectibility_label"`
+	Facilities          int                   `json:"facilities"`
+	OverdueAmount       domain.Money          `json:"overdue_amount"`
+}
+
+type CollectibilityBatchResponse struct {
+	AsOf       string                 `json:"as_of"`
+	Facilities int                    `json:"facilities"`
+	Buckets    []CollectibilityBucket `json:"buckets"`
+}
+
+// CheckResult is the outcome of one rule of an eligibility check.
+type CheckResult string
+
+const (
+	CheckPassed CheckResult = "PASS"
+	CheckFailed CheckResult = "FAIL"
+	// CheckSkipped is a rule that could not be checked because a rule it depends on failed.
+	CheckSkipped CheckResult = "SKIPPED"
+)
+
+type EligibilityCheck struct {
+	Rule    string      `json:"rule"`
+	Result  CheckResult `json:"result"`
+	Code    string      `json:"code,omitempty"` // the error code SubmitFinancing would return
+	Message string      `json:"message,omitempty"`
+}
+
+type EligibilityResponse struct {
+	Eligible bool               `json:"eligible"`
+	Checks   []EligibilityCheck `json:"checks"`
 }
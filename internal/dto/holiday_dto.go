@@ -0,0 +1,36 @@
+package dto
+
+import "io"
+
+type ListHolidaysRequest struct {
+	Year int `form:"year"` // optional, every year when empty
+}
+
+type HolidayRequest struct {
+	Date string `json:"date"`
+	Name string `json:"name"`
+}
+
+type HolidayResponse struct {
+	Date string `json:"date"`
+	Name string `json:"name"`
+}
+
+type HolidayListResponse struct {
+	Holidays []HolidayResponse `json:"holidays"`
+}
+
+// Holiday import file formats.
+const (
+	HolidayFormatCSV = "csv"
+	HolidayFormatICS = "ics"
+)
+
+type HolidayImportRequest struct {
+	Format string    // HolidayFormatCSV or HolidayFormatICS
+	File   io.Reader // the file to import
+}
+
+type HolidayImportResponse struct {
+	Imported int `json:"imported"`
+}
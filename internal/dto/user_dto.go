@@ -0,0 +1,56 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/elokanugrah/go-financing-btpns/internal/domain"
+)
+
+// CreateUserRequest registers a customer. The identity fields are optional until KYC.
+type CreateUserRequest struct {
+	Name        string `json:"name"`
+	Phone       string `json:"phone"`
+	NIK         string `json:"nik"`
+	DateOfBirth string `json:"date_of_birth"` // YYYY-MM-DD
+	Address     string `json:"address"`
+}
+
+// UpdateUserRequest changes only the fields that are sent.
+type UpdateUserRequest struct {
+	Name        *string `json:"name"`
+	Phone       *string `json:"phone"`
+	NIK         *string `json:"nik"`
+	DateOfBirth *string `json:"date_of_birth"`
+	Address     *string `json:"address"`
+}
+
+type KYCRequest struct {
+	Status string `json:"status"` // VERIFIED or REJECTED
+}
+
+type SearchUsersRequest struct {
+	Name     string `form:"name"`
+	Phone    string `form:"phone"`
+	Page     int    `form:"page"`
+	PageSize int    `form:"page_size"`
+}
+
+type UserResponse struct {
+	UserID      int64            `json:"user_id"`
+	Name        string           `json:"name"`
+	Phone       string           `json:"phone"`
+	NIK         string           `json:"nik,omitempty"`
+	DateOfBirth string           `json:"date_of_birth,omitempty"`
+	Address     string           `json:"address,omitempty"`
+	KYCStatus   domain.KYCStatus `json:"kyc_status"`
+	CreatedAt   time.Time        `json:"created_at"`
+	UpdatedAt   time.Time        `json:"updated_at"`
+}
+
+type UserListResponse struct {
+	Users      []UserResponse `json:"users"`
+	Page       int            `json:"page"`
+	PageSize   int            `json:"page_size"`
+	Total      int64          `json:"total"`
+	TotalPages int            `json:"total_pages"`
+}
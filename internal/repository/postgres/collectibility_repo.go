@@ -0,0 +1,40 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/elokanugrah/go-financing-btpns/internal/domain"
+)
+
+type collectibilityRepository struct {
+	db *sql.DB
+}
+
+func NewCollectibilityRepository(db *sql.DB) domain.CollectibilityRepository {
+	return &collectibilityRepository{db: db}
+}
+
+func (r *collectibilityRepository) Save(ctx context.Context, a domain.Aging) error {
+	query := `
+		INSERT INTO facility_collectibilities
+		(user_facility_id, as_of_date, days_past_due, collectibility, overdue_installments, overdue_amount, oldest_due_date, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, NOW())
+		ON CONFLICT (user_facility_id, as_of_date) DO UPDATE SET
+			days_past_due = EXCLUDED.days_past_due,
+			collectibility = EXCLUDED.collectibility,
+			overdue_installments = EXCLUDED.overdue_installments,
+			overdue_amount = EXCLUDED.overdue_amount,
+			oldest_due_date = EXCLUDED.oldest_due_date,
+			created_at = EXCLUDED.created_at`
+	_, err := r.db.ExecContext(ctx, query,
+		a.UserFacilityID,
+		a.AsOf,
+		a.DaysPastDue,
+		a.Collectibility,
+		a.OverdueInstallments,
+		a.OverdueAmount,
+		a.OldestDueDate,
+	)
+	return err
+}
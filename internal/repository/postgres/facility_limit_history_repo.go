@@ -0,0 +1,62 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/elokanugrah/go-financing-btpns/internal/domain"
+)
+
+type facilityLimitHistoryRepository struct {
+	db *sql.DB
+}
+
+func NewFacilityLimitHistoryRepository(db *sql.DB) domain.FacilityLimitHistoryRepository {
+	return &facilityLimitHistoryRepository{db: db}
+}
+
+// getQuerier extracts a transaction from the context if it exists,
+// otherwise it returns the base database connection.
+func (r *facilityLimitHistoryRepository) getQuerier(ctx context.Context) querier {
+	tx, ok := ctx.Value(txKey{}).(*sql.Tx)
+	if ok {
+		return tx
+	}
+
+	return r.db
+}
+
+func (r *facilityLimitHistoryRepository) Create(ctx context.Context, h *domain.FacilityLimitHistory) error {
+	q := r.getQuerier(ctx)
+
+	query := `
+		INSERT INTO facility_limit_histories (facility_limit_id, action, amount, limit_before, limit_after, expires_at, actor, reason, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, NOW())
+		RETURNING history_id, created_at`
+	return q.QueryRowContext(ctx, query, h.FacilityLimitID, h.Action, h.Amount, h.LimitBefore, h.LimitAfter, h.ExpiresAt, h.Actor, h.Reason).
+		Scan(&h.HistoryID, &h.CreatedAt)
+}
+
+func (r *facilityLimitHistoryRepository) ListByFacilityLimitID(ctx context.Context, facilityLimitID int64) ([]domain.FacilityLimitHistory, error) {
+	query := `
+		SELECT history_id, facility_limit_id, action, amount, limit_before, limit_after, expires_at, actor, reason, created_at
+		FROM facility_limit_histories
+		WHERE facility_limit_id = $1
+		ORDER BY created_at ASC, history_id ASC`
+	rows, err := r.getQuerier(ctx).QueryContext(ctx, query, facilityLimitID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var histories []domain.FacilityLimitHistory
+	for rows.Next() {
+		var h domain.FacilityLimitHistory
+		if err := rows.Scan(&h.HistoryID, &h.FacilityLimitID, &h.Action, &h.Amount, &h.LimitBefore, &h.LimitAfter, &h.ExpiresAt, &h.Actor, &h.Reason, &h.CreatedAt); err != nil {
+			return nil, err
+		}
+		histories = append(histories, h)
+	}
+
+	return histories, rows.Err()
+}
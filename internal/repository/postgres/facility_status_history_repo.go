@@ -0,0 +1,62 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/elokanugrah/go-financing-btpns/internal/domain"
+)
+
+type facilityStatusHistoryRepository struct {
+	db *sql.DB
+}
+
+func NewFacilityStatusHistoryRepository(db *sql.DB) domain.FacilityStatusHistoryRepository {
+	return &facilityStatusHistoryRepository{db: db}
+}
+
+// getQuerier extracts a transaction from the context if it exists,
+// otherwise it returns the base database connection.
+func (r *facilityStatusHistoryRepository) getQuerier(ctx context.Context) querier {
+	tx, ok := ctx.Value(txKey{}).(*sql.Tx)
+	if ok {
+		return tx
+	}
+
+	return r.db
+}
+
+func (r *facilityStatusHistoryRepository) Create(ctx context.Context, h *domain.FacilityStatusHistory) error {
+	q := r.getQuerier(ctx)
+
+	query := `
+		INSERT INTO facility_status_histories (user_facility_id, from_status, to_status, actor, reason, created_at)
+		VALUES ($1, NULLIF($2, ''), $3, $4, $5, NOW())
+		RETURNING history_id, created_at`
+	return q.QueryRowContext(ctx, query, h.UserFacilityID, h.FromStatus, h.ToStatus, h.Actor, h.Reason).
+		Scan(&h.HistoryID, &h.CreatedAt)
+}
+
+func (r *facilityStatusHistoryRepository) ListByFacilityID(ctx context.Context, userFacilityID int64) ([]domain.FacilityStatusHistory, error) {
+	query := `
+		SELECT history_id, user_facility_id, COALESCE(from_status, ''), to_status, actor, reason, created_at
+		FROM facility_status_histories
+		WHERE user_facility_id = $1
+		ORDER BY created_at ASC, history_id ASC`
+	rows, err := r.getQuerier(ctx).QueryContext(ctx, query, userFacilityID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var histories []domain.FacilityStatusHistory
+	for rows.Next() {
+		var h domain.FacilityStatusHistory
+		if err := rows.Scan(&h.HistoryID, &h.UserFacilityID, &h.FromStatus, &h.ToStatus, &h.Actor, &h.Reason, &h.CreatedAt); err != nil {
+			return nil, err
+		}
+		histories = append(histories, h)
+	}
+
+	return histories, rows.Err()
+}
@@ -0,0 +1,83 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/elokanugrah/go-financing-btpns/internal/domain"
+)
+
+type holidayRepository struct {
+	db *sql.DB
+}
+
+func NewHolidayRepository(db *sql.DB) domain.HolidayRepository {
+	return &holidayRepository{db: db}
+}
+
+// getQuerier extracts a transaction from the context if it exists,
+// otherwise it returns the base database connection.
+func (r *holidayRepository) getQuerier(ctx context.Context) querier {
+	tx, ok := ctx.Value(txKey{}).(*sql.Tx)
+	if ok {
+		return tx
+	}
+
+	return r.db
+}
+
+func (r *holidayRepository) List(ctx context.Context, year int) ([]domain.Holiday, error) {
+	query := `
+		SELECT holiday_date, name, created_at, updated_at
+		FROM holidays
+		WHERE $1 = 0 OR EXTRACT(YEAR FROM holiday_date) = $1
+		ORDER BY holiday_date ASC`
+	rows, err := r.getQuerier(ctx).QueryContext(ctx, query, year)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var holidays []domain.Holiday
+	for rows.Next() {
+		var h domain.Holiday
+		if err := rows.Scan(&h.Date, &h.Name, &h.CreatedAt, &h.UpdatedAt); err != nil {
+			return nil, err
+		}
+		holidays = append(holidays, h)
+	}
+
+	return holidays, rows.Err()
+}
+
+func (r *holidayRepository) Save(ctx context.Context, holidays []domain.Holiday) error {
+	q := r.getQuerier(ctx)
+
+	query := `
+		INSERT INTO holidays (holiday_date, name, created_at, updated_at)
+		VALUES ($1, $2, NOW(), NOW())
+		ON CONFLICT (holiday_date) DO UPDATE SET
+			name = EXCLUDED.name,
+			updated_at = NOW()`
+
+	stmt, err := q.PrepareContext(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	for _, h := range holidays {
+		if _, err := stmt.ExecContext(ctx, h.Date, h.Name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *holidayRepository) Delete(ctx context.Context, date time.Time) error {
+	query := `DELETE FROM holidays WHERE holiday_date = $1 RETURNING holiday_date`
+	var deleted time.Time
+	err := r.getQuerier(ctx).QueryRowContext(ctx, query, date).Scan(&deleted)
+	return notFound(err)
+}
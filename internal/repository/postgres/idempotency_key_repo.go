@@ -0,0 +1,75 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/elokanugrah/go-financing-btpns/internal/domain"
+)
+
+type idempotencyKeyRepository struct {
+	db *sql.DB
+}
+
+func NewIdempotencyKeyRepository(db *sql.DB) domain.IdempotencyKeyRepository {
+	return &idempotencyKeyRepository{db: db}
+}
+
+// getQuerier extracts a transaction from the context if it exists,
+// otherwise it returns the base database connection.
+func (r *idempotencyKeyRepository) getQuerier(ctx context.Context) querier {
+	tx, ok := ctx.Value(txKey{}).(*sql.Tx)
+	if ok {
+		return tx
+	}
+
+	return r.db
+}
+
+// Reserve relies on ON CONFLICT DO NOTHING waiting for a concurrent insert of the same key,
+// so the lookup that follows a conflict always sees the committed row. The key references the
+// user, so a key of an unknown user violates the foreign key.
+func (r *idempotencyKeyRepository) Reserve(ctx context.Context, key domain.IdempotencyKey) (domain.IdempotencyKey, bool, error) {
+	q := r.getQuerier(ctx)
+
+	query := `
+		INSERT INTO idempotency_keys (user_id, idempotency_key, request_hash)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (user_id, idempotency_key) DO NOTHING
+		RETURNING created_at`
+	err := q.QueryRowContext(ctx, query, key.UserID, key.Key, key.RequestHash).Scan(&key.CreatedAt)
+	if err == nil {
+		return key, true, nil
+	}
+	if foreignKeyViolation(err) {
+		return domain.IdempotencyKey{}, false, domain.ErrNotFound
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return domain.IdempotencyKey{}, false, err
+	}
+
+	var stored domain.IdempotencyKey
+	query = `
+		SELECT user_id, idempotency_key, request_hash, response, created_at
+		FROM idempotency_keys
+		WHERE user_id = $1 AND idempotency_key = $2`
+	err = q.QueryRowContext(ctx, query, key.UserID, key.Key).
+		Scan(&stored.UserID, &stored.Key, &stored.RequestHash, &stored.Response, &stored.CreatedAt)
+	if err != nil {
+		return domain.IdempotencyKey{}, false, notFound(err)
+	}
+	return stored, false, nil
+}
+
+func (r *idempotencyKeyRepository) SaveResponse(ctx context.Context, userID int64, key string, response []byte) error {
+	q := r.getQuerier(ctx)
+
+	query := `
+		UPDATE idempotency_keys
+		SET response = $3
+		WHERE user_id = $1 AND idempotency_key = $2`
+	// Sent as text, lib/pq would encode a []byte argument as bytea.
+	_, err := q.ExecContext(ctx, query, userID, key, string(response))
+	return err
+}
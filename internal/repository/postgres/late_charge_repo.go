@@ -0,0 +1,103 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/elokanugrah/go-financing-btpns/internal/domain"
+)
+
+type lateChargeRepository struct {
+	db *sql.DB
+}
+
+func NewLateChargeRepository(db *sql.DB) domain.LateChargeRepository {
+	return &lateChargeRepository{db: db}
+}
+
+// getQuerier extracts a transaction from the context if it exists,
+// otherwise it returns the base database connection.
+func (r *lateChargeRepository) getQuerier(ctx context.Context) querier {
+	tx, ok := ctx.Value(txKey{}).(*sql.Tx)
+	if ok {
+		return tx
+	}
+
+	return r.db
+}
+
+func (r *lateChargeRepository) ListByFacilityID(ctx context.Context, userFacilityID int64) ([]domain.LateCharge, error) {
+	query := `
+		SELECT c.late_charge_id, c.user_facility_id, c.detail_id, d.due_date, c.days_late, c.amount,
+			c.paid_amount, c.status, c.assessed_on, c.paid_at, c.created_at, c.updated_at
+		FROM late_charges c
+		JOIN user_facility_details d ON d.detail_id = c.detail_id
+		WHERE c.user_facility_id = $1
+		ORDER BY d.due_date ASC, c.detail_id ASC`
+	rows, err := r.getQuerier(ctx).QueryContext(ctx, query, userFacilityID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var charges []domain.LateCharge
+	for rows.Next() {
+		var c domain.LateCharge
+		err := rows.Scan(
+			&c.LateChargeID,
+			&c.UserFacilityID,
+			&c.DetailID,
+			&c.DueDate,
+			&c.DaysLate,
+			&c.Amount,
+			&c.PaidAmount,
+			&c.Status,
+			&c.AssessedOn,
+			&c.PaidAt,
+			&c.CreatedAt,
+			&c.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		charges = append(charges, c)
+	}
+
+	return charges, rows.Err()
+}
+
+// Save inserts the charge of an installment, or brings the amount of the existing one up to date.
+func (r *lateChargeRepository) Save(ctx context.Context, c *domain.LateCharge) error {
+	query := `
+		INSERT INTO late_charges
+		(user_facility_id, detail_id, days_late, amount, paid_amount, status, assessed_on, paid_at, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, NOW(), NOW())
+		ON CONFLICT (detail_id) DO UPDATE SET
+			days_late = EXCLUDED.days_late,
+			amount = EXCLUDED.amount,
+			status = EXCLUDED.status,
+			assessed_on = EXCLUDED.assessed_on,
+			paid_at = EXCLUDED.paid_at,
+			updated_at = NOW()
+		RETURNING late_charge_id, created_at, updated_at`
+	return r.getQuerier(ctx).QueryRowContext(ctx, query,
+		c.UserFacilityID,
+		c.DetailID,
+		c.DaysLate,
+		c.Amount,
+		c.PaidAmount,
+		c.Status,
+		c.AssessedOn,
+		c.PaidAt,
+	).Scan(&c.LateChargeID, &c.CreatedAt, &c.UpdatedAt)
+}
+
+// UpdatePayment stores the paid amount and status of a charge after a payment was allocated to it.
+func (r *lateChargeRepository) UpdatePayment(ctx context.Context, c domain.LateCharge) error {
+	query := `
+		UPDATE late_charges
+		SET paid_amount = $2, status = $3, paid_at = $4, updated_at = NOW()
+		WHERE late_charge_id = $1`
+	_, err := r.getQuerier(ctx).ExecContext(ctx, query, c.LateChargeID, c.PaidAmount, c.Status, c.PaidAt)
+	return err
+}
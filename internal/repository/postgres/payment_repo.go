@@ -0,0 +1,86 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/elokanugrah/go-financing-btpns/internal/domain"
+)
+
+type paymentRepository struct {
+	db *sql.DB
+}
+
+func NewPaymentRepository(db *sql.DB) domain.PaymentRepository {
+	return &paymentRepository{db: db}
+}
+
+// getQuerier extracts a transaction from the context if it exists,
+// otherwise it returns the base database connection.
+func (r *paymentRepository) getQuerier(ctx context.Context) querier {
+	tx, ok := ctx.Value(txKey{}).(*sql.Tx)
+	if ok {
+		return tx
+	}
+
+	return r.db
+}
+
+// Create stores the payment and its allocations, it should run inside a transaction
+// so a payment is never stored without them.
+func (r *paymentRepository) Create(ctx context.Context, p *domain.Payment) error {
+	q := r.getQuerier(ctx)
+
+	query := `
+		INSERT INTO payments (user_facility_id, amount, credit_amount, reference, paid_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, NOW())
+		RETURNING payment_id, created_at`
+	err := q.QueryRowContext(ctx, query, p.UserFacilityID, p.Amount, p.CreditAmount, p.Reference, p.PaidAt).
+		Scan(&p.PaymentID, &p.CreatedAt)
+	if err != nil {
+		return err
+	}
+
+	query = `
+		INSERT INTO payment_allocations (payment_id, detail_id, late_charge_id, amount)
+		VALUES ($1, $2, $3, $4)
+		RETURNING allocation_id`
+	for i := range p.Allocations {
+		a := &p.Allocations[i]
+		a.PaymentID = p.PaymentID
+		lateChargeID := sql.NullInt64{Int64: a.LateChargeID, Valid: a.LateChargeID != 0}
+		if err := q.QueryRowContext(ctx, query, a.PaymentID, a.DetailID, lateChargeID, a.Amount).Scan(&a.AllocationID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *paymentRepository) PaidByDetail(ctx context.Context, userFacilityID int64, asOf time.Time) (map[int64]domain.Money, error) {
+	query := `
+		SELECT a.detail_id, SUM(a.amount)
+		FROM payment_allocations a
+		JOIN payments p ON p.payment_id = a.payment_id
+		WHERE p.user_facility_id = $1 AND p.paid_at <= $2::date AND a.late_charge_id IS NULL
+		GROUP BY a.detail_id`
+	rows, err := r.getQuerier(ctx).QueryContext(ctx, query, userFacilityID, asOf)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	paid := make(map[int64]domain.Money)
+	for rows.Next() {
+		var (
+			detailID int64
+			amount   domain.Money
+		)
+		if err := rows.Scan(&detailID, &amount); err != nil {
+			return nil, err
+		}
+		paid[detailID] = amount
+	}
+
+	return paid, rows.Err()
+}
@@ -0,0 +1,27 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/elokanugrah/go-financing-btpns/internal/domain"
+)
+
+type productRepository struct {
+	db *sql.DB
+}
+
+func NewProductRepository(db *sql.DB) domain.ProductRepository {
+	return &productRepository{db: db}
+}
+
+func (r *productRepository) GetByCode(ctx context.Context, productCode string) (domain.Product, error) {
+	var p domain.Product
+	query := `
+		SELECT product_id, product_code, name, calculation_method, grace_period_months, grace_type, created_at, updated_at
+		FROM products
+		WHERE product_code = $1`
+	err := r.db.QueryRowContext(ctx, query, productCode).
+		Scan(&p.ProductID, &p.ProductCode, &p.Name, &p.CalculationMethod, &p.GracePeriod.Months, &p.GracePeriod.Type, &p.CreatedAt, &p.UpdatedAt)
+	return p, notFound(err)
+}
@@ -3,6 +3,10 @@ package postgres
 import (
 	"context"
 	"database/sql"
+	"errors"
+
+	"github.com/elokanugrah/go-financing-btpns/internal/domain"
+	"github.com/lib/pq"
 )
 
 // querier is an interface that is satisfied by both *sql.DB and *sql.Tx.
@@ -12,3 +16,37 @@ type querier interface {
 	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
 	PrepareContext(ctx context.Context, query string) (*sql.Stmt, error)
 }
+
+// rowScanner is an interface that is satisfied by both *sql.Row and *sql.Rows.
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// notFound translates sql.ErrNoRows into domain.ErrNotFound so callers never depend on database/sql.
+func notFound(err error) error {
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.ErrNotFound
+	}
+	return err
+}
+
+// uniqueViolationCode is the PostgreSQL error code of a unique constraint violation.
+const uniqueViolationCode = "23505"
+
+// uniqueViolation returns the name of the unique constraint err violates, if any.
+func uniqueViolation(err error) (string, bool) {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == uniqueViolationCode {
+		return pqErr.Constraint, true
+	}
+	return "", false
+}
+
+// foreignKeyViolationCode is the PostgreSQL error code of a foreign key constraint violation.
+const foreignKeyViolationCode = "23503"
+
+// foreignKeyViolation reports whether err is a foreign key constraint violation.
+func foreignKeyViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == foreignKeyViolationCode
+}
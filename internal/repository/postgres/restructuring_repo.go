@@ -0,0 +1,47 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/elokanugrah/go-financing-btpns/internal/domain"
+)
+
+type restructuringRepository struct {
+	db *sql.DB
+}
+
+func NewRestructuringRepository(db *sql.DB) domain.RestructuringRepository {
+	return &restructuringRepository{db: db}
+}
+
+// getQuerier extracts a transaction from the context if it exists,
+// otherwise it returns the base database connection.
+func (r *restructuringRepository) getQuerier(ctx context.Context) querier {
+	tx, ok := ctx.Value(txKey{}).(*sql.Tx)
+	if ok {
+		return tx
+	}
+
+	return r.db
+}
+
+func (r *restructuringRepository) Create(ctx context.Context, rs *domain.Restructuring) error {
+	query := `
+		INSERT INTO restructurings
+		(user_facility_id, from_version, to_version, outstanding_principal, remaining_margin, tenor, start_date, monthly_installment, reason, actor, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, NOW())
+		RETURNING restructuring_id, created_at`
+	return r.getQuerier(ctx).QueryRowContext(ctx, query,
+		rs.UserFacilityID,
+		rs.FromVersion,
+		rs.ToVersion,
+		rs.OutstandingPrincipal,
+		rs.RemainingMargin,
+		rs.Tenor,
+		rs.StartDate,
+		rs.MonthlyInstallment,
+		rs.Reason,
+		rs.Actor,
+	).Scan(&rs.RestructuringID, &rs.CreatedAt)
+}
@@ -0,0 +1,50 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/elokanugrah/go-financing-btpns/internal/domain"
+)
+
+type settlementRepository struct {
+	db *sql.DB
+}
+
+func NewSettlementRepository(db *sql.DB) domain.SettlementRepository {
+	return &settlementRepository{db: db}
+}
+
+// getQuerier extracts a transaction from the context if it exists,
+// otherwise it returns the base database connection.
+func (r *settlementRepository) getQuerier(ctx context.Context) querier {
+	tx, ok := ctx.Value(txKey{}).(*sql.Tx)
+	if ok {
+		return tx
+	}
+
+	return r.db
+}
+
+func (r *settlementRepository) Create(ctx context.Context, s *domain.Settlement) error {
+	q := r.getQuerier(ctx)
+
+	query := `
+		INSERT INTO settlements
+		(user_facility_id, payment_id, settlement_date, outstanding_principal, remaining_margin, unearned_margin, rebate_amount, late_charge_amount, credit_applied, payoff_amount, actor, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, NOW())
+		RETURNING settlement_id, created_at`
+	return q.QueryRowContext(ctx, query,
+		s.UserFacilityID,
+		s.PaymentID,
+		s.SettlementDate,
+		s.OutstandingPrincipal,
+		s.RemainingMargin,
+		s.UnearnedMargin,
+		s.Rebate,
+		s.LateCharges,
+		s.CreditApplied,
+		s.PayoffAmount,
+		s.Actor,
+	).Scan(&s.SettlementID, &s.CreatedAt)
+}
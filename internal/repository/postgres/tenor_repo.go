@@ -3,6 +3,7 @@ package postgres
 import (
 	"context"
 	"database/sql"
+	"time"
 
 	"github.com/elokanugrah/go-financing-btpns/internal/domain"
 )
@@ -15,8 +16,9 @@ func NewTenorRepository(db *sql.DB) domain.TenorRepository {
 	return &tenorRepository{db: db}
 }
 
+// GetAll returns the active tenors.
 func (r *tenorRepository) GetAll(ctx context.Context) ([]domain.Tenor, error) {
-	rows, err := r.db.QueryContext(ctx, `SELECT tenor_id, tenor_value, created_at, updated_at FROM tenors ORDER BY tenor_value ASC`)
+	rows, err := r.db.QueryContext(ctx, `SELECT tenor_id, tenor_value, is_active, created_at, updated_at FROM tenors WHERE is_active ORDER BY tenor_value ASC`)
 	if err != nil {
 		return nil, err
 	}
@@ -25,11 +27,66 @@ func (r *tenorRepository) GetAll(ctx context.Context) ([]domain.Tenor, error) {
 	var tenors []domain.Tenor
 	for rows.Next() {
 		var t domain.Tenor
-		if err := rows.Scan(&t.TenorID, &t.TenorValue, &t.CreatedAt, &t.UpdatedAt); err != nil {
+		if err := rows.Scan(&t.TenorID, &t.TenorValue, &t.IsActive, &t.CreatedAt, &t.UpdatedAt); err != nil {
 			return nil, err
 		}
 		tenors = append(tenors, t)
 	}
 
-	return tenors, nil
+	return tenors, rows.Err()
+}
+
+// GetByValue returns the tenor regardless of its active flag, callers decide how to treat inactive tenors.
+func (r *tenorRepository) GetByValue(ctx context.Context, tenorValue int) (domain.Tenor, error) {
+	var t domain.Tenor
+	query := `
+		SELECT tenor_id, tenor_value, is_active, created_at, updated_at
+		FROM tenors
+		WHERE tenor_value = $1`
+	err := r.db.QueryRowContext(ctx, query, tenorValue).
+		Scan(&t.TenorID, &t.TenorValue, &t.IsActive, &t.CreatedAt, &t.UpdatedAt)
+	return t, notFound(err)
+}
+
+// GetAllPriced returns the active tenors that have a margin rate for the product in effect at the given time.
+func (r *tenorRepository) GetAllPriced(ctx context.Context, productCode string, at time.Time) ([]domain.Tenor, error) {
+	query := `
+		SELECT t.tenor_id, t.tenor_value, t.is_active, m.margin_rate, t.created_at, t.updated_at
+		FROM tenors t
+		JOIN tenor_margin_rates m ON m.tenor_id = t.tenor_id
+		WHERE t.is_active
+		  AND m.product_code = $1
+		  AND m.effective_from <= $2::date
+		  AND (m.effective_to IS NULL OR m.effective_to > $2::date)
+		ORDER BY t.tenor_value ASC`
+	rows, err := r.db.QueryContext(ctx, query, productCode, at)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tenors []domain.Tenor
+	for rows.Next() {
+		var t domain.Tenor
+		if err := rows.Scan(&t.TenorID, &t.TenorValue, &t.IsActive, &t.MarginRate, &t.CreatedAt, &t.UpdatedAt); err != nil {
+			return nil, err
+		}
+		tenors = append(tenors, t)
+	}
+
+	return tenors, rows.Err()
+}
+
+func (r *tenorRepository) GetMarginRate(ctx context.Context, productCode string, tenorValue int, at time.Time) (domain.Rate, error) {
+	var rate domain.Rate
+	query := `
+		SELECT m.margin_rate
+		FROM tenor_margin_rates m
+		JOIN tenors t ON t.tenor_id = m.tenor_id
+		WHERE m.product_code = $1
+		  AND t.tenor_value = $2
+		  AND m.effective_from <= $3::date
+		  AND (m.effective_to IS NULL OR m.effective_to > $3::date)`
+	err := r.db.QueryRowContext(ctx, query, productCode, tenorValue, at).Scan(&rate)
+	return rate, notFound(err)
 }
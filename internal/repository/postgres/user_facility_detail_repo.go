@@ -5,6 +5,7 @@ import (
 	"database/sql"
 
 	"github.com/elokanugrah/go-financing-btpns/internal/domain"
+	"github.com/lib/pq"
 )
 
 type userFacilityDetailRepository struct {
@@ -32,8 +33,8 @@ func (r *userFacilityDetailRepository) BulkCreate(ctx context.Context, details [
 
 	query := `
 		INSERT INTO user_facility_details
-		(user_facility_id, due_date, installment_amount, created_at, updated_at)
-		VALUES ($1, $2, $3, NOW(), NOW())`
+		(user_facility_id, schedule_version, due_date, installment_amount, principal_amount, margin_amount, outstanding_after, status, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, NOW(), NOW())`
 
 	stmt, err := q.PrepareContext(ctx, query)
 	if err != nil {
@@ -42,10 +43,80 @@ func (r *userFacilityDetailRepository) BulkCreate(ctx context.Context, details [
 	defer stmt.Close()
 
 	for _, d := range details {
-		_, err := stmt.ExecContext(ctx, d.UserFacilityID, d.DueDate, d.InstallmentAmount)
+		_, err := stmt.ExecContext(ctx, d.UserFacilityID, d.ScheduleVersion, d.DueDate, d.InstallmentAmount, d.PrincipalAmount, d.MarginAmount, d.OutstandingAfter, d.Status)
 		if err != nil {
 			return err
 		}
 	}
 	return nil
 }
+
+const userFacilityDetailColumns = `
+	detail_id, user_facility_id, due_date, installment_amount, principal_amount, margin_amount,
+	outstanding_after, paid_amount, status, paid_at, schedule_version, created_at, updated_at`
+
+func (r *userFacilityDetailRepository) ListByFacilityID(ctx context.Context, userFacilityID int64) ([]domain.UserFacilityDetail, error) {
+	query := `SELECT ` + userFacilityDetailColumns + `
+		FROM user_facility_details
+		WHERE user_facility_id = $1 AND superseded_at IS NULL
+		ORDER BY due_date ASC, detail_id ASC`
+	rows, err := r.getQuerier(ctx).QueryContext(ctx, query, userFacilityID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var details []domain.UserFacilityDetail
+	for rows.Next() {
+		d, err := scanUserFacilityDetail(rows)
+		if err != nil {
+			return nil, err
+		}
+		details = append(details, d)
+	}
+
+	return details, rows.Err()
+}
+
+func scanUserFacilityDetail(row rowScanner) (domain.UserFacilityDetail, error) {
+	var d domain.UserFacilityDetail
+	err := row.Scan(
+		&d.DetailID,
+		&d.UserFacilityID,
+		&d.DueDate,
+		&d.InstallmentAmount,
+		&d.PrincipalAmount,
+		&d.MarginAmount,
+		&d.OutstandingAfter,
+		&d.PaidAmount,
+		&d.Status,
+		&d.PaidAt,
+		&d.ScheduleVersion,
+		&d.CreatedAt,
+		&d.UpdatedAt,
+	)
+	return d, err
+}
+
+// UpdatePayment stores the paid amount and status of an installment after a payment was allocated to it.
+func (r *userFacilityDetailRepository) UpdatePayment(ctx context.Context, d domain.UserFacilityDetail) error {
+	q := r.getQuerier(ctx)
+
+	query := `
+		UPDATE user_facility_details
+		SET paid_amount = $2, status = $3, paid_at = $4, updated_at = NOW()
+		WHERE detail_id = $1`
+	_, err := q.ExecContext(ctx, query, d.DetailID, d.PaidAmount, d.Status, d.PaidAt)
+	return err
+}
+
+func (r *userFacilityDetailRepository) Supersede(ctx context.Context, detailIDs []int64) error {
+	q := r.getQuerier(ctx)
+
+	query := `
+		UPDATE user_facility_details
+		SET superseded_at = NOW(), updated_at = NOW()
+		WHERE detail_id = ANY($1) AND superseded_at IS NULL`
+	_, err := q.ExecContext(ctx, query, pq.Array(detailIDs))
+	return err
+}
@@ -3,6 +3,7 @@ package postgres
 import (
 	"context"
 	"database/sql"
+	"time"
 
 	"github.com/elokanugrah/go-financing-btpns/internal/domain"
 )
@@ -15,19 +16,82 @@ func NewUserFacilityLimitRepository(db *sql.DB) domain.UserFacilityLimitReposito
 	return &userFacilityLimitRepository{db: db}
 }
 
+// getQuerier extracts a transaction from the context if it exists,
+// otherwise it returns the base database connection.
+func (r *userFacilityLimitRepository) getQuerier(ctx context.Context) querier {
+	tx, ok := ctx.Value(txKey{}).(*sql.Tx)
+	if ok {
+		return tx
+	}
+
+	return r.db
+}
+
+func (r *userFacilityLimitRepository) Create(ctx context.Context, l *domain.UserFacilityLimit) error {
+	q := r.getQuerier(ctx)
+
+	query := `
+		INSERT INTO user_facility_limits (user_id, limit_amount, used_amount, limit_type, expires_at, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, NOW(), NOW())
+		RETURNING facility_limit_id, created_at, updated_at`
+	return q.QueryRowContext(ctx, query, l.UserID, l.LimitAmount, l.UsedAmount, l.LimitType, l.ExpiresAt).
+		Scan(&l.FacilityLimitID, &l.CreatedAt, &l.UpdatedAt)
+}
+
 func (r *userFacilityLimitRepository) GetByID(ctx context.Context, facilityLimitID int64) (domain.UserFacilityLimit, error) {
-	var limit domain.UserFacilityLimit
 	query := `
-		SELECT facility_limit_id, user_id, limit_amount, created_at, updated_at
+		SELECT facility_limit_id, user_id, limit_amount, used_amount, limit_type, expires_at, created_at, updated_at
+		FROM user_facility_limits
+		WHERE facility_limit_id = $1`
+	return r.scanOne(ctx, query, facilityLimitID)
+}
+
+// GetByIDForUpdate reads the limit and locks its row until the surrounding transaction ends,
+// so it must be called with a context obtained from TransactionManager.WithTransaction.
+func (r *userFacilityLimitRepository) GetByIDForUpdate(ctx context.Context, facilityLimitID int64) (domain.UserFacilityLimit, error) {
+	query := `
+		SELECT facility_limit_id, user_id, limit_amount, used_amount, limit_type, expires_at, created_at, updated_at
 		FROM user_facility_limits
+		WHERE facility_limit_id = $1
+		FOR UPDATE`
+	return r.scanOne(ctx, query, facilityLimitID)
+}
+
+func (r *userFacilityLimitRepository) UpdateUsedAmount(ctx context.Context, facilityLimitID int64, usedAmount domain.Money) error {
+	q := r.getQuerier(ctx)
+
+	query := `
+		UPDATE user_facility_limits
+		SET used_amount = $2, updated_at = NOW()
 		WHERE facility_limit_id = $1`
-	err := r.db.QueryRowContext(ctx, query, facilityLimitID).
+	_, err := q.ExecContext(ctx, query, facilityLimitID, usedAmount)
+	return err
+}
+
+func (r *userFacilityLimitRepository) UpdateLimit(ctx context.Context, facilityLimitID int64, limitAmount domain.Money, expiresAt *time.Time) error {
+	q := r.getQuerier(ctx)
+
+	query := `
+		UPDATE user_facility_limits
+		SET limit_amount = $2, expires_at = $3, updated_at = NOW()
+		WHERE facility_limit_id = $1
+		RETURNING facility_limit_id`
+	var id int64
+	return notFound(q.QueryRowContext(ctx, query, facilityLimitID, limitAmount, expiresAt).Scan(&id))
+}
+
+func (r *userFacilityLimitRepository) scanOne(ctx context.Context, query string, args ...interface{}) (domain.UserFacilityLimit, error) {
+	var limit domain.UserFacilityLimit
+	err := r.getQuerier(ctx).QueryRowContext(ctx, query, args...).
 		Scan(
 			&limit.FacilityLimitID,
 			&limit.UserID,
 			&limit.LimitAmount,
+			&limit.UsedAmount,
+			&limit.LimitType,
+			&limit.ExpiresAt,
 			&limit.CreatedAt,
 			&limit.UpdatedAt,
 		)
-	return limit, err
+	return limit, notFound(err)
 }
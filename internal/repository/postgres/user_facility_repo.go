@@ -3,8 +3,11 @@ package postgres
 import (
 	"context"
 	"database/sql"
+	"fmt"
+	"strings"
 
 	"github.com/elokanugrah/go-financing-btpns/internal/domain"
+	"github.com/lib/pq"
 )
 
 type userFacilityRepository struct {
@@ -32,17 +35,218 @@ func (r *userFacilityRepository) Create(ctx context.Context, uf *domain.UserFaci
 
 	query := `
 		INSERT INTO user_facilities 
-		(user_id, facility_limit_id, amount, tenor, start_date, monthly_installment, total_margin, total_payment, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, NOW(), NOW())
+		(user_id, facility_limit_id, product_code, margin_rate, calculation_method, amount, tenor, grace_period_months, grace_type, start_date, monthly_installment, total_margin, total_payment, status, schedule_version, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, NOW(), NOW())
 		RETURNING user_facility_id`
 	return q.QueryRowContext(ctx, query,
 		uf.UserID,
 		uf.FacilityLimitID,
+		uf.ProductCode,
+		uf.MarginRate,
+		uf.CalculationMethod,
 		uf.Amount,
 		uf.Tenor,
+		uf.GracePeriod.Months,
+		uf.GracePeriod.Type,
 		uf.StartDate,
 		uf.MonthlyInstallment,
 		uf.TotalMargin,
 		uf.TotalPayment,
+		uf.Status,
+		uf.ScheduleVersion,
 	).Scan(&uf.UserFacilityID)
 }
+
+const userFacilityColumns = `
+	user_facility_id, user_id, facility_limit_id, product_code, margin_rate, calculation_method,
+	amount, tenor, grace_period_months, grace_type, start_date, monthly_installment, total_margin, total_payment, status, credit_balance, schedule_version, created_at, updated_at`
+
+func (r *userFacilityRepository) GetByID(ctx context.Context, id int64) (domain.UserFacility, error) {
+	query := `SELECT ` + userFacilityColumns + ` FROM user_facilities WHERE user_facility_id = $1`
+	uf, err := scanUserFacility(r.getQuerier(ctx).QueryRowContext(ctx, query, id))
+	return uf, notFound(err)
+}
+
+// GetByIDForUpdate reads the facility and locks its row until the surrounding transaction ends.
+func (r *userFacilityRepository) GetByIDForUpdate(ctx context.Context, id int64) (domain.UserFacility, error) {
+	query := `SELECT ` + userFacilityColumns + ` FROM user_facilities WHERE user_facility_id = $1 FOR UPDATE`
+	uf, err := scanUserFacility(r.getQuerier(ctx).QueryRowContext(ctx, query, id))
+	return uf, notFound(err)
+}
+
+func (r *userFacilityRepository) UpdateStatus(ctx context.Context, id int64, status domain.FacilityStatus) error {
+	q := r.getQuerier(ctx)
+
+	query := `
+		UPDATE user_facilities
+		SET status = $2, updated_at = NOW()
+		WHERE user_facility_id = $1`
+	_, err := q.ExecContext(ctx, query, id, status)
+	return err
+}
+
+func (r *userFacilityRepository) UpdateCreditBalance(ctx context.Context, id int64, creditBalance domain.Money) error {
+	q := r.getQuerier(ctx)
+
+	query := `
+		UPDATE user_facilities
+		SET credit_balance = $2, updated_at = NOW()
+		WHERE user_facility_id = $1`
+	_, err := q.ExecContext(ctx, query, id, creditBalance)
+	return err
+}
+
+func (r *userFacilityRepository) UpdateScheduleVersion(ctx context.Context, id int64, version int) error {
+	q := r.getQuerier(ctx)
+
+	query := `
+		UPDATE user_facilities
+		SET schedule_version = $2, updated_at = NOW()
+		WHERE user_facility_id = $1`
+	_, err := q.ExecContext(ctx, query, id, version)
+	return err
+}
+
+func scanUserFacility(row rowScanner) (domain.UserFacility, error) {
+	var uf domain.UserFacility
+	err := row.Scan(
+		&uf.UserFacilityID,
+		&uf.UserID,
+		&uf.FacilityLimitID,
+		&uf.ProductCode,
+		&uf.MarginRate,
+		&uf.CalculationMethod,
+		&uf.Amount,
+		&uf.Tenor,
+		&uf.GracePeriod.Months,
+		&uf.GracePeriod.Type,
+		&uf.StartDate,
+		&uf.MonthlyInstallment,
+		&uf.TotalMargin,
+		&uf.TotalPayment,
+		&uf.Status,
+		&uf.CreditBalance,
+		&uf.ScheduleVersion,
+		&uf.CreatedAt,
+		&uf.UpdatedAt,
+	)
+	return uf, err
+}
+
+// facilitySortColumns whitelists the columns a list can be ordered by.
+var facilitySortColumns = map[string]string{
+	domain.FacilitySortCreatedAt: "created_at",
+	domain.FacilitySortStartDate: "start_date",
+	domain.FacilitySortAmount:    "amount",
+	domain.FacilitySortTenor:     "tenor",
+}
+
+func (r *userFacilityRepository) UpdateTerms(ctx context.Context, uf domain.UserFacility) error {
+	q := r.getQuerier(ctx)
+
+	query := `
+		UPDATE user_facilities
+		SET tenor = $2, monthly_installment = $3, total_margin = $4, total_payment = $5, updated_at = NOW()
+		WHERE user_facility_id = $1`
+	_, err := q.ExecContext(ctx, query, uf.UserFacilityID, uf.Tenor, uf.MonthlyInstallment, uf.TotalMargin, uf.TotalPayment)
+	return err
+}
+
+func (r *userFacilityRepository) ListByUser(ctx context.Context, filter domain.UserFacilityFilter) ([]domain.UserFacility, int64, error) {
+	q := r.getQuerier(ctx)
+
+	conditions := []string{"user_id = $1"}
+	args := []interface{}{filter.UserID}
+	if filter.Tenor > 0 {
+		args = append(args, filter.Tenor)
+		conditions = append(conditions, fmt.Sprintf("tenor = $%d", len(args)))
+	}
+	if filter.Status != "" {
+		args = append(args, filter.Status)
+		conditions = append(conditions, fmt.Sprintf("status = $%d", len(args)))
+	}
+	if filter.StartDateFrom != nil {
+		args = append(args, *filter.StartDateFrom)
+		conditions = append(conditions, fmt.Sprintf("start_date >= $%d", len(args)))
+	}
+	if filter.StartDateTo != nil {
+		args = append(args, *filter.StartDateTo)
+		conditions = append(conditions, fmt.Sprintf("start_date <= $%d", len(args)))
+	}
+	where := " WHERE " + strings.Join(conditions, " AND ")
+
+	var total int64
+	if err := q.QueryRowContext(ctx, `SELECT COUNT(*) FROM user_facilities`+where, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	column, ok := facilitySortColumns[filter.SortBy]
+	if !ok {
+		column = "created_at"
+	}
+	direction := "ASC"
+	if filter.SortDesc {
+		direction = "DESC"
+	}
+
+	args = append(args, filter.Limit, filter.Offset)
+	query := `SELECT ` + userFacilityColumns + ` FROM user_facilities` + where +
+		fmt.Sprintf(" ORDER BY %s %s, user_facility_id %s LIMIT $%d OFFSET $%d", column, direction, direction, len(args)-1, len(args))
+
+	rows, err := q.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var facilities []domain.UserFacility
+	for rows.Next() {
+		uf, err := scanUserFacility(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		facilities = append(facilities, uf)
+	}
+
+	return facilities, total, rows.Err()
+}
+
+func (r *userFacilityRepository) CountByUser(ctx context.Context, userID int64, statuses []domain.FacilityStatus) (int, error) {
+	query := `SELECT COUNT(*) FROM user_facilities WHERE user_id = $1 AND status = ANY($2)`
+	var count int
+	err := r.getQuerier(ctx).QueryRowContext(ctx, query, userID, pq.Array(facilityStatusValues(statuses))).Scan(&count)
+	return count, err
+}
+
+func (r *userFacilityRepository) ListByStatus(ctx context.Context, statuses []domain.FacilityStatus, afterID int64, limit int) ([]domain.UserFacility, error) {
+	query := `SELECT ` + userFacilityColumns + `
+		FROM user_facilities
+		WHERE status = ANY($1) AND user_facility_id > $2
+		ORDER BY user_facility_id ASC
+		LIMIT $3`
+	rows, err := r.getQuerier(ctx).QueryContext(ctx, query, pq.Array(facilityStatusValues(statuses)), afterID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var facilities []domain.UserFacility
+	for rows.Next() {
+		uf, err := scanUserFacility(rows)
+		if err != nil {
+			return nil, err
+		}
+		facilities = append(facilities, uf)
+	}
+
+	return facilities, rows.Err()
+}
+
+// facilityStatusValues converts statuses to the text array pq.Array sends for "status = ANY".
+func facilityStatusValues(statuses []domain.FacilityStatus) []string {
+	values := make([]string, len(statuses))
+	for i, s := range statuses {
+		values[i] = string(s)
+	}
+	return values
+}
@@ -0,0 +1,170 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/elokanugrah/go-financing-btpns/internal/domain"
+)
+
+type userRepository struct {
+	db *sql.DB
+}
+
+func NewUserRepository(db *sql.DB) domain.UserRepository {
+	return &userRepository{db: db}
+}
+
+// getQuerier extracts a transaction from the context if it exists,
+// otherwise it returns the base database connection.
+func (r *userRepository) getQuerier(ctx context.Context) querier {
+	tx, ok := ctx.Value(txKey{}).(*sql.Tx)
+	if ok {
+		return tx
+	}
+
+	return r.db
+}
+
+func (r *userRepository) Create(ctx context.Context, u *domain.User) error {
+	query := `
+		INSERT INTO users (name, phone, nik, date_of_birth, address, kyc_status, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, NOW(), NOW())
+		RETURNING user_id, created_at, updated_at`
+	err := r.getQuerier(ctx).QueryRowContext(ctx, query,
+		u.Name,
+		u.Phone,
+		nullableNIK(u.NIK),
+		u.DateOfBirth,
+		u.Address,
+		u.KYCStatus,
+	).Scan(&u.UserID, &u.CreatedAt, &u.UpdatedAt)
+	return userConflict(err)
+}
+
+const userColumns = `user_id, name, phone, nik, date_of_birth, address, kyc_status, created_at, updated_at`
+
+func (r *userRepository) GetByID(ctx context.Context, id int64) (domain.User, error) {
+	query := `SELECT ` + userColumns + ` FROM users WHERE user_id = $1`
+	u, err := scanUser(r.getQuerier(ctx).QueryRowContext(ctx, query, id))
+	return u, notFound(err)
+}
+
+// GetByIDForUpdate reads the user and locks its row until the surrounding transaction ends.
+func (r *userRepository) GetByIDForUpdate(ctx context.Context, id int64) (domain.User, error) {
+	query := `SELECT ` + userColumns + ` FROM users WHERE user_id = $1 FOR UPDATE`
+	u, err := scanUser(r.getQuerier(ctx).QueryRowContext(ctx, query, id))
+	return u, notFound(err)
+}
+
+func (r *userRepository) Update(ctx context.Context, u *domain.User) error {
+	query := `
+		UPDATE users
+		SET name = $2, phone = $3, nik = $4, date_of_birth = $5, address = $6, kyc_status = $7, updated_at = NOW()
+		WHERE user_id = $1
+		RETURNING updated_at`
+	err := r.getQuerier(ctx).QueryRowContext(ctx, query,
+		u.UserID,
+		u.Name,
+		u.Phone,
+		nullableNIK(u.NIK),
+		u.DateOfBirth,
+		u.Address,
+		u.KYCStatus,
+	).Scan(&u.UpdatedAt)
+	return notFound(userConflict(err))
+}
+
+func (r *userRepository) Search(ctx context.Context, filter domain.UserFilter) ([]domain.User, int64, error) {
+	q := r.getQuerier(ctx)
+
+	var conditions []string
+	var args []interface{}
+	if filter.Name != "" {
+		args = append(args, "%"+escapeLike(filter.Name)+"%")
+		conditions = append(conditions, fmt.Sprintf("name ILIKE $%d", len(args)))
+	}
+	if filter.Phone != "" {
+		args = append(args, filter.Phone)
+		conditions = append(conditions, fmt.Sprintf("phone = $%d", len(args)))
+	}
+	where := ""
+	if len(conditions) > 0 {
+		where = " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	var total int64
+	if err := q.QueryRowContext(ctx, `SELECT COUNT(*) FROM users`+where, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	args = append(args, filter.Limit, filter.Offset)
+	query := `SELECT ` + userColumns + ` FROM users` + where +
+		fmt.Sprintf(" ORDER BY name ASC, user_id ASC LIMIT $%d OFFSET $%d", len(args)-1, len(args))
+
+	rows, err := q.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var users []domain.User
+	for rows.Next() {
+		u, err := scanUser(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		users = append(users, u)
+	}
+
+	return users, total, rows.Err()
+}
+
+func scanUser(row rowScanner) (domain.User, error) {
+	var u domain.User
+	var nik sql.NullString
+	err := row.Scan(
+		&u.UserID,
+		&u.Name,
+		&u.Phone,
+		&nik,
+		&u.DateOfBirth,
+		&u.Address,
+		&u.KYCStatus,
+		&u.CreatedAt,
+		&u.UpdatedAt,
+	)
+	u.NIK = nik.String
+	return u, err
+}
+
+// userConflicts maps the unique indexes of users to the error reported when they are violated.
+var userConflicts = map[string]error{
+	"users_phone_key": domain.ErrPhoneRegistered,
+	"users_nik_key":   domain.ErrNIKRegistered,
+}
+
+func userConflict(err error) error {
+	constraint, ok := uniqueViolation(err)
+	if !ok {
+		return err
+	}
+	if conflictErr, ok := userConflicts[constraint]; ok {
+		return conflictErr
+	}
+	return domain.ErrConflict
+}
+
+// nullableNIK stores a missing NIK as NULL, so users without one do not collide on the unique index.
+func nullableNIK(nik string) sql.NullString {
+	return sql.NullString{String: nik, Valid: nik != ""}
+}
+
+// likeEscaper escapes the LIKE wildcards of a search term so it is matched literally.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+func escapeLike(term string) string {
+	return likeEscaper.Replace(term)
+}
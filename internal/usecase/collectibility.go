@@ -0,0 +1,120 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/elokanugrah/go-financing-btpns/internal/domain"
+	"github.com/elokanugrah/go-financing-btpns/internal/dto"
+)
+
+// collectibilityBatchSize is how many facilities the portfolio batch loads per page.
+const collectibilityBatchSize = 100
+
+// GetCollectibility computes the days past due and collectibility of a disbursed facility as of a date.
+// The result is not stored, the portfolio batch keeps the daily classification.
+func (u *financingUsecase) GetCollectibility(ctx context.Context, facilityID int64, req dto.CollectibilityRequest) (dto.CollectibilityResponse, error) {
+	asOf, err := u.pastDate(req.AsOf, "as_of")
+	if err != nil {
+		return dto.CollectibilityResponse{}, err
+	}
+
+	facility, err := u.userFacilityRepo.GetByID(ctx, facilityID)
+	if errors.Is(err, domain.ErrNotFound) {
+		return dto.CollectibilityResponse{}, errFacilityNotFound
+	}
+	if err != nil {
+		return dto.CollectibilityResponse{}, err
+	}
+	if !isRepayable(facility.Status) && facility.Status != domain.FacilityStatusPaidOff {
+		return dto.CollectibilityResponse{}, domain.ConflictError("FACILITY_NOT_DISBURSED", fmt.Sprintf("facility has no collectibility while %s", facility.Status))
+	}
+
+	aging, err := u.ageFacility(ctx, facility, asOf)
+	if err != nil {
+		return dto.CollectibilityResponse{}, err
+	}
+
+	resp := dto.CollectibilityResponse{
+		UserFacilityID:      facility.UserFacilityID,
+		AsOf:                aging.AsOf.Format("2006-01-02"),
+		DaysPastDue:         aging.DaysPastDue,
+		Collectibility:      aging.Collectibility,
+		CollectibilityLabel: aging.Collectibility.Label(),
+		OverdueInstallments: aging.OverdueInstallments,
+		OverdueAmount:       aging.OverdueAmount,
+		FacilityStatus:      facility.Status,
+	}
+	if aging.OldestDueDate != nil {
+		resp.OldestDueDate = aging.OldestDueDate.Format("2006-01-02")
+	}
+	return resp, nil
+}
+
+// RunCollectibilityBatch classifies every disbursed or active facility as of a date, stores each
+// result and returns how many facilities fall in every bucket. Running it again for the same date
+// replaces the stored results.
+func (u *financingUsecase) RunCollectibilityBatch(ctx context.Context, req dto.CollectibilityBatchRequest) (dto.CollectibilityBatchResponse, error) {
+	asOf, err := u.pastDate(req.AsOf, "as_of")
+	if err != nil {
+		return dto.CollectibilityBatchResponse{}, err
+	}
+
+	buckets := make([]dto.CollectibilityBucket, domain.CollectibilityLoss)
+	for i := range buckets {
+		c := domain.Collectibility(i + 1)
+		buckets[i] = dto.CollectibilityBucket{Collectibility: c, CollectibilityLabel: c.Label()}
+	}
+
+	resp := dto.CollectibilityBatchResponse{AsOf: asOf.Format("2006-01-02")}
+	statuses := []domain.FacilityStatus{domain.FacilityStatusDisbursed, domain.FacilityStatusActive}
+	var afterID int64
+	for {
+		facilities, err := u.userFacilityRepo.ListByStatus(ctx, statuses, afterID, collectibilityBatchSize)
+		if err != nil {
+			return dto.CollectibilityBatchResponse{}, err
+		}
+
+		for _, f := range facilities {
+			aging, err := u.ageFacility(ctx, f, asOf)
+			if err != nil {
+				return dto.CollectibilityBatchResponse{}, err
+			}
+			if err := u.collectibilityRepo.Save(ctx, aging); err != nil {
+				return dto.CollectibilityBatchResponse{}, err
+			}
+
+			bucket := &buckets[aging.Collectibility-1]
+			bucket.Facilities++
+			bucket.OverdueAmount += aging.OverdueAmount
+			resp.Facilities++
+			afterID = f.UserFacilityID
+		}
+
+		if len(facilities) < collectibilityBatchSize {
+			break
+		}
+	}
+
+	resp.Buckets = buckets
+	return resp, nil
+}
+
+// ageFacility ages the schedule with only the payments made up to asOf, so a past date is
+// classified as it stood on that day.
+func (u *financingUsecase) ageFacility(ctx context.Context, f domain.UserFacility, asOf time.Time) (domain.Aging, error) {
+	details, err := u.userFacilityDetailRepo.ListByFacilityID(ctx, f.UserFacilityID)
+	if err != nil {
+		return domain.Aging{}, err
+	}
+	paid, err := u.paymentRepo.PaidByDetail(ctx, f.UserFacilityID, asOf)
+	if err != nil {
+		return domain.Aging{}, err
+	}
+
+	aging := domain.AgeSchedule(details, paid, asOf, u.schedulePolicy.DueDates)
+	aging.UserFacilityID = f.UserFacilityID
+	return aging, nil
+}
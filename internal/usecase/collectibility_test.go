@@ -0,0 +1,122 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/elokanugrah/go-financing-btpns/internal/domain"
+	"github.com/elokanugrah/go-financing-btpns/internal/dto"
+	"github.com/elokanugrah/go-financing-btpns/internal/usecase"
+	"github.com/elokanugrah/go-financing-btpns/internal/usecase/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestFinancingUsecase_Collectibility(t *testing.T) {
+	ctx := context.Background()
+	start := time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC)
+	asOf := time.Date(2025, 5, 15, 0, 0, 0, 0, time.UTC)
+
+	schedule := func(id int64) []domain.UserFacilityDetail {
+		return []domain.UserFacilityDetail{
+			{DetailID: id*10 + 1, UserFacilityID: id, DueDate: start.AddDate(0, 1, 0), InstallmentAmount: domain.NewMoney(1000)},
+			{DetailID: id*10 + 2, UserFacilityID: id, DueDate: start.AddDate(0, 2, 0), InstallmentAmount: domain.NewMoney(1000)},
+			{DetailID: id*10 + 3, UserFacilityID: id, DueDate: start.AddDate(0, 3, 0), InstallmentAmount: domain.NewMoney(1000)},
+		}
+	}
+
+	t.Run("success - ages the facility with the payments made up to as_of", func(t *testing.T) {
+		mockUserFacilityRepo := new(mocks.UserFacilityRepository)
+		mockUserFacilityDetailRepo := new(mocks.UserFacilityDetailRepository)
+		mockPaymentRepo := new(mocks.PaymentRepository)
+		uc := usecase.NewFinancingUsecase(usecase.FinancingDeps{
+			UserFacilityDetailRepo: mockUserFacilityDetailRepo,
+			UserFacilityRepo:       mockUserFacilityRepo,
+			PaymentRepo:            mockPaymentRepo,
+			SchedulePolicy:         domain.DefaultSchedulePolicy(),
+		})
+
+		mockUserFacilityRepo.On("GetByID", ctx, int64(1)).Return(domain.UserFacility{UserFacilityID: 1, StartDate: start, Status: domain.FacilityStatusActive}, nil).Once()
+		mockUserFacilityDetailRepo.On("ListByFacilityID", ctx, int64(1)).Return(schedule(1), nil).Once()
+		mockPaymentRepo.On("PaidByDetail", ctx, int64(1), asOf).Return(map[int64]domain.Money{11: domain.NewMoney(1000), 12: domain.NewMoney(400)}, nil).Once()
+
+		res, err := uc.GetCollectibility(ctx, 1, dto.CollectibilityRequest{AsOf: "2025-05-15"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "2025-05-15", res.AsOf)
+		assert.Equal(t, 66, res.DaysPastDue)
+		assert.Equal(t, domain.CollectibilitySpecialMention, res.Collectibility)
+		assert.Equal(t, "Dalam Perhatian Khusus", res.CollectibilityLabel)
+		assert.Equal(t, 2, res.OverdueInstallments)
+		assert.Equal(t, domain.NewMoney(1600), res.OverdueAmount)
+		assert.Equal(t, "2025-03-10", res.OldestDueDate)
+	})
+
+	t.Run("Failure - facility not disbursed", func(t *testing.T) {
+		mockUserFacilityRepo := new(mocks.UserFacilityRepository)
+		uc := usecase.NewFinancingUsecase(usecase.FinancingDeps{
+			UserFacilityRepo: mockUserFacilityRepo,
+			SchedulePolicy:   domain.DefaultSchedulePolicy(),
+		})
+
+		mockUserFacilityRepo.On("GetByID", ctx, int64(1)).Return(domain.UserFacility{UserFacilityID: 1, Status: domain.FacilityStatusApproved}, nil).Once()
+
+		_, err := uc.GetCollectibility(ctx, 1, dto.CollectibilityRequest{})
+
+		assert.ErrorIs(t, err, domain.ErrConflict)
+	})
+
+	t.Run("Failure - as_of in the future", func(t *testing.T) {
+		uc := usecase.NewFinancingUsecase(usecase.FinancingDeps{
+			SchedulePolicy: domain.DefaultSchedulePolicy(),
+		})
+
+		_, err := uc.GetCollectibility(ctx, 1, dto.CollectibilityRequest{AsOf: time.Now().AddDate(0, 0, 2).Format("2006-01-02")})
+
+		assert.ErrorIs(t, err, domain.ErrValidation)
+	})
+
+	t.Run("success - batch stores every facility and counts the buckets", func(t *testing.T) {
+		mockUserFacilityRepo := new(mocks.UserFacilityRepository)
+		mockUserFacilityDetailRepo := new(mocks.UserFacilityDetailRepository)
+		mockPaymentRepo := new(mocks.PaymentRepository)
+		mockCollectibilityRepo := new(mocks.CollectibilityRepository)
+		uc := usecase.NewFinancingUsecase(usecase.FinancingDeps{
+			UserFacilityDetailRepo: mockUserFacilityDetailRepo,
+			UserFacilityRepo:       mockUserFacilityRepo,
+			PaymentRepo:            mockPaymentRepo,
+			CollectibilityRepo:     mockCollectibilityRepo,
+			SchedulePolicy:         domain.DefaultSchedulePolicy(),
+		})
+
+		statuses := []domain.FacilityStatus{domain.FacilityStatusDisbursed, domain.FacilityStatusActive}
+		mockUserFacilityRepo.On("ListByStatus", ctx, statuses, int64(0), 100).Return([]domain.UserFacility{
+			{UserFacilityID: 1, Status: domain.FacilityStatusActive},
+			{UserFacilityID: 2, Status: domain.FacilityStatusDisbursed},
+		}, nil).Once()
+		for _, id := range []int64{1, 2} {
+			mockUserFacilityDetailRepo.On("ListByFacilityID", ctx, id).Return(schedule(id), nil).Once()
+		}
+		mockPaymentRepo.On("PaidByDetail", ctx, int64(1), asOf).Return(map[int64]domain.Money{11: domain.NewMoney(1000), 12: domain.NewMoney(1000), 13: domain.NewMoney(1000)}, nil).Once()
+		mockPaymentRepo.On("PaidByDetail", ctx, int64(2), asOf).Return(map[int64]domain.Money{}, nil).Once()
+		mockCollectibilityRepo.On("Save", ctx, mock.MatchedBy(func(a domain.Aging) bool {
+			return a.UserFacilityID == 1 && a.Collectibility == domain.CollectibilityCurrent
+		})).Return(nil).Once()
+		mockCollectibilityRepo.On("Save", ctx, mock.MatchedBy(func(a domain.Aging) bool {
+			return a.UserFacilityID == 2 && a.DaysPastDue == 94 && a.Collectibility == domain.CollectibilitySubstandard
+		})).Return(nil).Once()
+
+		res, err := uc.RunCollectibilityBatch(ctx, dto.CollectibilityBatchRequest{AsOf: "2025-05-15"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, res.Facilities)
+		assert.Len(t, res.Buckets, 5)
+		assert.Equal(t, 1, res.Buckets[0].Facilities)
+		assert.Equal(t, 0, res.Buckets[1].Facilities)
+		assert.Equal(t, 1, res.Buckets[2].Facilities)
+		assert.Equal(t, domain.NewMoney(3000), res.Buckets[2].OverdueAmount)
+		mockCollectibilityRepo.AssertExpectations(t)
+	})
+}
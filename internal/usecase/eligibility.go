@@ -0,0 +1,226 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/elokanugrah/go-financing-btpns/internal/domain"
+	"github.com/elokanugrah/go-financing-btpns/internal/dto"
+)
+
+// The rules a submission has to pass, in the order SubmitFinancing checks them. SubmitFinancing
+// stops at the first rule that fails, CheckEligibility reports every one of them.
+const (
+	RuleAmount           = "AMOUNT"
+	RuleStartDate        = "START_DATE"
+	RuleStartDateWindow  = "START_DATE_WINDOW"
+	RuleTenor            = "TENOR"
+	RuleProduct          = "PRODUCT"
+	RulePricing          = "PRICING"
+	RuleGracePeriod      = "GRACE_PERIOD"
+	RuleKYC              = "KYC"
+	RuleActiveFacilities = "ACTIVE_FACILITIES"
+	RuleLimitOwnership   = "LIMIT_OWNERSHIP"
+	RuleLimitExpiry      = "LIMIT_EXPIRY"
+	RuleRemainingLimit   = "REMAINING_LIMIT"
+)
+
+// CheckEligibility runs the rules of SubmitFinancing against req without submitting anything.
+// A rule that depends on one that failed, such as the pricing of an invalid tenor, is skipped.
+func (u *financingUsecase) CheckEligibility(ctx context.Context, req dto.SubmitFinancingRequest) (dto.EligibilityResponse, error) {
+	resp := dto.EligibilityResponse{Eligible: true, Checks: make([]dto.EligibilityCheck, 0, 12)}
+
+	// check records the outcome of a rule. It hands back the errors that are not a rule failing,
+	// such as a database error, and reports whether the rule passed.
+	check := func(rule string, err error) (bool, error) {
+		var ruleErr *domain.Error
+		switch {
+		case err == nil:
+			resp.Checks = append(resp.Checks, dto.EligibilityCheck{Rule: rule, Result: dto.CheckPassed})
+			return true, nil
+		case errors.As(err, &ruleErr):
+			resp.Eligible = false
+			resp.Checks = append(resp.Checks, dto.EligibilityCheck{Rule: rule, Result: dto.CheckFailed, Code: ruleErr.Code, Message: ruleErr.Message})
+			return false, nil
+		default:
+			return false, err
+		}
+	}
+	skip := func(rule, reason string) {
+		resp.Eligible = false
+		resp.Checks = append(resp.Checks, dto.EligibilityCheck{Rule: rule, Result: dto.CheckSkipped, Message: reason})
+	}
+
+	amountValid, _ := check(RuleAmount, checkAmount(req.Amount))
+	startDate, err := parseStartDate(req.StartDate)
+	if startDateValid, _ := check(RuleStartDate, err); startDateValid {
+		check(RuleStartDateWindow, u.checkStartDateWindow(startDate))
+	} else {
+		skip(RuleStartDateWindow, "the start date must be valid first")
+	}
+
+	tenor, err := u.submissionTenor(ctx, req.Tenor)
+	tenorValid, err := check(RuleTenor, err)
+	if err != nil {
+		return dto.EligibilityResponse{}, err
+	}
+	product, err := u.getProduct(ctx, productCode(req.ProductCode))
+	productValid, err := check(RuleProduct, err)
+	if err != nil {
+		return dto.EligibilityResponse{}, err
+	}
+	if tenorValid && productValid {
+		_, err = u.submissionMarginRate(ctx, product, tenor)
+		if _, err := check(RulePricing, err); err != nil {
+			return dto.EligibilityResponse{}, err
+		}
+		_, err = submissionGracePeriod(product, tenor, req)
+		check(RuleGracePeriod, err)
+	} else {
+		skip(RulePricing, "the tenor and product must be valid first")
+		skip(RuleGracePeriod, "the tenor and product must be valid first")
+	}
+
+	if _, err := check(RuleKYC, checkKYC(u.userRepo.GetByID(ctx, req.UserID))); err != nil {
+		return dto.EligibilityResponse{}, err
+	}
+	if _, err := check(RuleActiveFacilities, u.checkActiveFacilities(ctx, req.UserID)); err != nil {
+		return dto.EligibilityResponse{}, err
+	}
+
+	limit, err := u.facilityLimitRepo.GetByID(ctx, req.FacilityLimitID)
+	if err != nil {
+		err = facilityLimitLookup(err)
+	} else {
+		err = checkLimitOwner(limit, req.UserID)
+	}
+	owned, err := check(RuleLimitOwnership, err)
+	if err != nil {
+		return dto.EligibilityResponse{}, err
+	}
+	if !owned {
+		// Nothing is told about a limit the user does not own
+		skip(RuleLimitExpiry, "the facility limit must belong to the user first")
+		skip(RuleRemainingLimit, "the facility limit must belong to the user first")
+		return resp, nil
+	}
+	check(RuleLimitExpiry, checkLimitExpiry(limit, truncateToDate(u.now())))
+	if amountValid {
+		check(RuleRemainingLimit, checkRemainingLimit(limit, req.Amount))
+	} else {
+		skip(RuleRemainingLimit, "the amount must be valid first")
+	}
+	return resp, nil
+}
+
+func checkAmount(amount domain.Money) error {
+	if amount <= 0 {
+		return errAmountNotPositive
+	}
+	return nil
+}
+
+func parseStartDate(value string) (time.Time, error) {
+	startDate, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return time.Time{}, domain.ValidationError("INVALID_START_DATE", "invalid start_date format")
+	}
+	return startDate, nil
+}
+
+// submissionTenor resolves an active tenor by its number of months.
+func (u *financingUsecase) submissionTenor(ctx context.Context, value int) (domain.Tenor, error) {
+	tenor, err := u.tenorRepo.GetByValue(ctx, value)
+	if errors.Is(err, domain.ErrNotFound) || (err == nil && !tenor.IsActive) {
+		return domain.Tenor{}, domain.ValidationError("INVALID_TENOR", "invalid tenor")
+	}
+	return tenor, err
+}
+
+// submissionMarginRate returns the margin rate the product charges for the tenor today.
+func (u *financingUsecase) submissionMarginRate(ctx context.Context, product domain.Product, tenor domain.Tenor) (domain.Rate, error) {
+	marginRate, err := u.tenorRepo.GetMarginRate(ctx, product.ProductCode, tenor.TenorValue, u.now())
+	if errors.Is(err, domain.ErrNotFound) {
+		return 0, domain.ValidationError("TENOR_NOT_PRICED", "no margin rate configured for tenor")
+	}
+	return marginRate, err
+}
+
+// submissionGracePeriod returns the grace period of the request, the zero GracePeriod when there is none.
+func submissionGracePeriod(product domain.Product, tenor domain.Tenor, req dto.SubmitFinancingRequest) (domain.GracePeriod, error) {
+	grace := gracePeriod(product, req.GracePeriodMonths, req.GraceType)
+	if err := grace.Validate(tenor.TenorValue); err != nil {
+		return domain.GracePeriod{}, domain.ValidationError("INVALID_GRACE_PERIOD", err.Error())
+	}
+	if grace.Months == 0 {
+		return domain.GracePeriod{}, nil
+	}
+	return grace, nil
+}
+
+// checkStartDateWindow refuses start dates outside the window the submission policy allows around today.
+func (u *financingUsecase) checkStartDateWindow(startDate time.Time) error {
+	if err := u.submissionPolicy.CheckStartDate(startDate, truncateToDate(u.now())); err != nil {
+		return domain.ValidationError("START_DATE_OUT_OF_WINDOW", err.Error())
+	}
+	return nil
+}
+
+// checkKYC maps the lookup of the submitting user and refuses users whose identity has not been verified.
+func checkKYC(user domain.User, err error) error {
+	if errors.Is(err, domain.ErrNotFound) {
+		return errUserNotFound
+	}
+	if err != nil {
+		return err
+	}
+	if user.KYCStatus != domain.KYCVerified {
+		return domain.ForbiddenError("KYC_NOT_VERIFIED", "user identity (KYC) is not verified")
+	}
+	return nil
+}
+
+// checkActiveFacilities refuses users who already hold as many open facilities as the submission policy allows.
+func (u *financingUsecase) checkActiveFacilities(ctx context.Context, userID int64) error {
+	if u.submissionPolicy.MaxActiveFacilities == 0 {
+		return nil
+	}
+	active, err := u.userFacilityRepo.CountByUser(ctx, userID, domain.ActiveFacilityStatuses)
+	if err != nil {
+		return err
+	}
+	if err := u.submissionPolicy.CheckActiveFacilities(active); err != nil {
+		return domain.ConflictError("MAX_ACTIVE_FACILITIES", err.Error())
+	}
+	return nil
+}
+
+// facilityLimitLookup maps the error of reading the limit a submission is made against.
+func facilityLimitLookup(err error) error {
+	if errors.Is(err, domain.ErrNotFound) {
+		return domain.NotFoundError("FACILITY_LIMIT_NOT_FOUND", "no financing facilities yet")
+	}
+	return err
+}
+
+func checkLimitOwner(limit domain.UserFacilityLimit, userID int64) error {
+	if limit.UserID != userID {
+		return domain.ForbiddenError("FACILITY_LIMIT_FORBIDDEN", "facility limit does not belong to user")
+	}
+	return nil
+}
+
+func checkLimitExpiry(limit domain.UserFacilityLimit, today time.Time) error {
+	if limit.IsExpired(today) {
+		return errFacilityLimitExpired
+	}
+	return nil
+}
+
+func checkRemainingLimit(limit domain.UserFacilityLimit, amount domain.Money) error {
+	if limit.AvailableAmount() < amount {
+		return domain.NewError(domain.ErrInsufficientLimit, "INSUFFICIENT_LIMIT", "insufficient facility limit")
+	}
+	return nil
+}
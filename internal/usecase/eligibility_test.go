@@ -0,0 +1,210 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/elokanugrah/go-financing-btpns/internal/domain"
+	"github.com/elokanugrah/go-financing-btpns/internal/dto"
+	"github.com/elokanugrah/go-financing-btpns/internal/usecase"
+	"github.com/elokanugrah/go-financing-btpns/internal/usecase/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestFinancingUsecase_CheckEligibility(t *testing.T) {
+	ctx := context.Background()
+	startDate := time.Now().UTC().AddDate(0, 0, 7).Format("2006-01-02")
+
+	// results flattens the checks to rule => result and code, the order is asserted separately.
+	results := func(resp dto.EligibilityResponse) map[string]string {
+		out := map[string]string{}
+		for _, c := range resp.Checks {
+			out[c.Rule] = string(c.Result) + " " + c.Code
+		}
+		return out
+	}
+
+	t.Run("success - every rule passes and nothing is stored", func(t *testing.T) {
+		mockUserFacilityRepo := new(mocks.UserFacilityRepository)
+		mockFacilityLimitRepo := new(mocks.UserFacilityLimitRepository)
+		// No transaction manager or write repositories: the check must not reach them
+		uc := usecase.NewFinancingUsecase(usecase.FinancingDeps{
+			TenorRepo:         pricedTenorRepo(),
+			ProductRepo:       flatProductRepo(),
+			UserFacilityRepo:  mockUserFacilityRepo,
+			UserRepo:          verifiedUserRepo(),
+			FacilityLimitRepo: mockFacilityLimitRepo,
+			SchedulePolicy:    domain.DefaultSchedulePolicy(),
+			SubmissionPolicy:  domain.SubmissionPolicy{StartDateMaxDaysAhead: 30, MaxActiveFacilities: 2},
+		})
+
+		mockUserFacilityRepo.On("CountByUser", ctx, int64(1), domain.ActiveFacilityStatuses).Return(1, nil).Once()
+		mockFacilityLimitRepo.On("GetByID", ctx, int64(10)).Return(domain.UserFacilityLimit{FacilityLimitID: 10, UserID: 1, LimitAmount: domain.NewMoney(10000000)}, nil).Once()
+
+		resp, err := uc.CheckEligibility(ctx, dto.SubmitFinancingRequest{UserID: 1, FacilityLimitID: 10, Amount: domain.NewMoney(5000000), Tenor: 12, StartDate: startDate})
+
+		assert.NoError(t, err)
+		assert.True(t, resp.Eligible)
+		rules := make([]string, 0, len(resp.Checks))
+		for _, c := range resp.Checks {
+			assert.Equal(t, dto.CheckPassed, c.Result, c.Rule)
+			rules = append(rules, c.Rule)
+		}
+		assert.Equal(t, []string{
+			usecase.RuleAmount, usecase.RuleStartDate, usecase.RuleStartDateWindow, usecase.RuleTenor, usecase.RuleProduct,
+			usecase.RulePricing, usecase.RuleGracePeriod, usecase.RuleKYC, usecase.RuleActiveFacilities,
+			usecase.RuleLimitOwnership, usecase.RuleLimitExpiry, usecase.RuleRemainingLimit,
+		}, rules)
+		mockFacilityLimitRepo.AssertNotCalled(t, "GetByIDForUpdate", mock.Anything, mock.Anything)
+		mockFacilityLimitRepo.AssertNotCalled(t, "UpdateUsedAmount", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Failure - reports every failing rule with its code", func(t *testing.T) {
+		mockTenorRepo := new(mocks.TenorRepository)
+		mockUserRepo := new(mocks.UserRepository)
+		mockFacilityLimitRepo := new(mocks.UserFacilityLimitRepository)
+		uc := usecase.NewFinancingUsecase(usecase.FinancingDeps{
+			TenorRepo:         mockTenorRepo,
+			ProductRepo:       flatProductRepo(),
+			UserRepo:          mockUserRepo,
+			FacilityLimitRepo: mockFacilityLimitRepo,
+			SchedulePolicy:    domain.DefaultSchedulePolicy(),
+		})
+
+		mockTenorRepo.On("GetByValue", ctx, 7).Return(domain.Tenor{}, domain.ErrNotFound).Once()
+		mockUserRepo.On("GetByID", ctx, int64(1)).Return(domain.User{UserID: 1, KYCStatus: domain.KYCPending}, nil).Once()
+		lastWeek := time.Now().UTC().AddDate(0, 0, -7)
+		mockFacilityLimitRepo.On("GetByID", ctx, int64(10)).Return(domain.UserFacilityLimit{FacilityLimitID: 10, UserID: 1, LimitAmount: domain.NewMoney(10000000), ExpiresAt: &lastWeek}, nil).Once()
+
+		resp, err := uc.CheckEligibility(ctx, dto.SubmitFinancingRequest{UserID: 1, FacilityLimitID: 10, Amount: 0, Tenor: 7, StartDate: "10-08-2025"})
+
+		assert.NoError(t, err)
+		assert.False(t, resp.Eligible)
+		assert.Equal(t, map[string]string{
+			usecase.RuleAmount:           "FAIL INVALID_AMOUNT",
+			usecase.RuleStartDate:        "FAIL INVALID_START_DATE",
+			usecase.RuleStartDateWindow:  "SKIPPED ",
+			usecase.RuleTenor:            "FAIL INVALID_TENOR",
+			usecase.RuleProduct:          "PASS ",
+			usecase.RulePricing:          "SKIPPED ",
+			usecase.RuleGracePeriod:      "SKIPPED ",
+			usecase.RuleKYC:              "FAIL KYC_NOT_VERIFIED",
+			usecase.RuleActiveFacilities: "PASS ",
+			usecase.RuleLimitOwnership:   "PASS ",
+			usecase.RuleLimitExpiry:      "FAIL FACILITY_LIMIT_EXPIRED",
+			usecase.RuleRemainingLimit:   "SKIPPED ",
+		}, results(resp))
+		mockTenorRepo.AssertNotCalled(t, "GetMarginRate", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Failure - start date outside the window", func(t *testing.T) {
+		mockFacilityLimitRepo := new(mocks.UserFacilityLimitRepository)
+		uc := usecase.NewFinancingUsecase(usecase.FinancingDeps{
+			TenorRepo:         pricedTenorRepo(),
+			ProductRepo:       flatProductRepo(),
+			UserRepo:          verifiedUserRepo(),
+			FacilityLimitRepo: mockFacilityLimitRepo,
+			SchedulePolicy:    domain.DefaultSchedulePolicy(),
+			SubmissionPolicy:  domain.SubmissionPolicy{StartDateMaxDaysAhead: 30},
+		})
+
+		mockFacilityLimitRepo.On("GetByID", ctx, int64(10)).Return(domain.UserFacilityLimit{FacilityLimitID: 10, UserID: 1, LimitAmount: domain.NewMoney(10000000)}, nil).Twice()
+
+		for _, days := range []int{-1, 31} {
+			resp, err := uc.CheckEligibility(ctx, dto.SubmitFinancingRequest{UserID: 1, FacilityLimitID: 10, Amount: domain.NewMoney(5000000), Tenor: 12, StartDate: time.Now().UTC().AddDate(0, 0, days).Format("2006-01-02")})
+
+			assert.NoError(t, err)
+			assert.False(t, resp.Eligible, days)
+			assert.Equal(t, "FAIL START_DATE_OUT_OF_WINDOW", results(resp)[usecase.RuleStartDateWindow], days)
+		}
+	})
+
+	t.Run("Failure - user already holds the maximum of active facilities", func(t *testing.T) {
+		mockUserFacilityRepo := new(mocks.UserFacilityRepository)
+		mockFacilityLimitRepo := new(mocks.UserFacilityLimitRepository)
+		uc := usecase.NewFinancingUsecase(usecase.FinancingDeps{
+			TenorRepo:         pricedTenorRepo(),
+			ProductRepo:       flatProductRepo(),
+			UserFacilityRepo:  mockUserFacilityRepo,
+			UserRepo:          verifiedUserRepo(),
+			FacilityLimitRepo: mockFacilityLimitRepo,
+			SchedulePolicy:    domain.DefaultSchedulePolicy(),
+			SubmissionPolicy:  domain.SubmissionPolicy{MaxActiveFacilities: 2},
+		})
+
+		mockUserFacilityRepo.On("CountByUser", ctx, int64(1), domain.ActiveFacilityStatuses).Return(2, nil).Once()
+		mockFacilityLimitRepo.On("GetByID", ctx, int64(10)).Return(domain.UserFacilityLimit{FacilityLimitID: 10, UserID: 1, LimitAmount: domain.NewMoney(10000000)}, nil).Once()
+
+		resp, err := uc.CheckEligibility(ctx, dto.SubmitFinancingRequest{UserID: 1, FacilityLimitID: 10, Amount: domain.NewMoney(5000000), Tenor: 12, StartDate: startDate})
+
+		assert.NoError(t, err)
+		assert.False(t, resp.Eligible)
+		got := results(resp)
+		assert.Equal(t, "FAIL MAX_ACTIVE_FACILITIES", got[usecase.RuleActiveFacilities])
+		assert.Equal(t, "PASS ", got[usecase.RuleRemainingLimit])
+	})
+
+	t.Run("Failure - another user's limit is not inspected further", func(t *testing.T) {
+		mockFacilityLimitRepo := new(mocks.UserFacilityLimitRepository)
+		uc := usecase.NewFinancingUsecase(usecase.FinancingDeps{
+			TenorRepo:         pricedTenorRepo(),
+			ProductRepo:       flatProductRepo(),
+			UserRepo:          verifiedUserRepo(),
+			FacilityLimitRepo: mockFacilityLimitRepo,
+			SchedulePolicy:    domain.DefaultSchedulePolicy(),
+		})
+
+		mockFacilityLimitRepo.On("GetByID", ctx, int64(10)).Return(domain.UserFacilityLimit{FacilityLimitID: 10, UserID: 2, LimitAmount: domain.NewMoney(1000000)}, nil).Once()
+
+		resp, err := uc.CheckEligibility(ctx, dto.SubmitFinancingRequest{UserID: 1, FacilityLimitID: 10, Amount: domain.NewMoney(5000000), Tenor: 12, StartDate: startDate})
+
+		assert.NoError(t, err)
+		assert.False(t, resp.Eligible)
+		got := results(resp)
+		assert.Equal(t, "FAIL FACILITY_LIMIT_FORBIDDEN", got[usecase.RuleLimitOwnership])
+		assert.Equal(t, "SKIPPED ", got[usecase.RuleLimitExpiry])
+		assert.Equal(t, "SKIPPED ", got[usecase.RuleRemainingLimit])
+	})
+
+	t.Run("Failure - insufficient remaining limit", func(t *testing.T) {
+		mockFacilityLimitRepo := new(mocks.UserFacilityLimitRepository)
+		uc := usecase.NewFinancingUsecase(usecase.FinancingDeps{
+			TenorRepo:         pricedTenorRepo(),
+			ProductRepo:       flatProductRepo(),
+			UserRepo:          verifiedUserRepo(),
+			FacilityLimitRepo: mockFacilityLimitRepo,
+			SchedulePolicy:    domain.DefaultSchedulePolicy(),
+		})
+
+		mockFacilityLimitRepo.On("GetByID", ctx, int64(10)).Return(domain.UserFacilityLimit{FacilityLimitID: 10, UserID: 1, LimitAmount: domain.NewMoney(10000000), UsedAmount: domain.NewMoney(8000000)}, nil).Once()
+
+		resp, err := uc.CheckEligibility(ctx, dto.SubmitFinancingRequest{UserID: 1, FacilityLimitID: 10, Amount: domain.NewMoney(5000000), Tenor: 12, StartDate: startDate})
+
+		assert.NoError(t, err)
+		assert.False(t, resp.Eligible)
+		last := resp.Checks[len(resp.Checks)-1]
+		assert.Equal(t, dto.EligibilityCheck{Rule: usecase.RuleRemainingLimit, Result: dto.CheckFailed, Code: "INSUFFICIENT_LIMIT", Message: "insufficient facility limit"}, last)
+	})
+
+	t.Run("Failure - repository error is returned, not reported as a rule", func(t *testing.T) {
+		mockFacilityLimitRepo := new(mocks.UserFacilityLimitRepository)
+		uc := usecase.NewFinancingUsecase(usecase.FinancingDeps{
+			TenorRepo:         pricedTenorRepo(),
+			ProductRepo:       flatProductRepo(),
+			UserRepo:          verifiedUserRepo(),
+			FacilityLimitRepo: mockFacilityLimitRepo,
+			SchedulePolicy:    domain.DefaultSchedulePolicy(),
+		})
+
+		dbErr := errors.New("connection refused")
+		mockFacilityLimitRepo.On("GetByID", ctx, int64(10)).Return(domain.UserFacilityLimit{}, dbErr).Once()
+
+		_, err := uc.CheckEligibility(ctx, dto.SubmitFinancingRequest{UserID: 1, FacilityLimitID: 10, Amount: domain.NewMoney(5000000), Tenor: 12, StartDate: startDate})
+
+		assert.ErrorIs(t, err, dbErr)
+	})
+}
@@ -0,0 +1,248 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/elokanugrah/go-financing-btpns/internal/domain"
+	"github.com/elokanugrah/go-financing-btpns/internal/dto"
+)
+
+type facilityLimitUsecase struct {
+	facilityLimitRepo UserFacilityLimitRepository
+	limitHistoryRepo  FacilityLimitHistoryRepository
+	userRepo          UserRepository
+	txManager         TransactionManager
+	now               func() time.Time
+}
+
+func NewFacilityLimitUsecase(flr UserFacilityLimitRepository, flhr FacilityLimitHistoryRepository, ur UserRepository, tm TransactionManager) FacilityLimitUsecase {
+	return &facilityLimitUsecase{
+		facilityLimitRepo: flr,
+		limitHistoryRepo:  flhr,
+		userRepo:          ur,
+		txManager:         tm,
+		now:               time.Now,
+	}
+}
+
+var (
+	errFacilityLimitNotFound = domain.NotFoundError("FACILITY_LIMIT_NOT_FOUND", "facility limit not found")
+	errFacilityLimitExpired  = domain.ConflictError("FACILITY_LIMIT_EXPIRED", "facility limit has expired")
+)
+
+// GrantLimit gives a user a new facility limit, valid until req.ExpiresAt when it is set.
+func (u *facilityLimitUsecase) GrantLimit(ctx context.Context, req dto.GrantLimitRequest) (dto.FacilityLimitResponse, error) {
+	actor, reason, err := limitAudit(req.Actor, req.Reason)
+	if err != nil {
+		return dto.FacilityLimitResponse{}, err
+	}
+	if req.LimitAmount <= 0 {
+		return dto.FacilityLimitResponse{}, domain.ValidationError("INVALID_LIMIT_AMOUNT", "limit_amount must be greater than 0")
+	}
+
+	limitType := domain.LimitType(strings.ToUpper(strings.TrimSpace(req.LimitType)))
+	switch limitType {
+	case "":
+		limitType = domain.LimitTypeNonRevolving
+	case domain.LimitTypeRevolving, domain.LimitTypeNonRevolving:
+	default:
+		return dto.FacilityLimitResponse{}, domain.ValidationError("INVALID_LIMIT_TYPE", "limit_type must be REVOLVING or NON_REVOLVING")
+	}
+
+	today := truncateToDate(u.now())
+	limit := domain.UserFacilityLimit{UserID: req.UserID, LimitAmount: req.LimitAmount, LimitType: limitType}
+	if req.ExpiresAt != "" {
+		expiresAt, err := time.Parse("2006-01-02", req.ExpiresAt)
+		if err != nil {
+			return dto.FacilityLimitResponse{}, domain.ValidationError("INVALID_EXPIRES_AT", "invalid expires_at format")
+		}
+		if expiresAt.Before(today) {
+			return dto.FacilityLimitResponse{}, domain.ValidationError("INVALID_EXPIRES_AT", "expires_at must not be in the past")
+		}
+		limit.ExpiresAt = &expiresAt
+	}
+
+	var history domain.FacilityLimitHistory
+	err = u.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		_, err := u.userRepo.GetByID(txCtx, req.UserID)
+		if errors.Is(err, domain.ErrNotFound) {
+			return errUserNotFound
+		}
+		if err != nil {
+			return err
+		}
+
+		if err := u.facilityLimitRepo.Create(txCtx, &limit); err != nil {
+			return err
+		}
+
+		history = domain.FacilityLimitHistory{
+			FacilityLimitID: limit.FacilityLimitID,
+			Action:          domain.LimitActionGrant,
+			Amount:          limit.LimitAmount,
+			LimitAfter:      limit.LimitAmount,
+			ExpiresAt:       limit.ExpiresAt,
+			Actor:           actor,
+			Reason:          reason,
+		}
+		return u.limitHistoryRepo.Create(txCtx, &history)
+	})
+	if err != nil {
+		return dto.FacilityLimitResponse{}, err
+	}
+	return toFacilityLimitResponse(limit, []domain.FacilityLimitHistory{history}, today), nil
+}
+
+func (u *facilityLimitUsecase) GetLimit(ctx context.Context, id int64) (dto.FacilityLimitResponse, error) {
+	limit, err := u.facilityLimitRepo.GetByID(ctx, id)
+	if errors.Is(err, domain.ErrNotFound) {
+		return dto.FacilityLimitResponse{}, errFacilityLimitNotFound
+	}
+	if err != nil {
+		return dto.FacilityLimitResponse{}, err
+	}
+
+	histories, err := u.limitHistoryRepo.ListByFacilityLimitID(ctx, id)
+	if err != nil {
+		return dto.FacilityLimitResponse{}, err
+	}
+	return toFacilityLimitResponse(limit, histories, truncateToDate(u.now())), nil
+}
+
+func (u *facilityLimitUsecase) TopUpLimit(ctx context.Context, id int64, req dto.LimitAdjustmentRequest) (dto.FacilityLimitResponse, error) {
+	if req.Amount <= 0 {
+		return dto.FacilityLimitResponse{}, errAmountNotPositive
+	}
+	return u.changeLimit(ctx, id, domain.LimitActionTopUp, req.Amount, req.Actor, req.Reason)
+}
+
+// ReduceLimit lowers the limit by req.Amount. The limit cannot go below what submitted facilities already use.
+func (u *facilityLimitUsecase) ReduceLimit(ctx context.Context, id int64, req dto.LimitAdjustmentRequest) (dto.FacilityLimitResponse, error) {
+	if req.Amount <= 0 {
+		return dto.FacilityLimitResponse{}, errAmountNotPositive
+	}
+	return u.changeLimit(ctx, id, domain.LimitActionReduce, req.Amount, req.Actor, req.Reason)
+}
+
+// ExpireLimit ends the limit today: it can no longer be used for new submissions, while the
+// facilities already submitted against it are unaffected.
+func (u *facilityLimitUsecase) ExpireLimit(ctx context.Context, id int64, req dto.LimitExpiryRequest) (dto.FacilityLimitResponse, error) {
+	return u.changeLimit(ctx, id, domain.LimitActionExpire, 0, req.Actor, req.Reason)
+}
+
+// changeLimit applies a top-up, reduction or expiry to a limit that has not expired and records
+// it in the limit's history.
+func (u *facilityLimitUsecase) changeLimit(ctx context.Context, id int64, action domain.LimitAction, amount domain.Money, actor, reason string) (dto.FacilityLimitResponse, error) {
+	actor, reason, err := limitAudit(actor, reason)
+	if err != nil {
+		return dto.FacilityLimitResponse{}, err
+	}
+	today := truncateToDate(u.now())
+
+	var resp dto.FacilityLimitResponse
+	err = u.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		// Lock the limit so a concurrent submission cannot reserve against the old amount.
+		limit, err := u.facilityLimitRepo.GetByIDForUpdate(txCtx, id)
+		if errors.Is(err, domain.ErrNotFound) {
+			return errFacilityLimitNotFound
+		}
+		if err != nil {
+			return err
+		}
+		if limit.IsExpired(today) {
+			return errFacilityLimitExpired
+		}
+
+		history := domain.FacilityLimitHistory{
+			FacilityLimitID: limit.FacilityLimitID,
+			Action:          action,
+			Amount:          amount,
+			LimitBefore:     limit.LimitAmount,
+			Actor:           actor,
+			Reason:          reason,
+		}
+		switch action {
+		case domain.LimitActionTopUp:
+			limit.LimitAmount += amount
+		case domain.LimitActionReduce:
+			if limit.LimitAmount-amount < limit.UsedAmount {
+				return domain.ConflictError("LIMIT_BELOW_USED", "facility limit cannot be reduced below its used amount")
+			}
+			limit.LimitAmount -= amount
+		case domain.LimitActionExpire:
+			// The limit is usable up to and including its expiry date, so it ended yesterday
+			yesterday := today.AddDate(0, 0, -1)
+			limit.ExpiresAt = &yesterday
+		}
+		history.LimitAfter = limit.LimitAmount
+		history.ExpiresAt = limit.ExpiresAt
+
+		if err := u.facilityLimitRepo.UpdateLimit(txCtx, limit.FacilityLimitID, limit.LimitAmount, limit.ExpiresAt); err != nil {
+			return err
+		}
+		if err := u.limitHistoryRepo.Create(txCtx, &history); err != nil {
+			return err
+		}
+
+		histories, err := u.limitHistoryRepo.ListByFacilityLimitID(txCtx, limit.FacilityLimitID)
+		if err != nil {
+			return err
+		}
+		resp = toFacilityLimitResponse(limit, histories, today)
+		return nil
+	})
+	if err != nil {
+		return dto.FacilityLimitResponse{}, err
+	}
+	return resp, nil
+}
+
+// limitAudit validates who changes a limit and why, both are kept in the limit's history.
+func limitAudit(actor, reason string) (string, string, error) {
+	actor = strings.TrimSpace(actor)
+	if actor == "" {
+		return "", "", domain.ValidationError("ACTOR_REQUIRED", "actor is required")
+	}
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return "", "", domain.ValidationError("REASON_REQUIRED", "reason is required to change a facility limit")
+	}
+	return actor, reason, nil
+}
+
+func toFacilityLimitResponse(l domain.UserFacilityLimit, histories []domain.FacilityLimitHistory, today time.Time) dto.FacilityLimitResponse {
+	resp := dto.FacilityLimitResponse{
+		FacilityLimitID: l.FacilityLimitID,
+		UserID:          l.UserID,
+		LimitAmount:     l.LimitAmount,
+		UsedAmount:      l.UsedAmount,
+		AvailableAmount: l.AvailableAmount(),
+		LimitType:       l.LimitType,
+		Expired:         l.IsExpired(today),
+		CreatedAt:       l.CreatedAt,
+		UpdatedAt:       l.UpdatedAt,
+		History:         make([]dto.LimitHistoryItem, 0, len(histories)),
+	}
+	if l.ExpiresAt != nil {
+		resp.ExpiresAt = l.ExpiresAt.Format("2006-01-02")
+	}
+	for _, h := range histories {
+		item := dto.LimitHistoryItem{
+			Action:      h.Action,
+			Amount:      h.Amount,
+			LimitBefore: h.LimitBefore,
+			LimitAfter:  h.LimitAfter,
+			Actor:       h.Actor,
+			Reason:      h.Reason,
+			CreatedAt:   h.CreatedAt,
+		}
+		if h.ExpiresAt != nil {
+			item.ExpiresAt = h.ExpiresAt.Format("2006-01-02")
+		}
+		resp.History = append(resp.History, item)
+	}
+	return resp
+}
@@ -0,0 +1,217 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/elokanugrah/go-financing-btpns/internal/domain"
+	"github.com/elokanugrah/go-financing-btpns/internal/dto"
+	"github.com/elokanugrah/go-financing-btpns/internal/usecase"
+	"github.com/elokanugrah/go-financing-btpns/internal/usecase/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestFacilityLimitUsecase_GrantLimit(t *testing.T) {
+	ctx := context.Background()
+	nextYear := time.Now().UTC().AddDate(1, 0, 0).Format("2006-01-02")
+
+	t.Run("success - creates the limit and records the grant", func(t *testing.T) {
+		mockLimitRepo := new(mocks.UserFacilityLimitRepository)
+		mockHistoryRepo := new(mocks.FacilityLimitHistoryRepository)
+		mockUserRepo := new(mocks.UserRepository)
+		mockTxManager := new(mocks.TransactionManager)
+		uc := usecase.NewFacilityLimitUsecase(mockLimitRepo, mockHistoryRepo, mockUserRepo, mockTxManager)
+
+		mockTxManager.On("WithTransaction", ctx, mock.AnythingOfType("func(context.Context) error")).Return(nil).Run(func(args mock.Arguments) {
+			fn := args.Get(1).(func(txCtx context.Context) error)
+			mockUserRepo.On("GetByID", mock.Anything, int64(1)).Return(domain.User{UserID: 1}, nil).Once()
+			mockLimitRepo.On("Create", mock.Anything, mock.MatchedBy(func(l *domain.UserFacilityLimit) bool {
+				return l.UserID == 1 && l.LimitAmount == domain.NewMoney(10000000) && l.LimitType == domain.LimitTypeRevolving && l.ExpiresAt.Format("2006-01-02") == nextYear
+			})).Return(nil).Run(func(args mock.Arguments) {
+				args.Get(1).(*domain.UserFacilityLimit).FacilityLimitID = 7
+			}).Once()
+			mockHistoryRepo.On("Create", mock.Anything, mock.MatchedBy(func(h *domain.FacilityLimitHistory) bool {
+				return h.FacilityLimitID == 7 && h.Action == domain.LimitActionGrant && h.Amount == domain.NewMoney(10000000) &&
+					h.LimitBefore == 0 && h.LimitAfter == domain.NewMoney(10000000) && h.Actor == "credit-officer" && h.Reason == "annual review"
+			})).Return(nil).Once()
+			assert.NoError(t, fn(ctx))
+		}).Once()
+
+		resp, err := uc.GrantLimit(ctx, dto.GrantLimitRequest{UserID: 1, LimitAmount: domain.NewMoney(10000000), LimitType: "revolving", ExpiresAt: nextYear, Actor: " credit-officer ", Reason: "annual review"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(7), resp.FacilityLimitID)
+		assert.Equal(t, domain.NewMoney(10000000), resp.AvailableAmount)
+		assert.Equal(t, nextYear, resp.ExpiresAt)
+		assert.False(t, resp.Expired)
+		assert.Len(t, resp.History, 1)
+		mockLimitRepo.AssertExpectations(t)
+		mockHistoryRepo.AssertExpectations(t)
+	})
+
+	t.Run("Failure - user not found", func(t *testing.T) {
+		mockLimitRepo := new(mocks.UserFacilityLimitRepository)
+		mockUserRepo := new(mocks.UserRepository)
+		mockTxManager := new(mocks.TransactionManager)
+		uc := usecase.NewFacilityLimitUsecase(mockLimitRepo, nil, mockUserRepo, mockTxManager)
+
+		mockTxManager.On("WithTransaction", ctx, mock.AnythingOfType("func(context.Context) error")).Return(domain.ErrNotFound).Run(func(args mock.Arguments) {
+			fn := args.Get(1).(func(txCtx context.Context) error)
+			mockUserRepo.On("GetByID", mock.Anything, int64(9)).Return(domain.User{}, domain.ErrNotFound).Once()
+			err := fn(ctx)
+			assert.ErrorIs(t, err, domain.ErrNotFound)
+			assert.EqualError(t, err, "user not found")
+		}).Once()
+
+		_, err := uc.GrantLimit(ctx, dto.GrantLimitRequest{UserID: 9, LimitAmount: domain.NewMoney(1000000), Actor: "credit-officer", Reason: "new customer"})
+
+		assert.Error(t, err)
+		mockLimitRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Failure - invalid request", func(t *testing.T) {
+		uc := usecase.NewFacilityLimitUsecase(nil, nil, nil, nil)
+
+		_, err := uc.GrantLimit(ctx, dto.GrantLimitRequest{UserID: 1, LimitAmount: domain.NewMoney(1000000), Reason: "new customer"})
+		assert.ErrorIs(t, err, domain.ErrValidation)
+		assert.EqualError(t, err, "actor is required")
+
+		_, err = uc.GrantLimit(ctx, dto.GrantLimitRequest{UserID: 1, LimitAmount: domain.NewMoney(1000000), Actor: "credit-officer"})
+		assert.EqualError(t, err, "reason is required to change a facility limit")
+
+		_, err = uc.GrantLimit(ctx, dto.GrantLimitRequest{UserID: 1, Actor: "credit-officer", Reason: "new customer"})
+		assert.EqualError(t, err, "limit_amount must be greater than 0")
+
+		_, err = uc.GrantLimit(ctx, dto.GrantLimitRequest{UserID: 1, LimitAmount: domain.NewMoney(1000000), LimitType: "ROLLING", Actor: "credit-officer", Reason: "new customer"})
+		assert.EqualError(t, err, "limit_type must be REVOLVING or NON_REVOLVING")
+
+		yesterday := time.Now().UTC().AddDate(0, 0, -1).Format("2006-01-02")
+		_, err = uc.GrantLimit(ctx, dto.GrantLimitRequest{UserID: 1, LimitAmount: domain.NewMoney(1000000), ExpiresAt: yesterday, Actor: "credit-officer", Reason: "new customer"})
+		assert.ErrorIs(t, err, domain.ErrValidation)
+		assert.EqualError(t, err, "expires_at must not be in the past")
+	})
+}
+
+func TestFacilityLimitUsecase_ChangeLimit(t *testing.T) {
+	ctx := context.Background()
+	limit := domain.UserFacilityLimit{FacilityLimitID: 7, UserID: 1, LimitAmount: domain.NewMoney(10000000), UsedAmount: domain.NewMoney(4000000)}
+
+	t.Run("success - top-up raises the limit", func(t *testing.T) {
+		mockLimitRepo := new(mocks.UserFacilityLimitRepository)
+		mockHistoryRepo := new(mocks.FacilityLimitHistoryRepository)
+		mockTxManager := new(mocks.TransactionManager)
+		uc := usecase.NewFacilityLimitUsecase(mockLimitRepo, mockHistoryRepo, nil, mockTxManager)
+
+		mockTxManager.On("WithTransaction", ctx, mock.AnythingOfType("func(context.Context) error")).Return(nil).Run(func(args mock.Arguments) {
+			fn := args.Get(1).(func(txCtx context.Context) error)
+			mockLimitRepo.On("GetByIDForUpdate", mock.Anything, int64(7)).Return(limit, nil).Once()
+			mockLimitRepo.On("UpdateLimit", mock.Anything, int64(7), domain.NewMoney(12000000), (*time.Time)(nil)).Return(nil).Once()
+			mockHistoryRepo.On("Create", mock.Anything, &domain.FacilityLimitHistory{
+				FacilityLimitID: 7,
+				Action:          domain.LimitActionTopUp,
+				Amount:          domain.NewMoney(2000000),
+				LimitBefore:     domain.NewMoney(10000000),
+				LimitAfter:      domain.NewMoney(12000000),
+				Actor:           "credit-officer",
+				Reason:          "salary increase",
+			}).Return(nil).Once()
+			mockHistoryRepo.On("ListByFacilityLimitID", mock.Anything, int64(7)).Return([]domain.FacilityLimitHistory{
+				{Action: domain.LimitActionGrant}, {Action: domain.LimitActionTopUp},
+			}, nil).Once()
+			assert.NoError(t, fn(ctx))
+		}).Once()
+
+		resp, err := uc.TopUpLimit(ctx, 7, dto.LimitAdjustmentRequest{Amount: domain.NewMoney(2000000), Actor: "credit-officer", Reason: "salary increase"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, domain.NewMoney(12000000), resp.LimitAmount)
+		assert.Equal(t, domain.NewMoney(8000000), resp.AvailableAmount)
+		assert.Len(t, resp.History, 2)
+		mockLimitRepo.AssertExpectations(t)
+		mockHistoryRepo.AssertExpectations(t)
+	})
+
+	t.Run("Failure - reduce below the used amount", func(t *testing.T) {
+		mockLimitRepo := new(mocks.UserFacilityLimitRepository)
+		mockTxManager := new(mocks.TransactionManager)
+		uc := usecase.NewFacilityLimitUsecase(mockLimitRepo, nil, nil, mockTxManager)
+
+		mockTxManager.On("WithTransaction", ctx, mock.AnythingOfType("func(context.Context) error")).Return(domain.ErrConflict).Run(func(args mock.Arguments) {
+			fn := args.Get(1).(func(txCtx context.Context) error)
+			mockLimitRepo.On("GetByIDForUpdate", mock.Anything, int64(7)).Return(limit, nil).Once()
+			err := fn(ctx)
+			assert.ErrorIs(t, err, domain.ErrConflict)
+			assert.EqualError(t, err, "facility limit cannot be reduced below its used amount")
+		}).Once()
+
+		_, err := uc.ReduceLimit(ctx, 7, dto.LimitAdjustmentRequest{Amount: domain.NewMoney(7000000), Actor: "credit-officer", Reason: "risk review"})
+
+		assert.Error(t, err)
+		mockLimitRepo.AssertNotCalled(t, "UpdateLimit", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("success - expire ends the limit yesterday", func(t *testing.T) {
+		mockLimitRepo := new(mocks.UserFacilityLimitRepository)
+		mockHistoryRepo := new(mocks.FacilityLimitHistoryRepository)
+		mockTxManager := new(mocks.TransactionManager)
+		uc := usecase.NewFacilityLimitUsecase(mockLimitRepo, mockHistoryRepo, nil, mockTxManager)
+
+		now := time.Now().UTC()
+		yesterday := time.Date(now.Year(), now.Month(), now.Day()-1, 0, 0, 0, 0, time.UTC)
+		mockTxManager.On("WithTransaction", ctx, mock.AnythingOfType("func(context.Context) error")).Return(nil).Run(func(args mock.Arguments) {
+			fn := args.Get(1).(func(txCtx context.Context) error)
+			mockLimitRepo.On("GetByIDForUpdate", mock.Anything, int64(7)).Return(limit, nil).Once()
+			mockLimitRepo.On("UpdateLimit", mock.Anything, int64(7), limit.LimitAmount, &yesterday).Return(nil).Once()
+			mockHistoryRepo.On("Create", mock.Anything, mock.MatchedBy(func(h *domain.FacilityLimitHistory) bool {
+				return h.Action == domain.LimitActionExpire && h.Amount == 0 && h.LimitAfter == limit.LimitAmount && h.ExpiresAt.Equal(yesterday)
+			})).Return(nil).Once()
+			mockHistoryRepo.On("ListByFacilityLimitID", mock.Anything, int64(7)).Return(nil, nil).Once()
+			assert.NoError(t, fn(ctx))
+		}).Once()
+
+		resp, err := uc.ExpireLimit(ctx, 7, dto.LimitExpiryRequest{Actor: "credit-officer", Reason: "customer request"})
+
+		assert.NoError(t, err)
+		assert.True(t, resp.Expired)
+		assert.Equal(t, yesterday.Format("2006-01-02"), resp.ExpiresAt)
+		mockLimitRepo.AssertExpectations(t)
+		mockHistoryRepo.AssertExpectations(t)
+	})
+
+	t.Run("Failure - expired limit cannot be changed", func(t *testing.T) {
+		mockLimitRepo := new(mocks.UserFacilityLimitRepository)
+		mockTxManager := new(mocks.TransactionManager)
+		uc := usecase.NewFacilityLimitUsecase(mockLimitRepo, nil, nil, mockTxManager)
+
+		expired := limit
+		lastMonth := time.Now().UTC().AddDate(0, -1, 0)
+		expired.ExpiresAt = &lastMonth
+		mockTxManager.On("WithTransaction", ctx, mock.AnythingOfType("func(context.Context) error")).Return(domain.ErrConflict).Run(func(args mock.Arguments) {
+			fn := args.Get(1).(func(txCtx context.Context) error)
+			mockLimitRepo.On("GetByIDForUpdate", mock.Anything, int64(7)).Return(expired, nil).Once()
+			err := fn(ctx)
+			assert.ErrorIs(t, err, domain.ErrConflict)
+			assert.EqualError(t, err, "facility limit has expired")
+		}).Once()
+
+		_, err := uc.TopUpLimit(ctx, 7, dto.LimitAdjustmentRequest{Amount: domain.NewMoney(1000000), Actor: "credit-officer", Reason: "salary increase"})
+
+		assert.Error(t, err)
+		mockLimitRepo.AssertNotCalled(t, "UpdateLimit", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Failure - limit not found", func(t *testing.T) {
+		mockLimitRepo := new(mocks.UserFacilityLimitRepository)
+		uc := usecase.NewFacilityLimitUsecase(mockLimitRepo, nil, nil, nil)
+
+		mockLimitRepo.On("GetByID", ctx, int64(99)).Return(domain.UserFacilityLimit{}, domain.ErrNotFound).Once()
+
+		_, err := uc.GetLimit(ctx, 99)
+
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+		assert.EqualError(t, err, "facility limit not found")
+	})
+}
@@ -0,0 +1,96 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/elokanugrah/go-financing-btpns/internal/domain"
+	"github.com/elokanugrah/go-financing-btpns/internal/dto"
+)
+
+func (u *financingUsecase) ApproveFacility(ctx context.Context, id int64, req dto.FacilityTransitionRequest) (dto.FacilityResponse, error) {
+	return u.transitionFacility(ctx, id, domain.FacilityStatusApproved, req)
+}
+
+func (u *financingUsecase) RejectFacility(ctx context.Context, id int64, req dto.FacilityTransitionRequest) (dto.FacilityResponse, error) {
+	return u.transitionFacility(ctx, id, domain.FacilityStatusRejected, req)
+}
+
+func (u *financingUsecase) DisburseFacility(ctx context.Context, id int64, req dto.FacilityTransitionRequest) (dto.FacilityResponse, error) {
+	return u.transitionFacility(ctx, id, domain.FacilityStatusDisbursed, req)
+}
+
+func (u *financingUsecase) CancelFacility(ctx context.Context, id int64, req dto.FacilityTransitionRequest) (dto.FacilityResponse, error) {
+	return u.transitionFacility(ctx, id, domain.FacilityStatusCancelled, req)
+}
+
+// transitionFacility moves a facility to the given status on behalf of req.Actor.
+func (u *financingUsecase) transitionFacility(ctx context.Context, id int64, to domain.FacilityStatus, req dto.FacilityTransitionRequest) (dto.FacilityResponse, error) {
+	actor := strings.TrimSpace(req.Actor)
+	if actor == "" {
+		return dto.FacilityResponse{}, domain.ValidationError("ACTOR_REQUIRED", "actor is required")
+	}
+	reason := strings.TrimSpace(req.Reason)
+	if to.ReleasesLimit() && reason == "" {
+		return dto.FacilityResponse{}, domain.ValidationError("REASON_REQUIRED", "reason is required to reject or cancel a facility")
+	}
+
+	var facility domain.UserFacility
+	err := u.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		// Lock the facility so two concurrent transitions cannot both pass the check below.
+		f, err := u.userFacilityRepo.GetByIDForUpdate(txCtx, id)
+		if errors.Is(err, domain.ErrNotFound) {
+			return errFacilityNotFound
+		}
+		if err != nil {
+			return err
+		}
+
+		if err := u.changeStatus(txCtx, &f, to, actor, reason); err != nil {
+			return err
+		}
+		facility = f
+		return nil
+	})
+	if err != nil {
+		return dto.FacilityResponse{}, err
+	}
+
+	return toFacilityResponse(facility), nil
+}
+
+// changeStatus checks and applies a status change of a facility locked in the current transaction,
+// records it in the history and gives the reserved amount back to the limit when the facility ends unused.
+func (u *financingUsecase) changeStatus(txCtx context.Context, f *domain.UserFacility, to domain.FacilityStatus, actor, reason string) error {
+	if !f.Status.CanTransitionTo(to) {
+		return domain.ConflictError("INVALID_STATUS_TRANSITION", fmt.Sprintf("facility cannot move from %s to %s", f.Status, to))
+	}
+
+	if to.ReleasesLimit() {
+		limit, err := u.facilityLimitRepo.GetByIDForUpdate(txCtx, f.FacilityLimitID)
+		if err != nil {
+			return err
+		}
+		if err := u.facilityLimitRepo.UpdateUsedAmount(txCtx, limit.FacilityLimitID, limit.UsedAmount-f.Amount); err != nil {
+			return err
+		}
+	}
+
+	if err := u.userFacilityRepo.UpdateStatus(txCtx, f.UserFacilityID, to); err != nil {
+		return err
+	}
+	if err := u.statusHistoryRepo.Create(txCtx, &domain.FacilityStatusHistory{
+		UserFacilityID: f.UserFacilityID,
+		FromStatus:     f.Status,
+		ToStatus:       to,
+		Actor:          actor,
+		Reason:         reason,
+	}); err != nil {
+		return err
+	}
+
+	f.Status = to
+	return nil
+}
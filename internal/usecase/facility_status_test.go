@@ -0,0 +1,167 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/elokanugrah/go-financing-btpns/internal/domain"
+	"github.com/elokanugrah/go-financing-btpns/internal/dto"
+	"github.com/elokanugrah/go-financing-btpns/internal/usecase"
+	"github.com/elokanugrah/go-financing-btpns/internal/usecase/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestFinancingUsecase_FacilityTransitions(t *testing.T) {
+	ctx := context.Background()
+	submitted := domain.UserFacility{UserFacilityID: 100, UserID: 1, FacilityLimitID: 10, Amount: domain.NewMoney(5000000), Status: domain.FacilityStatusSubmitted}
+
+	t.Run("success - approve records the transition without touching the limit", func(t *testing.T) {
+		mockUserFacilityRepo := new(mocks.UserFacilityRepository)
+		mockStatusHistoryRepo := new(mocks.FacilityStatusHistoryRepository)
+		mockFacilityLimitRepo := new(mocks.UserFacilityLimitRepository)
+		mockTxManager := new(mocks.TransactionManager)
+		uc := usecase.NewFinancingUsecase(usecase.FinancingDeps{
+			UserFacilityRepo:  mockUserFacilityRepo,
+			StatusHistoryRepo: mockStatusHistoryRepo,
+			FacilityLimitRepo: mockFacilityLimitRepo,
+			TxManager:         mockTxManager,
+			SchedulePolicy:    domain.DefaultSchedulePolicy(),
+		})
+
+		mockTxManager.On("WithTransaction", ctx, mock.AnythingOfType("func(context.Context) error")).Return(nil).Run(func(args mock.Arguments) {
+			fn := args.Get(1).(func(txCtx context.Context) error)
+			mockUserFacilityRepo.On("GetByIDForUpdate", mock.Anything, int64(100)).Return(submitted, nil).Once()
+			mockUserFacilityRepo.On("UpdateStatus", mock.Anything, int64(100), domain.FacilityStatusApproved).Return(nil).Once()
+			mockStatusHistoryRepo.On("Create", mock.Anything, &domain.FacilityStatusHistory{
+				UserFacilityID: 100,
+				FromStatus:     domain.FacilityStatusSubmitted,
+				ToStatus:       domain.FacilityStatusApproved,
+				Actor:          "analyst",
+			}).Return(nil).Once()
+			assert.NoError(t, fn(ctx))
+		}).Once()
+
+		res, err := uc.ApproveFacility(ctx, 100, dto.FacilityTransitionRequest{Actor: " analyst "})
+
+		assert.NoError(t, err)
+		assert.Equal(t, domain.FacilityStatusApproved, res.Status)
+		mockUserFacilityRepo.AssertExpectations(t)
+		mockStatusHistoryRepo.AssertExpectations(t)
+		mockFacilityLimitRepo.AssertNotCalled(t, "UpdateUsedAmount", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("success - reject releases the reserved amount", func(t *testing.T) {
+		mockUserFacilityRepo := new(mocks.UserFacilityRepository)
+		mockStatusHistoryRepo := new(mocks.FacilityStatusHistoryRepository)
+		mockFacilityLimitRepo := new(mocks.UserFacilityLimitRepository)
+		mockTxManager := new(mocks.TransactionManager)
+		uc := usecase.NewFinancingUsecase(usecase.FinancingDeps{
+			UserFacilityRepo:  mockUserFacilityRepo,
+			StatusHistoryRepo: mockStatusHistoryRepo,
+			FacilityLimitRepo: mockFacilityLimitRepo,
+			TxManager:         mockTxManager,
+			SchedulePolicy:    domain.DefaultSchedulePolicy(),
+		})
+
+		limit := domain.UserFacilityLimit{FacilityLimitID: 10, UserID: 1, LimitAmount: domain.NewMoney(15000000), UsedAmount: domain.NewMoney(8000000)}
+
+		mockTxManager.On("WithTransaction", ctx, mock.AnythingOfType("func(context.Context) error")).Return(nil).Run(func(args mock.Arguments) {
+			fn := args.Get(1).(func(txCtx context.Context) error)
+			mockUserFacilityRepo.On("GetByIDForUpdate", mock.Anything, int64(100)).Return(submitted, nil).Once()
+			mockFacilityLimitRepo.On("GetByIDForUpdate", mock.Anything, int64(10)).Return(limit, nil).Once()
+			mockFacilityLimitRepo.On("UpdateUsedAmount", mock.Anything, int64(10), domain.NewMoney(3000000)).Return(nil).Once()
+			mockUserFacilityRepo.On("UpdateStatus", mock.Anything, int64(100), domain.FacilityStatusRejected).Return(nil).Once()
+			mockStatusHistoryRepo.On("Create", mock.Anything, mock.MatchedBy(func(h *domain.FacilityStatusHistory) bool {
+				return h.ToStatus == domain.FacilityStatusRejected && h.Reason == "income not verified"
+			})).Return(nil).Once()
+			assert.NoError(t, fn(ctx))
+		}).Once()
+
+		res, err := uc.RejectFacility(ctx, 100, dto.FacilityTransitionRequest{Actor: "analyst", Reason: "income not verified"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, domain.FacilityStatusRejected, res.Status)
+		mockFacilityLimitRepo.AssertExpectations(t)
+		mockStatusHistoryRepo.AssertExpectations(t)
+	})
+
+	t.Run("Failure - illegal transition is a conflict", func(t *testing.T) {
+		mockUserFacilityRepo := new(mocks.UserFacilityRepository)
+		mockTxManager := new(mocks.TransactionManager)
+		uc := usecase.NewFinancingUsecase(usecase.FinancingDeps{
+			UserFacilityRepo: mockUserFacilityRepo,
+			TxManager:        mockTxManager,
+			SchedulePolicy:   domain.DefaultSchedulePolicy(),
+		})
+
+		mockTxManager.On("WithTransaction", ctx, mock.AnythingOfType("func(context.Context) error")).Return(domain.ErrConflict).Run(func(args mock.Arguments) {
+			fn := args.Get(1).(func(txCtx context.Context) error)
+			mockUserFacilityRepo.On("GetByIDForUpdate", mock.Anything, int64(100)).Return(submitted, nil).Once()
+			err := fn(ctx)
+			assert.ErrorIs(t, err, domain.ErrConflict)
+			assert.EqualError(t, err, "facility cannot move from SUBMITTED to DISBURSED")
+		}).Once()
+
+		_, err := uc.DisburseFacility(ctx, 100, dto.FacilityTransitionRequest{Actor: "ops"})
+
+		assert.ErrorIs(t, err, domain.ErrConflict)
+		mockUserFacilityRepo.AssertNotCalled(t, "UpdateStatus", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Failure - facility not found", func(t *testing.T) {
+		mockUserFacilityRepo := new(mocks.UserFacilityRepository)
+		mockTxManager := new(mocks.TransactionManager)
+		uc := usecase.NewFinancingUsecase(usecase.FinancingDeps{
+			UserFacilityRepo: mockUserFacilityRepo,
+			TxManager:        mockTxManager,
+			SchedulePolicy:   domain.DefaultSchedulePolicy(),
+		})
+
+		mockTxManager.On("WithTransaction", ctx, mock.AnythingOfType("func(context.Context) error")).Return(domain.ErrNotFound).Run(func(args mock.Arguments) {
+			fn := args.Get(1).(func(txCtx context.Context) error)
+			mockUserFacilityRepo.On("GetByIDForUpdate", mock.Anything, int64(404)).Return(domain.UserFacility{}, domain.ErrNotFound).Once()
+			assert.EqualError(t, fn(ctx), "facility not found")
+		}).Once()
+
+		_, err := uc.ApproveFacility(ctx, 404, dto.FacilityTransitionRequest{Actor: "analyst"})
+
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+	})
+
+	validationCases := []struct {
+		name    string
+		call    func(uc usecase.FinancingUsecase) error
+		wantErr string
+	}{
+		{
+			name: "missing actor",
+			call: func(uc usecase.FinancingUsecase) error {
+				_, err := uc.ApproveFacility(ctx, 100, dto.FacilityTransitionRequest{})
+				return err
+			},
+			wantErr: "actor is required",
+		},
+		{
+			name: "cancel without reason",
+			call: func(uc usecase.FinancingUsecase) error {
+				_, err := uc.CancelFacility(ctx, 100, dto.FacilityTransitionRequest{Actor: "user:1", Reason: "  "})
+				return err
+			},
+			wantErr: "reason is required to reject or cancel a facility",
+		},
+	}
+	for _, tc := range validationCases {
+		t.Run("Failure - "+tc.name, func(t *testing.T) {
+			uc := usecase.NewFinancingUsecase(usecase.FinancingDeps{
+				SchedulePolicy: domain.DefaultSchedulePolicy(),
+			})
+
+			err := tc.call(uc)
+
+			assert.EqualError(t, err, tc.wantErr)
+			assert.ErrorIs(t, err, domain.ErrValidation)
+		})
+	}
+}
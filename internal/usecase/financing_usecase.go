@@ -2,8 +2,12 @@ package usecase
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/elokanugrah/go-financing-btpns/internal/domain"
@@ -12,139 +16,518 @@ import (
 
 type financingUsecase struct {
 	tenorRepo              TenorRepository
+	productRepo            ProductRepository
 	userFacilityDetailRepo UserFacilityDetailRepository
 	userFacilityRepo       UserFacilityRepository
+	statusHistoryRepo      FacilityStatusHistoryRepository
+	paymentRepo            PaymentRepository
+	settlementRepo         SettlementRepository
+	collectibilityRepo     CollectibilityRepository
+	lateChargeRepo         LateChargeRepository
+	restructuringRepo      RestructuringRepository
+	userRepo               UserRepository
 	facilityLimitRepo      UserFacilityLimitRepository
+	idempotencyKeyRepo     IdempotencyKeyRepository
 	txManager              TransactionManager
+	schedulePolicy         domain.SchedulePolicy
+	settlementPolicy       domain.SettlementPolicy
+	lateChargePolicy       domain.LateChargePolicy
+	submissionPolicy       domain.SubmissionPolicy
+	now                    func() time.Time
 }
 
-func NewFinancingUsecase(tr TenorRepository, ufdr UserFacilityDetailRepository, ufr UserFacilityRepository, flr UserFacilityLimitRepository, tm TransactionManager) FinancingUsecase {
+// FinancingDeps are the repositories and policies of the financing usecase. A test only needs to
+// set the ones the path under test uses.
+type FinancingDeps struct {
+	TenorRepo              TenorRepository
+	ProductRepo            ProductRepository
+	UserFacilityDetailRepo UserFacilityDetailRepository
+	UserFacilityRepo       UserFacilityRepository
+	StatusHistoryRepo      FacilityStatusHistoryRepository
+	PaymentRepo            PaymentRepository
+	SettlementRepo         SettlementRepository
+	CollectibilityRepo     CollectibilityRepository
+	LateChargeRepo         LateChargeRepository
+	RestructuringRepo      RestructuringRepository
+	UserRepo               UserRepository
+	FacilityLimitRepo      UserFacilityLimitRepository
+	IdempotencyKeyRepo     IdempotencyKeyRepository
+	TxManager              TransactionManager
+	SchedulePolicy         domain.SchedulePolicy
+	SettlementPolicy       domain.SettlementPolicy
+	LateChargePolicy       domain.LateChargePolicy
+	SubmissionPolicy       domain.SubmissionPolicy
+}
+
+func NewFinancingUsecase(deps FinancingDeps) FinancingUsecase {
 	return &financingUsecase{
-		tenorRepo:              tr,
-		userFacilityDetailRepo: ufdr,
-		userFacilityRepo:       ufr,
-		facilityLimitRepo:      flr,
-		txManager:              tm,
+		tenorRepo:              deps.TenorRepo,
+		productRepo:            deps.ProductRepo,
+		userFacilityDetailRepo: deps.UserFacilityDetailRepo,
+		userFacilityRepo:       deps.UserFacilityRepo,
+		statusHistoryRepo:      deps.StatusHistoryRepo,
+		paymentRepo:            deps.PaymentRepo,
+		settlementRepo:         deps.SettlementRepo,
+		collectibilityRepo:     deps.CollectibilityRepo,
+		lateChargeRepo:         deps.LateChargeRepo,
+		restructuringRepo:      deps.RestructuringRepo,
+		userRepo:               deps.UserRepo,
+		facilityLimitRepo:      deps.FacilityLimitRepo,
+		idempotencyKeyRepo:     deps.IdempotencyKeyRepo,
+		txManager:              deps.TxManager,
+		schedulePolicy:         deps.SchedulePolicy,
+		settlementPolicy:       deps.SettlementPolicy,
+		lateChargePolicy:       deps.LateChargePolicy,
+		submissionPolicy:       deps.SubmissionPolicy,
+		now:                    time.Now,
 	}
 }
 
-func (u *financingUsecase) CalculateAllTenors(ctx context.Context, amount float64) (dto.CalculateResponse, error) {
-	const marginRate = 0.20
+var (
+	errAmountNotPositive = domain.ValidationError("INVALID_AMOUNT", "amount must be greater than 0")
+	errFacilityNotFound  = domain.NotFoundError("FACILITY_NOT_FOUND", "facility not found")
+)
+
+// productCode normalizes the requested product, falling back to the default product.
+func productCode(code string) string {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if code == "" {
+		return domain.DefaultProductCode
+	}
+	return code
+}
 
-	if amount <= 0 {
-		return dto.CalculateResponse{}, errors.New("amount must be greater than 0")
+// gracePeriod resolves the grace period of a request, the product's default fills what the request leaves out.
+func gracePeriod(product domain.Product, months *int, graceType string) domain.GracePeriod {
+	grace := product.GracePeriod
+	if months != nil {
+		grace.Months = *months
+	}
+	if t := strings.ToUpper(strings.TrimSpace(graceType)); t != "" {
+		grace.Type = domain.GraceType(t)
 	}
+	return grace
+}
 
-	tenors, err := u.tenorRepo.GetAll(ctx)
+// getProduct resolves the product that decides how the margin is calculated.
+func (u *financingUsecase) getProduct(ctx context.Context, code string) (domain.Product, error) {
+	product, err := u.productRepo.GetByCode(ctx, code)
+	if errors.Is(err, domain.ErrNotFound) {
+		return domain.Product{}, domain.ValidationError("UNKNOWN_PRODUCT", "unknown product")
+	}
+	return product, err
+}
+
+func (u *financingUsecase) CalculateAllTenors(ctx context.Context, req dto.CalculateRequest) (dto.CalculateResponse, error) {
+	if req.Amount <= 0 {
+		return dto.CalculateResponse{}, errAmountNotPositive
+	}
+	product, err := u.getProduct(ctx, productCode(req.ProductCode))
+	if err != nil {
+		return dto.CalculateResponse{}, err
+	}
+
+	// Only tenors priced for the product today can be offered
+	tenors, err := u.tenorRepo.GetAllPriced(ctx, product.ProductCode, u.now())
 	if err != nil {
 		return dto.CalculateResponse{}, err
 	}
 	if len(tenors) == 0 {
-		return dto.CalculateResponse{}, errors.New("no tenor available")
+		return dto.CalculateResponse{}, domain.NotFoundError("NO_TENOR_AVAILABLE", "no tenor available")
 	}
 
+	grace := gracePeriod(product, req.GracePeriodMonths, req.GraceType)
 	results := make([]dto.CalculationResult, 0, len(tenors))
 
 	for _, tenor := range tenors {
-		monthly, margin, payment := tenor.Calculate(amount, marginRate)
+		// Tenors too short for the grace period are not offered
+		if grace.Months >= tenor.TenorValue {
+			continue
+		}
+		if err := grace.Validate(tenor.TenorValue); err != nil {
+			return dto.CalculateResponse{}, domain.ValidationError("INVALID_GRACE_PERIOD", err.Error())
+		}
+		plan := u.schedulePolicy.Plan(product.CalculationMethod, tenor, req.Amount, tenor.MarginRate, grace)
 		results = append(results, dto.CalculationResult{
 			Tenor:              tenor.TenorValue,
-			MonthlyInstallment: monthly,
-			TotalMargin:        margin,
-			TotalPayment:       payment,
+			MarginRate:         tenor.MarginRate,
+			MonthlyInstallment: plan.MonthlyInstallment,
+			TotalMargin:        plan.TotalMargin,
+			TotalPayment:       plan.TotalPayment,
 		})
 	}
 
-	return dto.CalculateResponse{
-		Calculations: results,
-	}, nil
+	if len(results) == 0 {
+		return dto.CalculateResponse{}, domain.ValidationError("INVALID_GRACE_PERIOD", "grace_period_months must be shorter than the tenor")
+	}
+
+	resp := dto.CalculateResponse{
+		ProductCode:       product.ProductCode,
+		CalculationMethod: product.CalculationMethod,
+		Calculations:      results,
+	}
+	if grace.Months > 0 {
+		resp.GracePeriodMonths, resp.GraceType = grace.Months, grace.Type
+	}
+	return resp, nil
 }
 
 func (u *financingUsecase) SubmitFinancing(ctx context.Context, req dto.SubmitFinancingRequest) (dto.SubmitFinancingResponse, error) {
-	const marginRate = 0.20
+	var resp dto.SubmitFinancingResponse
+
+	// using the callback pattern provided by our TransactionManager.
+	err := u.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		// A retried request is answered from the key it reserved before anything is checked again,
+		// the tenor or its pricing may have changed since it was submitted.
+		if req.IdempotencyKey != "" {
+			replayed, err := u.reserveIdempotencyKey(txCtx, req, &resp)
+			if err != nil || replayed {
+				return err
+			}
+		}
+
+		var err error
+		resp, err = u.submit(txCtx, req)
+		if err != nil || req.IdempotencyKey == "" {
+			return err
+		}
+		body, err := json.Marshal(resp)
+		if err != nil {
+			return err
+		}
+		return u.idempotencyKeyRepo.SaveResponse(txCtx, req.UserID, req.IdempotencyKey, body)
+	})
+	if err != nil {
+		return dto.SubmitFinancingResponse{}, err
+	}
+
+	return resp, nil
+}
 
+// submit checks, prices and stores a submission within the caller's transaction.
+func (u *financingUsecase) submit(txCtx context.Context, req dto.SubmitFinancingRequest) (dto.SubmitFinancingResponse, error) {
 	// Validation
-	if req.Amount <= 0 {
-		return dto.SubmitFinancingResponse{}, errors.New("amount must be greater than 0")
+	if err := checkAmount(req.Amount); err != nil {
+		return dto.SubmitFinancingResponse{}, err
+	}
+	startDate, err := parseStartDate(req.StartDate)
+	if err != nil {
+		return dto.SubmitFinancingResponse{}, err
 	}
-	validTenors := map[int]bool{6: true, 12: true, 18: true, 24: true, 30: true, 36: true}
-	if !validTenors[req.Tenor] {
-		return dto.SubmitFinancingResponse{}, errors.New("invalid tenor")
+	if err := u.checkStartDateWindow(startDate); err != nil {
+		return dto.SubmitFinancingResponse{}, err
 	}
-	startDate, err := time.Parse("2006-01-02", req.StartDate)
+
+	// Resolve the tenor through the same table CalculateAllTenors offers from
+	tenor, err := u.submissionTenor(txCtx, req.Tenor)
 	if err != nil {
-		return dto.SubmitFinancingResponse{}, errors.New("invalid start_date format")
+		return dto.SubmitFinancingResponse{}, err
 	}
 
-	limit, err := u.facilityLimitRepo.GetByID(ctx, req.FacilityLimitID)
+	product, err := u.getProduct(txCtx, productCode(req.ProductCode))
 	if err != nil {
-		return dto.SubmitFinancingResponse{}, fmt.Errorf("no financing facilities yet")
+		return dto.SubmitFinancingResponse{}, err
 	}
-	if limit.UserID != req.UserID {
-		return dto.SubmitFinancingResponse{}, errors.New("facility limit does not belong to user")
+
+	// Pricing in effect at submission time
+	marginRate, err := u.submissionMarginRate(txCtx, product, tenor)
+	if err != nil {
+		return dto.SubmitFinancingResponse{}, err
 	}
-	if limit.LimitAmount < req.Amount {
-		return dto.SubmitFinancingResponse{}, errors.New("insufficient facility limit")
+
+	grace, err := submissionGracePeriod(product, tenor, req)
+	if err != nil {
+		return dto.SubmitFinancingResponse{}, err
 	}
 
 	// Calculate
-	tenor := domain.Tenor{TenorValue: req.Tenor}
-	monthly, totalMargin, totalPayment := tenor.Calculate(req.Amount, marginRate)
+	plan := u.schedulePolicy.Plan(product.CalculationMethod, tenor, req.Amount, marginRate, grace)
 
-	var schedules []dto.ScheduleItem
+	// Only customers whose identity has been verified can take up financing. The user row stays
+	// locked so concurrent submissions cannot both pass the active facility cap.
+	if err := checkKYC(u.userRepo.GetByIDForUpdate(txCtx, req.UserID)); err != nil {
+		return dto.SubmitFinancingResponse{}, err
+	}
+	if err := u.checkActiveFacilities(txCtx, req.UserID); err != nil {
+		return dto.SubmitFinancingResponse{}, err
+	}
 
-	// using the callback pattern provided by our TransactionManager.
-	err = u.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
-		// Save User Facility
-		userFacility := domain.UserFacility{
-			UserID:             req.UserID,
-			FacilityLimitID:    req.FacilityLimitID,
-			Amount:             req.Amount,
-			Tenor:              req.Tenor,
-			StartDate:          startDate,
-			MonthlyInstallment: monthly,
-			TotalMargin:        totalMargin,
-			TotalPayment:       totalPayment,
-		}
-		if err := u.userFacilityRepo.Create(txCtx, &userFacility); err != nil {
-			return err
-		}
+	// Lock the limit row so concurrent submissions cannot reserve the same available amount.
+	limit, err := u.facilityLimitRepo.GetByIDForUpdate(txCtx, req.FacilityLimitID)
+	if err != nil {
+		return dto.SubmitFinancingResponse{}, facilityLimitLookup(err)
+	}
+	if err := checkLimitOwner(limit, req.UserID); err != nil {
+		return dto.SubmitFinancingResponse{}, err
+	}
+	if err := checkLimitExpiry(limit, truncateToDate(u.now())); err != nil {
+		return dto.SubmitFinancingResponse{}, err
+	}
+	if err := checkRemainingLimit(limit, req.Amount); err != nil {
+		return dto.SubmitFinancingResponse{}, err
+	}
 
-		// Generate installment schedule
-		var facilityDetails []domain.UserFacilityDetail
-		for i := 1; i <= req.Tenor; i++ {
-			due := startDate.AddDate(0, i, 0)
-			schedules = append(schedules, dto.ScheduleItem{
-				DueDate:           due.Format("2006-01-02"),
-				InstallmentAmount: monthly,
-			})
-			facilityDetails = append(facilityDetails, domain.UserFacilityDetail{
-				UserFacilityID:    userFacility.UserFacilityID,
-				DueDate:           due,
-				InstallmentAmount: monthly,
-			})
-		}
+	// Save User Facility
+	userFacility := domain.UserFacility{
+		UserID:             req.UserID,
+		FacilityLimitID:    req.FacilityLimitID,
+		ProductCode:        product.ProductCode,
+		MarginRate:         marginRate,
+		CalculationMethod:  plan.Method,
+		Amount:             req.Amount,
+		Tenor:              req.Tenor,
+		GracePeriod:        grace,
+		StartDate:          startDate,
+		MonthlyInstallment: plan.MonthlyInstallment,
+		TotalMargin:        plan.TotalMargin,
+		TotalPayment:       plan.TotalPayment,
+		Status:             domain.FacilityStatusSubmitted,
+		ScheduleVersion:    1,
+	}
+	if err := u.userFacilityRepo.Create(txCtx, &userFacility); err != nil {
+		return dto.SubmitFinancingResponse{}, err
+	}
+	if err := u.statusHistoryRepo.Create(txCtx, &domain.FacilityStatusHistory{
+		UserFacilityID: userFacility.UserFacilityID,
+		ToStatus:       domain.FacilityStatusSubmitted,
+		Actor:          fmt.Sprintf("user:%d", req.UserID),
+	}); err != nil {
+		return dto.SubmitFinancingResponse{}, err
+	}
 
-		if err := u.userFacilityDetailRepo.BulkCreate(txCtx, facilityDetails); err != nil {
-			return err
-		}
+	// Generate installment schedule, the rounding residual is booked on a single installment
+	facilityDetails := plan.Details(userFacility, userFacility.StartDate, u.schedulePolicy.DueDates)
 
-		return nil
-	})
-	if err != nil {
+	if err := u.userFacilityDetailRepo.BulkCreate(txCtx, facilityDetails); err != nil {
+		return dto.SubmitFinancingResponse{}, err
+	}
+
+	// Reserve the submitted amount against the limit
+	if err := u.facilityLimitRepo.UpdateUsedAmount(txCtx, limit.FacilityLimitID, limit.UsedAmount+req.Amount); err != nil {
 		return dto.SubmitFinancingResponse{}, err
 	}
 
-	// Return response
 	return dto.SubmitFinancingResponse{
-		UserID:          req.UserID,
-		FacilityLimitID: req.FacilityLimitID,
-		Amount:          req.Amount,
-		Tenor:           req.Tenor,
-		StartDate:       req.StartDate,
-		MonthlyInstall:  monthly,
-		TotalMargin:     totalMargin,
-		TotalPayment:    totalPayment,
-		Schedule:        schedules,
+		UserFacilityID:    userFacility.UserFacilityID,
+		UserID:            req.UserID,
+		FacilityLimitID:   req.FacilityLimitID,
+		ProductCode:       product.ProductCode,
+		CalculationMethod: plan.Method,
+		Amount:            req.Amount,
+		Tenor:             req.Tenor,
+		GracePeriodMonths: grace.Months,
+		GraceType:         grace.Type,
+		MarginRate:        marginRate,
+		StartDate:         req.StartDate,
+		MonthlyInstall:    plan.MonthlyInstallment,
+		TotalMargin:       plan.TotalMargin,
+		TotalPayment:      plan.TotalPayment,
+		Status:            userFacility.Status,
+		Schedule:          toScheduleItems(facilityDetails),
 	}, nil
 }
+
+// reserveIdempotencyKey claims the request's idempotency key. When the key was used before it
+// decodes the original response into resp and reports true, or fails if the request differs.
+func (u *financingUsecase) reserveIdempotencyKey(ctx context.Context, req dto.SubmitFinancingRequest, resp *dto.SubmitFinancingResponse) (bool, error) {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return false, err
+	}
+	hash := sha256.Sum256(body)
+
+	stored, reserved, err := u.idempotencyKeyRepo.Reserve(ctx, domain.IdempotencyKey{
+		UserID:      req.UserID,
+		Key:         req.IdempotencyKey,
+		RequestHash: hex.EncodeToString(hash[:]),
+	})
+	if errors.Is(err, domain.ErrNotFound) {
+		return false, errUserNotFound
+	}
+	if err != nil || reserved {
+		return false, err
+	}
+
+	if stored.RequestHash != hex.EncodeToString(hash[:]) {
+		return false, domain.ConflictError("IDEMPOTENCY_KEY_REUSED", "idempotency key was already used for a different request")
+	}
+	if err := json.Unmarshal(stored.Response, resp); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (u *financingUsecase) GetFacility(ctx context.Context, id int64) (dto.FacilityResponse, error) {
+	facility, err := u.userFacilityRepo.GetByID(ctx, id)
+	if errors.Is(err, domain.ErrNotFound) {
+		return dto.FacilityResponse{}, errFacilityNotFound
+	}
+	if err != nil {
+		return dto.FacilityResponse{}, err
+	}
+
+	details, err := u.userFacilityDetailRepo.ListByFacilityID(ctx, facility.UserFacilityID)
+	if err != nil {
+		return dto.FacilityResponse{}, err
+	}
+
+	histories, err := u.statusHistoryRepo.ListByFacilityID(ctx, facility.UserFacilityID)
+	if err != nil {
+		return dto.FacilityResponse{}, err
+	}
+
+	resp := toFacilityResponse(facility)
+	resp.Schedule = toScheduleItems(details)
+	resp.StatusHistory = toStatusHistoryItems(histories)
+	return resp, nil
+}
+
+func toFacilityResponse(uf domain.UserFacility) dto.FacilityResponse {
+	return dto.FacilityResponse{
+		UserFacilityID:    uf.UserFacilityID,
+		UserID:            uf.UserID,
+		FacilityLimitID:   uf.FacilityLimitID,
+		ProductCode:       uf.ProductCode,
+		CalculationMethod: uf.CalculationMethod,
+		Amount:            uf.Amount,
+		Tenor:             uf.Tenor,
+		GracePeriodMonths: uf.GracePeriod.Months,
+		GraceType:         uf.GracePeriod.Type,
+		MarginRate:        uf.MarginRate,
+		StartDate:         uf.StartDate.Format("2006-01-02"),
+		MonthlyInstall:    uf.MonthlyInstallment,
+		TotalMargin:       uf.TotalMargin,
+		TotalPayment:      uf.TotalPayment,
+		Status:            uf.Status,
+		CreditBalance:     uf.CreditBalance,
+		CreatedAt:         uf.CreatedAt,
+	}
+}
+
+func toStatusHistoryItems(histories []domain.FacilityStatusHistory) []dto.StatusHistoryItem {
+	items := make([]dto.StatusHistoryItem, 0, len(histories))
+	for _, h := range histories {
+		items = append(items, dto.StatusHistoryItem{
+			FromStatus: h.FromStatus,
+			ToStatus:   h.ToStatus,
+			Actor:      h.Actor,
+			Reason:     h.Reason,
+			CreatedAt:  h.CreatedAt,
+		})
+	}
+	return items
+}
+
+func toScheduleItems(details []domain.UserFacilityDetail) []dto.ScheduleItem {
+	items := make([]dto.ScheduleItem, 0, len(details))
+	for _, d := range details {
+		items = append(items, dto.ScheduleItem{
+			DetailID:          d.DetailID,
+			DueDate:           d.DueDate.Format("2006-01-02"),
+			InstallmentAmount: d.InstallmentAmount,
+			PrincipalAmount:   d.PrincipalAmount,
+			MarginAmount:      d.MarginAmount,
+			OutstandingAfter:  d.OutstandingAfter,
+			PaidAmount:        d.PaidAmount,
+			Status:            d.Status,
+		})
+	}
+	return items
+}
+
+const (
+	defaultPageSize = 20
+	maxPageSize     = 100
+)
+
+func (u *financingUsecase) ListUserFacilities(ctx context.Context, userID int64, req dto.ListFacilitiesRequest) (dto.FacilityListResponse, error) {
+	filter, err := newFacilityFilter(userID, req)
+	if err != nil {
+		return dto.FacilityListResponse{}, err
+	}
+
+	facilities, total, err := u.userFacilityRepo.ListByUser(ctx, filter)
+	if err != nil {
+		return dto.FacilityListResponse{}, err
+	}
+
+	items := make([]dto.FacilityResponse, 0, len(facilities))
+	for _, f := range facilities {
+		items = append(items, toFacilityResponse(f))
+	}
+
+	return dto.FacilityListResponse{
+		Facilities: items,
+		Page:       filter.Offset/filter.Limit + 1,
+		PageSize:   filter.Limit,
+		Total:      total,
+		TotalPages: int((total + int64(filter.Limit) - 1) / int64(filter.Limit)),
+	}, nil
+}
+
+// newFacilityFilter validates the list query and applies paging and sorting defaults.
+func newFacilityFilter(userID int64, req dto.ListFacilitiesRequest) (domain.UserFacilityFilter, error) {
+	filter := domain.UserFacilityFilter{
+		UserID:   userID,
+		Tenor:    req.Tenor,
+		SortBy:   domain.FacilitySortCreatedAt,
+		SortDesc: true,
+		Limit:    defaultPageSize,
+	}
+	if userID <= 0 {
+		return filter, domain.ValidationError("INVALID_USER_ID", "invalid user id")
+	}
+	if req.Page < 0 || req.PageSize < 0 || req.Tenor < 0 {
+		return filter, domain.ValidationError("INVALID_QUERY", "page, page_size and tenor must not be negative")
+	}
+
+	if req.PageSize > 0 {
+		filter.Limit = min(req.PageSize, maxPageSize)
+	}
+	if req.Page > 1 {
+		filter.Offset = (req.Page - 1) * filter.Limit
+	}
+
+	if req.Status != "" {
+		filter.Status = domain.FacilityStatus(strings.ToUpper(req.Status))
+		if !filter.Status.IsValid() {
+			return filter, domain.ValidationError("INVALID_STATUS", "invalid status")
+		}
+	}
+
+	if req.StartDateFrom != "" {
+		from, err := time.Parse("2006-01-02", req.StartDateFrom)
+		if err != nil {
+			return filter, domain.ValidationError("INVALID_START_DATE_FROM", "invalid start_date_from format")
+		}
+		filter.StartDateFrom = &from
+	}
+	if req.StartDateTo != "" {
+		to, err := time.Parse("2006-01-02", req.StartDateTo)
+		if err != nil {
+			return filter, domain.ValidationError("INVALID_START_DATE_TO", "invalid start_date_to format")
+		}
+		filter.StartDateTo = &to
+	}
+	if filter.StartDateFrom != nil && filter.StartDateTo != nil && filter.StartDateFrom.After(*filter.StartDateTo) {
+		return filter, domain.ValidationError("INVALID_DATE_RANGE", "start_date_from must not be after start_date_to")
+	}
+
+	switch req.SortBy {
+	case "":
+	case domain.FacilitySortCreatedAt, domain.FacilitySortStartDate, domain.FacilitySortAmount, domain.FacilitySortTenor:
+		filter.SortBy = req.SortBy
+	default:
+		return filter, domain.ValidationError("INVALID_SORT_BY", "invalid sort_by")
+	}
+
+	switch strings.ToLower(req.Order) {
+	case "", "desc":
+	case "asc":
+		filter.SortDesc = false
+	default:
+		return filter, domain.ValidationError("INVALID_ORDER", "invalid order")
+	}
+
+	return filter, nil
+}
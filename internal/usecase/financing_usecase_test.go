@@ -2,6 +2,9 @@ package usecase_test
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"testing"
 	"time"
@@ -25,9 +28,17 @@ func TestCalculateAllTenors(t *testing.T) {
 	mockTM := new(mocks.TransactionManager)
 
 	t.Run("should return error if amount <= 0", func(t *testing.T) {
-		uc := usecase.NewFinancingUsecase(mockRepo, mockUFDetail, mockUF, mockLimit, mockTM)
+		uc := usecase.NewFinancingUsecase(usecase.FinancingDeps{
+			TenorRepo:              mockRepo,
+			ProductRepo:            flatProductRepo(),
+			UserFacilityDetailRepo: mockUFDetail,
+			UserFacilityRepo:       mockUF,
+			FacilityLimitRepo:      mockLimit,
+			TxManager:              mockTM,
+			SchedulePolicy:         domain.DefaultSchedulePolicy(),
+		})
 
-		resp, err := uc.CalculateAllTenors(ctx, 0)
+		resp, err := uc.CalculateAllTenors(ctx, dto.CalculateRequest{Amount: 0})
 
 		assert.Error(t, err)
 		assert.Equal(t, "amount must be greater than 0", err.Error())
@@ -36,11 +47,19 @@ func TestCalculateAllTenors(t *testing.T) {
 
 	t.Run("should return error if repo returns error", func(t *testing.T) {
 		mockRepo := new(mocks.TenorRepository)
-		mockRepo.On("GetAll", mock.Anything).Return(nil, errors.New("db error"))
+		mockRepo.On("GetAllPriced", mock.Anything, domain.DefaultProductCode, mock.Anything).Return(nil, errors.New("db error"))
 
-		uc := usecase.NewFinancingUsecase(mockRepo, mockUFDetail, mockUF, mockLimit, mockTM)
+		uc := usecase.NewFinancingUsecase(usecase.FinancingDeps{
+			TenorRepo:              mockRepo,
+			ProductRepo:            flatProductRepo(),
+			UserFacilityDetailRepo: mockUFDetail,
+			UserFacilityRepo:       mockUF,
+			FacilityLimitRepo:      mockLimit,
+			TxManager:              mockTM,
+			SchedulePolicy:         domain.DefaultSchedulePolicy(),
+		})
 
-		resp, err := uc.CalculateAllTenors(ctx, 1000000)
+		resp, err := uc.CalculateAllTenors(ctx, dto.CalculateRequest{Amount: domain.NewMoney(1000000)})
 
 		assert.Error(t, err)
 		assert.Equal(t, "db error", err.Error())
@@ -49,11 +68,19 @@ func TestCalculateAllTenors(t *testing.T) {
 
 	t.Run("should return error if no tenor available", func(t *testing.T) {
 		mockRepo := new(mocks.TenorRepository)
-		mockRepo.On("GetAll", mock.Anything).Return([]domain.Tenor{}, nil)
+		mockRepo.On("GetAllPriced", mock.Anything, domain.DefaultProductCode, mock.Anything).Return([]domain.Tenor{}, nil)
 
-		uc := usecase.NewFinancingUsecase(mockRepo, mockUFDetail, mockUF, mockLimit, mockTM)
+		uc := usecase.NewFinancingUsecase(usecase.FinancingDeps{
+			TenorRepo:              mockRepo,
+			ProductRepo:            flatProductRepo(),
+			UserFacilityDetailRepo: mockUFDetail,
+			UserFacilityRepo:       mockUF,
+			FacilityLimitRepo:      mockLimit,
+			TxManager:              mockTM,
+			SchedulePolicy:         domain.DefaultSchedulePolicy(),
+		})
 
-		resp, err := uc.CalculateAllTenors(ctx, 1000000)
+		resp, err := uc.CalculateAllTenors(ctx, dto.CalculateRequest{Amount: domain.NewMoney(1000000)})
 
 		assert.Error(t, err)
 		assert.Equal(t, "no tenor available", err.Error())
@@ -63,28 +90,164 @@ func TestCalculateAllTenors(t *testing.T) {
 	t.Run("should return calculations correctly", func(t *testing.T) {
 		mockRepo := new(mocks.TenorRepository)
 
-		// Tenor sample
+		// Tenor sample priced for the default product
 		tenors := []domain.Tenor{
-			{TenorValue: 6},
-			{TenorValue: 12},
+			{TenorValue: 6, MarginRate: 2000},
+			{TenorValue: 12, MarginRate: 2000},
 		}
 
-		mockRepo.On("GetAll", mock.Anything).Return(tenors, nil)
+		mockRepo.On("GetAllPriced", mock.Anything, domain.DefaultProductCode, mock.Anything).Return(tenors, nil)
 
-		uc := usecase.NewFinancingUsecase(mockRepo, mockUFDetail, mockUF, mockLimit, mockTM)
+		uc := usecase.NewFinancingUsecase(usecase.FinancingDeps{
+			TenorRepo:              mockRepo,
+			ProductRepo:            flatProductRepo(),
+			UserFacilityDetailRepo: mockUFDetail,
+			UserFacilityRepo:       mockUF,
+			FacilityLimitRepo:      mockLimit,
+			TxManager:              mockTM,
+			SchedulePolicy:         domain.DefaultSchedulePolicy(),
+		})
 
-		resp, err := uc.CalculateAllTenors(ctx, 12000000)
+		resp, err := uc.CalculateAllTenors(ctx, dto.CalculateRequest{Amount: domain.NewMoney(12000000)})
 
 		assert.NoError(t, err)
 		assert.Len(t, resp.Calculations, 2)
 
-		expectedMargin := (12000000 * 0.2 * float64(6)) / 12
-		expectedPayment := 12000000 + expectedMargin
-		expectedInstallment := expectedPayment / 6
+		expectedMargin := domain.NewMoney(1200000)   // (12jt * 0.2 * 6) / 12
+		expectedPayment := domain.NewMoney(13200000) // 12jt + margin
+		expectedInstallment := domain.NewMoney(2200000)
 
-		assert.InDelta(t, expectedMargin, resp.Calculations[0].TotalMargin, 0.01)
-		assert.InDelta(t, expectedPayment, resp.Calculations[0].TotalPayment, 0.01)
-		assert.InDelta(t, expectedInstallment, resp.Calculations[0].MonthlyInstallment, 0.01)
+		assert.Equal(t, expectedMargin, resp.Calculations[0].TotalMargin)
+		assert.Equal(t, expectedPayment, resp.Calculations[0].TotalPayment)
+		assert.Equal(t, expectedInstallment, resp.Calculations[0].MonthlyInstallment)
+		assert.Equal(t, domain.DefaultProductCode, resp.ProductCode)
+	})
+
+	t.Run("should use the margin rate configured for each tenor of the product", func(t *testing.T) {
+		mockRepo := new(mocks.TenorRepository)
+
+		tenors := []domain.Tenor{
+			{TenorValue: 6, MarginRate: 1500},  // 15%
+			{TenorValue: 36, MarginRate: 2500}, // 25%
+		}
+		mockRepo.On("GetAllPriced", mock.Anything, "SYARIAH", mock.Anything).Return(tenors, nil).Once()
+
+		uc := usecase.NewFinancingUsecase(usecase.FinancingDeps{
+			TenorRepo:              mockRepo,
+			ProductRepo:            flatProductRepo(),
+			UserFacilityDetailRepo: mockUFDetail,
+			UserFacilityRepo:       mockUF,
+			FacilityLimitRepo:      mockLimit,
+			TxManager:              mockTM,
+			SchedulePolicy:         domain.DefaultSchedulePolicy(),
+		})
+
+		resp, err := uc.CalculateAllTenors(ctx, dto.CalculateRequest{Amount: domain.NewMoney(12000000), ProductCode: " syariah "})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "SYARIAH", resp.ProductCode)
+		assert.Equal(t, domain.NewMoney(900000), resp.Calculations[0].TotalMargin)  // 12jt * 0.15 * 6/12
+		assert.Equal(t, domain.NewMoney(9000000), resp.Calculations[1].TotalMargin) // 12jt * 0.25 * 36/12
+		assert.Equal(t, domain.Rate(2500), resp.Calculations[1].MarginRate)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("should use the annuity calculation selected by the product", func(t *testing.T) {
+		mockRepo := new(mocks.TenorRepository)
+		mockProductRepo := new(mocks.ProductRepository)
+
+		mockProductRepo.On("GetByCode", mock.Anything, "EFEKTIF").Return(domain.Product{ProductCode: "EFEKTIF", CalculationMethod: domain.CalculationAnnuity}, nil).Once()
+		mockRepo.On("GetAllPriced", mock.Anything, "EFEKTIF", mock.Anything).Return([]domain.Tenor{{TenorValue: 12, MarginRate: 1200}}, nil).Once()
+
+		uc := usecase.NewFinancingUsecase(usecase.FinancingDeps{
+			TenorRepo:              mockRepo,
+			ProductRepo:            mockProductRepo,
+			UserFacilityDetailRepo: mockUFDetail,
+			UserFacilityRepo:       mockUF,
+			FacilityLimitRepo:      mockLimit,
+			TxManager:              mockTM,
+			SchedulePolicy:         domain.DefaultSchedulePolicy(),
+		})
+
+		resp, err := uc.CalculateAllTenors(ctx, dto.CalculateRequest{Amount: domain.NewMoney(12000000), ProductCode: "EFEKTIF"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, domain.CalculationAnnuity, resp.CalculationMethod)
+		assert.Equal(t, domain.NewMoney(1066185), resp.Calculations[0].MonthlyInstallment)
+		assert.Equal(t, domain.Money(79422589), resp.Calculations[0].TotalMargin)
+		mockProductRepo.AssertExpectations(t)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("should apply the requested grace period and skip tenors too short for it", func(t *testing.T) {
+		mockRepo := new(mocks.TenorRepository)
+		tenors := []domain.Tenor{
+			{TenorValue: 3, MarginRate: 2000},
+			{TenorValue: 12, MarginRate: 2000},
+		}
+		mockRepo.On("GetAllPriced", mock.Anything, domain.DefaultProductCode, mock.Anything).Return(tenors, nil).Once()
+
+		uc := usecase.NewFinancingUsecase(usecase.FinancingDeps{
+			TenorRepo:              mockRepo,
+			ProductRepo:            flatProductRepo(),
+			UserFacilityDetailRepo: mockUFDetail,
+			UserFacilityRepo:       mockUF,
+			FacilityLimitRepo:      mockLimit,
+			TxManager:              mockTM,
+			SchedulePolicy:         domain.DefaultSchedulePolicy(),
+		})
+
+		months := 3
+		resp, err := uc.CalculateAllTenors(ctx, dto.CalculateRequest{Amount: domain.NewMoney(12000000), GracePeriodMonths: &months, GraceType: "zero"})
+
+		assert.NoError(t, err)
+		assert.Len(t, resp.Calculations, 1)
+		assert.Equal(t, 12, resp.Calculations[0].Tenor)
+		assert.Equal(t, domain.NewMoney(1600000), resp.Calculations[0].MonthlyInstallment)
+		assert.Equal(t, domain.NewMoney(2400000), resp.Calculations[0].TotalMargin)
+		assert.Equal(t, 3, resp.GracePeriodMonths)
+		assert.Equal(t, domain.GraceZero, resp.GraceType)
+	})
+
+	t.Run("should return error if the grace period covers every tenor", func(t *testing.T) {
+		mockRepo := new(mocks.TenorRepository)
+		mockRepo.On("GetAllPriced", mock.Anything, domain.DefaultProductCode, mock.Anything).Return([]domain.Tenor{{TenorValue: 6, MarginRate: 2000}}, nil).Once()
+
+		uc := usecase.NewFinancingUsecase(usecase.FinancingDeps{
+			TenorRepo:              mockRepo,
+			ProductRepo:            flatProductRepo(),
+			UserFacilityDetailRepo: mockUFDetail,
+			UserFacilityRepo:       mockUF,
+			FacilityLimitRepo:      mockLimit,
+			TxManager:              mockTM,
+			SchedulePolicy:         domain.DefaultSchedulePolicy(),
+		})
+
+		months := 6
+		_, err := uc.CalculateAllTenors(ctx, dto.CalculateRequest{Amount: domain.NewMoney(12000000), GracePeriodMonths: &months, GraceType: "MARGIN_ONLY"})
+
+		assert.ErrorIs(t, err, domain.ErrValidation)
+		assert.Equal(t, "grace_period_months must be shorter than the tenor", err.Error())
+	})
+
+	t.Run("should return error if product is unknown", func(t *testing.T) {
+		mockProductRepo := new(mocks.ProductRepository)
+		mockProductRepo.On("GetByCode", mock.Anything, "GHOST").Return(domain.Product{}, domain.ErrNotFound).Once()
+
+		uc := usecase.NewFinancingUsecase(usecase.FinancingDeps{
+			TenorRepo:              mockRepo,
+			ProductRepo:            mockProductRepo,
+			UserFacilityDetailRepo: mockUFDetail,
+			UserFacilityRepo:       mockUF,
+			FacilityLimitRepo:      mockLimit,
+			TxManager:              mockTM,
+			SchedulePolicy:         domain.DefaultSchedulePolicy(),
+		})
+
+		_, err := uc.CalculateAllTenors(ctx, dto.CalculateRequest{Amount: domain.NewMoney(12000000), ProductCode: "ghost"})
+
+		assert.EqualError(t, err, "unknown product")
+		mockProductRepo.AssertExpectations(t)
 	})
 }
 
@@ -96,13 +259,25 @@ func TestFinancingUsecase_SubmitFinancing(t *testing.T) {
 		mockFacilityLimitRepo := new(mocks.UserFacilityLimitRepository)
 		mockUserFacilityDetailRepo := new(mocks.UserFacilityDetailRepository)
 		mockTxManager := new(mocks.TransactionManager)
-		uc := usecase.NewFinancingUsecase(nil, mockUserFacilityDetailRepo, mockUserFacilityRepo, mockFacilityLimitRepo, mockTxManager)
+		mockTenorRepo := new(mocks.TenorRepository)
+		mockStatusHistoryRepo := new(mocks.FacilityStatusHistoryRepository)
+		uc := usecase.NewFinancingUsecase(usecase.FinancingDeps{
+			TenorRepo:              mockTenorRepo,
+			ProductRepo:            flatProductRepo(),
+			UserFacilityDetailRepo: mockUserFacilityDetailRepo,
+			UserFacilityRepo:       mockUserFacilityRepo,
+			StatusHistoryRepo:      mockStatusHistoryRepo,
+			UserRepo:               verifiedUserRepo(),
+			FacilityLimitRepo:      mockFacilityLimitRepo,
+			TxManager:              mockTxManager,
+			SchedulePolicy:         domain.DefaultSchedulePolicy(),
+		})
 
 		ctx := context.Background()
 		req := dto.SubmitFinancingRequest{
 			UserID:          1,
 			FacilityLimitID: 10,
-			Amount:          12000000,
+			Amount:          domain.NewMoney(12000000),
 			Tenor:           12,
 			StartDate:       "2025-08-10",
 		}
@@ -110,26 +285,42 @@ func TestFinancingUsecase_SubmitFinancing(t *testing.T) {
 		mockLimit := domain.UserFacilityLimit{
 			FacilityLimitID: 10,
 			UserID:          1,
-			LimitAmount:     15000000, // Limit enough
+			LimitAmount:     domain.NewMoney(15000000), // Limit enough
+			UsedAmount:      domain.NewMoney(1000000),
 		}
 
-		// DB Integration: Mock expectaion for GetByID
-		mockFacilityLimitRepo.On("GetByID", ctx, req.FacilityLimitID).Return(mockLimit, nil).Once()
+		// DB Integration: Mock expectaion for tenor and pricing lookup
+		mockTenorRepo.On("GetByValue", ctx, req.Tenor).Return(domain.Tenor{TenorID: 2, TenorValue: 12, IsActive: true}, nil).Once()
+		mockTenorRepo.On("GetMarginRate", ctx, domain.DefaultProductCode, req.Tenor, mock.AnythingOfType("time.Time")).Return(domain.Rate(2000), nil).Once()
 
 		// DB Integration: Mock expectaion for Transaction Manager
 		mockTxManager.On("WithTransaction", ctx, mock.AnythingOfType("func(context.Context) error")).Return(nil).Run(func(args mock.Arguments) {
 			fn := args.Get(1).(func(txCtx context.Context) error)
 
+			// Mock locking GetByIDForUpdate in transaction
+			mockFacilityLimitRepo.On("GetByIDForUpdate", mock.Anything, req.FacilityLimitID).Return(mockLimit, nil).Once()
+
 			// Mock Create UserFacility in transaction
 			mockUserFacilityRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.UserFacility")).Return(nil).Run(func(args mock.Arguments) {
 				// Simulation database return ID after data created
 				userFacility := args.Get(1).(*domain.UserFacility)
+				assert.Equal(t, domain.DefaultProductCode, userFacility.ProductCode)
+				assert.Equal(t, domain.Rate(2000), userFacility.MarginRate)
+				assert.Equal(t, domain.FacilityStatusSubmitted, userFacility.Status)
 				userFacility.UserFacilityID = 100
 			}).Once()
 
+			// Mock recording the submission as the first status of the facility
+			mockStatusHistoryRepo.On("Create", mock.Anything, mock.MatchedBy(func(h *domain.FacilityStatusHistory) bool {
+				return h.UserFacilityID == 100 && h.FromStatus == "" && h.ToStatus == domain.FacilityStatusSubmitted && h.Actor == "user:1"
+			})).Return(nil).Once()
+
 			// Mock BulkCreate UserFacilityDetail in transaction
 			mockUserFacilityDetailRepo.On("BulkCreate", mock.Anything, mock.AnythingOfType("[]domain.UserFacilityDetail")).Return(nil).Once()
 
+			// Mock reserving the submitted amount on top of the already used amount
+			mockFacilityLimitRepo.On("UpdateUsedAmount", mock.Anything, req.FacilityLimitID, domain.NewMoney(13000000)).Return(nil).Once()
+
 			// Execution callback function
 			err := fn(ctx)
 			assert.NoError(t, err) // Pastikan tidak ada error di dalam callback
@@ -143,22 +334,33 @@ func TestFinancingUsecase_SubmitFinancing(t *testing.T) {
 		assert.NotNil(t, res)
 
 		// Verification calc
-		expectedMarginRate := 0.20
-		expectedTotalMargin := req.Amount * expectedMarginRate * (float64(req.Tenor) / 12.0) // 12,000,000 * 0.20 * 1 = 2,400,000
-		expectedTotalPayment := req.Amount + expectedTotalMargin                             // 12,000,000 + 2,400,000 = 14,400,000
-		expectedMonthly := expectedTotalPayment / float64(req.Tenor)                         // 14,400,000 / 12 = 1,200,000
+		expectedTotalMargin := domain.NewMoney(2400000)   // 12,000,000 * 0.20 * 1 = 2,400,000
+		expectedTotalPayment := domain.NewMoney(14400000) // 12,000,000 + 2,400,000 = 14,400,000
+		expectedMonthly := domain.NewMoney(1200000)       // 14,400,000 / 12 = 1,200,000
 
+		assert.Equal(t, int64(100), res.UserFacilityID)
+		assert.Equal(t, domain.FacilityStatusSubmitted, res.Status)
 		assert.Equal(t, req.UserID, res.UserID)
 		assert.Equal(t, req.Amount, res.Amount)
-		assert.InDelta(t, expectedTotalMargin, res.TotalMargin, 0.01, "Perhitungan TotalMargin salah")
-		assert.InDelta(t, expectedTotalPayment, res.TotalPayment, 0.01, "Perhitungan TotalPayment salah")
-		assert.InDelta(t, expectedMonthly, res.MonthlyInstall, 0.01, "Perhitungan MonthlyInstall salah")
+		assert.Equal(t, expectedTotalMargin, res.TotalMargin, "Perhitungan TotalMargin salah")
+		assert.Equal(t, expectedTotalPayment, res.TotalPayment, "Perhitungan TotalPayment salah")
+		assert.Equal(t, expectedMonthly, res.MonthlyInstall, "Perhitungan MonthlyInstall salah")
 
 		assert.Len(t, res.Schedule, req.Tenor, "Jumlah jadwal angsuran tidak sesuai tenor")
 		expectedFirstDueDate, _ := time.Parse("2006-01-02", "2025-09-10")
 		assert.Equal(t, expectedFirstDueDate.Format("2006-01-02"), res.Schedule[0].DueDate, "Tanggal jatuh tempo pertama salah")
 		assert.Equal(t, expectedMonthly, res.Schedule[0].InstallmentAmount, "Jumlah angsuran pada jadwal salah")
+		assert.Equal(t, domain.NewMoney(1000000), res.Schedule[0].PrincipalAmount, "Pokok angsuran pada jadwal salah")
+		assert.Equal(t, domain.NewMoney(200000), res.Schedule[0].MarginAmount, "Margin angsuran pada jadwal salah")
+		assert.Equal(t, domain.NewMoney(11000000), res.Schedule[0].OutstandingAfter, "Sisa pokok pada jadwal salah")
+
+		var scheduleTotal domain.Money
+		for _, item := range res.Schedule {
+			scheduleTotal += item.InstallmentAmount
+		}
+		assert.Equal(t, expectedTotalPayment, scheduleTotal, "Total jadwal angsuran harus sama dengan TotalPayment")
 
+		mockTenorRepo.AssertExpectations(t)
 		mockFacilityLimitRepo.AssertExpectations(t)
 		mockTxManager.AssertExpectations(t)
 		mockUserFacilityRepo.AssertExpectations(t)
@@ -166,7 +368,10 @@ func TestFinancingUsecase_SubmitFinancing(t *testing.T) {
 	})
 
 	// Setup a simple usecase for input validation test cases
-	ucSimple := usecase.NewFinancingUsecase(nil, nil, nil, nil, nil)
+	ucSimple := usecase.NewFinancingUsecase(usecase.FinancingDeps{
+		TxManager:      passthroughTxManager(),
+		SchedulePolicy: domain.DefaultSchedulePolicy(),
+	})
 
 	// Case 2: Validation Failure - Amount <= 0
 	t.Run("2. Gagal - Validasi amount <= 0", func(t *testing.T) {
@@ -179,14 +384,86 @@ func TestFinancingUsecase_SubmitFinancing(t *testing.T) {
 
 	// Case 3: Validation Failure - Invalid Tenor
 	t.Run("3. Failure - Validation for invalid tenor", func(t *testing.T) {
-		req := dto.SubmitFinancingRequest{Amount: 1000, Tenor: 10} // Tenor 10 is invalid
-		_, err := ucSimple.SubmitFinancing(context.Background(), req)
+		mockTenorRepo := new(mocks.TenorRepository)
+		uc := usecase.NewFinancingUsecase(usecase.FinancingDeps{
+			TenorRepo:      mockTenorRepo,
+			ProductRepo:    flatProductRepo(),
+			TxManager:      passthroughTxManager(),
+			SchedulePolicy: domain.DefaultSchedulePolicy(),
+		})
+
+		req := dto.SubmitFinancingRequest{Amount: 1000, Tenor: 10, StartDate: "2025-08-10"} // Tenor 10 is not in the tenors table
+		mockTenorRepo.On("GetByValue", mock.Anything, 10).Return(domain.Tenor{}, domain.ErrNotFound).Once()
+
+		_, err := uc.SubmitFinancing(context.Background(), req)
 
 		assert.Error(t, err)
 		assert.Equal(t, "invalid tenor", err.Error())
+		mockTenorRepo.AssertExpectations(t)
+	})
+
+	t.Run("Failure - Validation for inactive tenor", func(t *testing.T) {
+		mockTenorRepo := new(mocks.TenorRepository)
+		uc := usecase.NewFinancingUsecase(usecase.FinancingDeps{
+			TenorRepo:      mockTenorRepo,
+			ProductRepo:    flatProductRepo(),
+			TxManager:      passthroughTxManager(),
+			SchedulePolicy: domain.DefaultSchedulePolicy(),
+		})
+
+		req := dto.SubmitFinancingRequest{Amount: 1000, Tenor: 30, StartDate: "2025-08-10"}
+		mockTenorRepo.On("GetByValue", mock.Anything, 30).Return(domain.Tenor{TenorID: 5, TenorValue: 30, IsActive: false}, nil).Once()
+
+		_, err := uc.SubmitFinancing(context.Background(), req)
+
+		assert.Error(t, err)
+		assert.Equal(t, "invalid tenor", err.Error())
+		mockTenorRepo.AssertNotCalled(t, "GetMarginRate", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Success - Tenor added to the tenors table is accepted", func(t *testing.T) {
+		mockTenorRepo := new(mocks.TenorRepository)
+		mockFacilityLimitRepo := new(mocks.UserFacilityLimitRepository)
+		uc := usecase.NewFinancingUsecase(usecase.FinancingDeps{
+			TenorRepo:         mockTenorRepo,
+			ProductRepo:       flatProductRepo(),
+			UserRepo:          verifiedUserRepo(),
+			FacilityLimitRepo: mockFacilityLimitRepo,
+			TxManager:         passthroughTxManager(),
+			SchedulePolicy:    domain.DefaultSchedulePolicy(),
+		})
+		ctx := context.Background()
+
+		req := dto.SubmitFinancingRequest{UserID: 1, FacilityLimitID: 10, Amount: 1000, Tenor: 48, StartDate: "2025-08-10"}
+		mockTenorRepo.On("GetByValue", ctx, 48).Return(domain.Tenor{TenorID: 7, TenorValue: 48, IsActive: true}, nil).Once()
+		mockTenorRepo.On("GetMarginRate", ctx, domain.DefaultProductCode, 48, mock.AnythingOfType("time.Time")).Return(domain.Rate(2000), nil).Once()
+		// The tenor is priced, the submission goes on to the limit
+		mockFacilityLimitRepo.On("GetByIDForUpdate", ctx, int64(10)).Return(domain.UserFacilityLimit{}, errors.New("stop")).Once()
+
+		_, err := uc.SubmitFinancing(ctx, req)
+
+		assert.EqualError(t, err, "stop")
+		mockTenorRepo.AssertExpectations(t)
+		mockFacilityLimitRepo.AssertExpectations(t)
 	})
 
 	// Case 4: Validation Failure - Incorrect start_date format
+	t.Run("Failure - Grace period not shorter than the tenor", func(t *testing.T) {
+		uc := usecase.NewFinancingUsecase(usecase.FinancingDeps{
+			TenorRepo:      pricedTenorRepo(),
+			ProductRepo:    flatProductRepo(),
+			TxManager:      passthroughTxManager(),
+			SchedulePolicy: domain.DefaultSchedulePolicy(),
+		})
+
+		months := 12
+		req := dto.SubmitFinancingRequest{UserID: 1, FacilityLimitID: 10, Amount: 1000, Tenor: 12, StartDate: "2025-08-10", GracePeriodMonths: &months, GraceType: "ZERO"}
+		_, err := uc.SubmitFinancing(context.Background(), req)
+
+		assert.ErrorIs(t, err, domain.ErrValidation)
+		assert.Equal(t, "grace_period_months must be shorter than the tenor", err.Error())
+	})
+
 	t.Run("4. Failure - Validation for incorrect start_date format", func(t *testing.T) {
 		req := dto.SubmitFinancingRequest{Amount: 1000, Tenor: 12, StartDate: "10-08-2025"} // Incorrect format
 		_, err := ucSimple.SubmitFinancing(context.Background(), req)
@@ -195,32 +472,219 @@ func TestFinancingUsecase_SubmitFinancing(t *testing.T) {
 		assert.Equal(t, "invalid start_date format", err.Error())
 	})
 
+	t.Run("Failure - No margin rate configured for tenor", func(t *testing.T) {
+		mockTenorRepo := new(mocks.TenorRepository)
+		uc := usecase.NewFinancingUsecase(usecase.FinancingDeps{
+			TenorRepo:      mockTenorRepo,
+			ProductRepo:    flatProductRepo(),
+			TxManager:      passthroughTxManager(),
+			SchedulePolicy: domain.DefaultSchedulePolicy(),
+		})
+		ctx := context.Background()
+
+		req := dto.SubmitFinancingRequest{UserID: 1, FacilityLimitID: 10, ProductCode: "syariah", Amount: 1000, Tenor: 12, StartDate: "2025-08-10"}
+		mockTenorRepo.On("GetByValue", ctx, 12).Return(domain.Tenor{TenorID: 2, TenorValue: 12, IsActive: true}, nil).Once()
+		mockTenorRepo.On("GetMarginRate", ctx, "SYARIAH", 12, mock.AnythingOfType("time.Time")).Return(domain.Rate(0), domain.ErrNotFound).Once()
+
+		_, err := uc.SubmitFinancing(ctx, req)
+
+		assert.Error(t, err)
+		assert.Equal(t, "no margin rate configured for tenor", err.Error())
+		mockTenorRepo.AssertExpectations(t)
+	})
+
 	// Case 5 & Error Handling
 	t.Run("5. Failure - Insufficient financing limit", func(t *testing.T) {
 		mockFacilityLimitRepo := new(mocks.UserFacilityLimitRepository)
-		uc := usecase.NewFinancingUsecase(nil, nil, nil, mockFacilityLimitRepo, nil)
+		mockTxManager := new(mocks.TransactionManager)
+		uc := usecase.NewFinancingUsecase(usecase.FinancingDeps{
+			TenorRepo:         pricedTenorRepo(),
+			ProductRepo:       flatProductRepo(),
+			UserRepo:          verifiedUserRepo(),
+			FacilityLimitRepo: mockFacilityLimitRepo,
+			TxManager:         mockTxManager,
+			SchedulePolicy:    domain.DefaultSchedulePolicy(),
+		})
 		ctx := context.Background()
 
-		req := dto.SubmitFinancingRequest{UserID: 1, FacilityLimitID: 10, Amount: 20000000, Tenor: 12, StartDate: "2025-08-10"}
-		mockLimit := domain.UserFacilityLimit{UserID: 1, LimitAmount: 15000000} // Insufficient limit
+		req := dto.SubmitFinancingRequest{UserID: 1, FacilityLimitID: 10, Amount: domain.NewMoney(20000000), Tenor: 12, StartDate: "2025-08-10"}
+		mockLimit := domain.UserFacilityLimit{UserID: 1, LimitAmount: domain.NewMoney(15000000)} // Insufficient limit
+		expectedErr := errors.New("insufficient facility limit")
 
-		mockFacilityLimitRepo.On("GetByID", ctx, req.FacilityLimitID).Return(mockLimit, nil).Once()
+		mockTxManager.On("WithTransaction", ctx, mock.AnythingOfType("func(context.Context) error")).Return(expectedErr).Run(func(args mock.Arguments) {
+			fn := args.Get(1).(func(txCtx context.Context) error)
+			mockFacilityLimitRepo.On("GetByIDForUpdate", mock.Anything, req.FacilityLimitID).Return(mockLimit, nil).Once()
+			err := fn(ctx)
+			assert.ErrorIs(t, err, domain.ErrInsufficientLimit)
+		}).Once()
 
 		_, err := uc.SubmitFinancing(ctx, req)
 
 		assert.Error(t, err)
 		assert.Equal(t, "insufficient facility limit", err.Error()) // Assert for a clear error message
 		mockFacilityLimitRepo.AssertExpectations(t)
+		mockTxManager.AssertExpectations(t)
+	})
+
+	t.Run("Failure - Limit already consumed by earlier submissions", func(t *testing.T) {
+		mockFacilityLimitRepo := new(mocks.UserFacilityLimitRepository)
+		mockTxManager := new(mocks.TransactionManager)
+		uc := usecase.NewFinancingUsecase(usecase.FinancingDeps{
+			TenorRepo:         pricedTenorRepo(),
+			ProductRepo:       flatProductRepo(),
+			UserRepo:          verifiedUserRepo(),
+			FacilityLimitRepo: mockFacilityLimitRepo,
+			TxManager:         mockTxManager,
+			SchedulePolicy:    domain.DefaultSchedulePolicy(),
+		})
+		ctx := context.Background()
+
+		req := dto.SubmitFinancingRequest{UserID: 1, FacilityLimitID: 10, Amount: domain.NewMoney(6000000), Tenor: 12, StartDate: "2025-08-10"}
+		mockLimit := domain.UserFacilityLimit{UserID: 1, LimitAmount: domain.NewMoney(10000000), UsedAmount: domain.NewMoney(5000000)} // Only 5jt available
+
+		mockTxManager.On("WithTransaction", ctx, mock.AnythingOfType("func(context.Context) error")).Return(errors.New("insufficient facility limit")).Run(func(args mock.Arguments) {
+			fn := args.Get(1).(func(txCtx context.Context) error)
+			mockFacilityLimitRepo.On("GetByIDForUpdate", mock.Anything, req.FacilityLimitID).Return(mockLimit, nil).Once()
+			err := fn(ctx)
+			assert.EqualError(t, err, "insufficient facility limit")
+		}).Once()
+
+		_, err := uc.SubmitFinancing(ctx, req)
+
+		assert.Error(t, err)
+		mockFacilityLimitRepo.AssertNotCalled(t, "UpdateUsedAmount", mock.Anything, mock.Anything, mock.Anything)
+		mockTxManager.AssertExpectations(t)
+	})
+
+	t.Run("Failure - Facility limit expired", func(t *testing.T) {
+		mockFacilityLimitRepo := new(mocks.UserFacilityLimitRepository)
+		mockTxManager := new(mocks.TransactionManager)
+		uc := usecase.NewFinancingUsecase(usecase.FinancingDeps{
+			TenorRepo:         pricedTenorRepo(),
+			ProductRepo:       flatProductRepo(),
+			UserRepo:          verifiedUserRepo(),
+			FacilityLimitRepo: mockFacilityLimitRepo,
+			TxManager:         mockTxManager,
+			SchedulePolicy:    domain.DefaultSchedulePolicy(),
+		})
+		ctx := context.Background()
+
+		req := dto.SubmitFinancingRequest{UserID: 1, FacilityLimitID: 10, Amount: domain.NewMoney(1000000), Tenor: 12, StartDate: "2025-08-10"}
+		yesterday := time.Now().UTC().AddDate(0, 0, -1)
+		mockLimit := domain.UserFacilityLimit{UserID: 1, LimitAmount: domain.NewMoney(10000000), ExpiresAt: &yesterday}
+
+		mockTxManager.On("WithTransaction", ctx, mock.AnythingOfType("func(context.Context) error")).Return(domain.ErrConflict).Run(func(args mock.Arguments) {
+			fn := args.Get(1).(func(txCtx context.Context) error)
+			mockFacilityLimitRepo.On("GetByIDForUpdate", mock.Anything, req.FacilityLimitID).Return(mockLimit, nil).Once()
+			err := fn(ctx)
+			assert.ErrorIs(t, err, domain.ErrConflict)
+			assert.EqualError(t, err, "facility limit has expired")
+		}).Once()
+
+		_, err := uc.SubmitFinancing(ctx, req)
+
+		assert.Error(t, err)
+		mockFacilityLimitRepo.AssertNotCalled(t, "UpdateUsedAmount", mock.Anything, mock.Anything, mock.Anything)
+		mockTxManager.AssertExpectations(t)
+	})
+
+	t.Run("Failure - User KYC not verified", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		mockFacilityLimitRepo := new(mocks.UserFacilityLimitRepository)
+		mockTxManager := new(mocks.TransactionManager)
+		uc := usecase.NewFinancingUsecase(usecase.FinancingDeps{
+			TenorRepo:         pricedTenorRepo(),
+			ProductRepo:       flatProductRepo(),
+			UserRepo:          mockUserRepo,
+			FacilityLimitRepo: mockFacilityLimitRepo,
+			TxManager:         mockTxManager,
+			SchedulePolicy:    domain.DefaultSchedulePolicy(),
+		})
+		ctx := context.Background()
+
+		mockTxManager.On("WithTransaction", ctx, mock.AnythingOfType("func(context.Context) error")).Return(domain.ErrForbidden).Run(func(args mock.Arguments) {
+			fn := args.Get(1).(func(txCtx context.Context) error)
+			mockUserRepo.On("GetByIDForUpdate", mock.Anything, int64(1)).Return(domain.User{UserID: 1, KYCStatus: domain.KYCPending}, nil).Once()
+			err := fn(ctx)
+			assert.ErrorIs(t, err, domain.ErrForbidden)
+			assert.EqualError(t, err, "user identity (KYC) is not verified")
+		}).Once()
+
+		req := dto.SubmitFinancingRequest{UserID: 1, FacilityLimitID: 10, Amount: 1000, Tenor: 12, StartDate: "2025-08-10"}
+		_, err := uc.SubmitFinancing(ctx, req)
+
+		assert.ErrorIs(t, err, domain.ErrForbidden)
+		mockFacilityLimitRepo.AssertNotCalled(t, "GetByIDForUpdate", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Failure - Start date outside the window", func(t *testing.T) {
+		mockTenorRepo := new(mocks.TenorRepository)
+		uc := usecase.NewFinancingUsecase(usecase.FinancingDeps{
+			TenorRepo:        mockTenorRepo,
+			TxManager:        passthroughTxManager(),
+			SchedulePolicy:   domain.DefaultSchedulePolicy(),
+			SubmissionPolicy: domain.SubmissionPolicy{StartDateMaxDaysBack: 3, StartDateMaxDaysAhead: 30},
+		})
+		ctx := context.Background()
+
+		req := dto.SubmitFinancingRequest{UserID: 1, FacilityLimitID: 10, Amount: 1000, Tenor: 12, StartDate: time.Now().UTC().AddDate(0, 0, -4).Format("2006-01-02")}
+		_, err := uc.SubmitFinancing(ctx, req)
+
+		assert.ErrorIs(t, err, domain.ErrValidation)
+		var domainErr *domain.Error
+		assert.ErrorAs(t, err, &domainErr)
+		assert.Equal(t, "START_DATE_OUT_OF_WINDOW", domainErr.Code)
+		mockTenorRepo.AssertNotCalled(t, "GetByValue", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Failure - User already holds the maximum of active facilities", func(t *testing.T) {
+		mockUserFacilityRepo := new(mocks.UserFacilityRepository)
+		mockFacilityLimitRepo := new(mocks.UserFacilityLimitRepository)
+		uc := usecase.NewFinancingUsecase(usecase.FinancingDeps{
+			TenorRepo:         pricedTenorRepo(),
+			ProductRepo:       flatProductRepo(),
+			UserFacilityRepo:  mockUserFacilityRepo,
+			UserRepo:          verifiedUserRepo(),
+			FacilityLimitRepo: mockFacilityLimitRepo,
+			TxManager:         passthroughTxManager(),
+			SchedulePolicy:    domain.DefaultSchedulePolicy(),
+			SubmissionPolicy:  domain.SubmissionPolicy{MaxActiveFacilities: 3},
+		})
+		ctx := context.Background()
+
+		mockUserFacilityRepo.On("CountByUser", ctx, int64(1), domain.ActiveFacilityStatuses).Return(3, nil).Once()
+
+		req := dto.SubmitFinancingRequest{UserID: 1, FacilityLimitID: 10, Amount: 1000, Tenor: 12, StartDate: "2025-08-10"}
+		_, err := uc.SubmitFinancing(ctx, req)
+
+		assert.ErrorIs(t, err, domain.ErrConflict)
+		assert.EqualError(t, err, "user already has 3 active facilities, the maximum is 3")
+		mockFacilityLimitRepo.AssertNotCalled(t, "GetByIDForUpdate", mock.Anything, mock.Anything)
+		mockUserFacilityRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
 	})
 
 	t.Run("Failure - Facility limit not found", func(t *testing.T) {
 		mockFacilityLimitRepo := new(mocks.UserFacilityLimitRepository)
-		uc := usecase.NewFinancingUsecase(nil, nil, nil, mockFacilityLimitRepo, nil)
+		mockTxManager := new(mocks.TransactionManager)
+		uc := usecase.NewFinancingUsecase(usecase.FinancingDeps{
+			TenorRepo:         pricedTenorRepo(),
+			ProductRepo:       flatProductRepo(),
+			UserRepo:          verifiedUserRepo(),
+			FacilityLimitRepo: mockFacilityLimitRepo,
+			TxManager:         mockTxManager,
+			SchedulePolicy:    domain.DefaultSchedulePolicy(),
+		})
 		ctx := context.Background()
-		dbError := errors.New("not found")
 
 		req := dto.SubmitFinancingRequest{UserID: 1, FacilityLimitID: 10, Amount: 1000, Tenor: 12, StartDate: "2025-01-01"}
-		mockFacilityLimitRepo.On("GetByID", ctx, req.FacilityLimitID).Return(domain.UserFacilityLimit{}, dbError).Once()
+
+		mockTxManager.On("WithTransaction", ctx, mock.AnythingOfType("func(context.Context) error")).Return(errors.New("no financing facilities yet")).Run(func(args mock.Arguments) {
+			fn := args.Get(1).(func(txCtx context.Context) error)
+			mockFacilityLimitRepo.On("GetByIDForUpdate", mock.Anything, req.FacilityLimitID).Return(domain.UserFacilityLimit{}, domain.ErrNotFound).Once()
+			err := fn(ctx)
+			assert.EqualError(t, err, "no financing facilities yet")
+			assert.ErrorIs(t, err, domain.ErrNotFound)
+		}).Once()
 
 		_, err := uc.SubmitFinancing(ctx, req)
 
@@ -229,22 +693,88 @@ func TestFinancingUsecase_SubmitFinancing(t *testing.T) {
 		mockFacilityLimitRepo.AssertExpectations(t)
 	})
 
+	t.Run("Failure - Database outage while locking the limit is not reported as missing facility", func(t *testing.T) {
+		mockFacilityLimitRepo := new(mocks.UserFacilityLimitRepository)
+		mockTxManager := new(mocks.TransactionManager)
+		uc := usecase.NewFinancingUsecase(usecase.FinancingDeps{
+			TenorRepo:         pricedTenorRepo(),
+			ProductRepo:       flatProductRepo(),
+			UserRepo:          verifiedUserRepo(),
+			FacilityLimitRepo: mockFacilityLimitRepo,
+			TxManager:         mockTxManager,
+			SchedulePolicy:    domain.DefaultSchedulePolicy(),
+		})
+		ctx := context.Background()
+		dbError := errors.New("connection refused")
+
+		req := dto.SubmitFinancingRequest{UserID: 1, FacilityLimitID: 10, Amount: 1000, Tenor: 12, StartDate: "2025-01-01"}
+
+		mockTxManager.On("WithTransaction", ctx, mock.AnythingOfType("func(context.Context) error")).Return(dbError).Run(func(args mock.Arguments) {
+			fn := args.Get(1).(func(txCtx context.Context) error)
+			mockFacilityLimitRepo.On("GetByIDForUpdate", mock.Anything, req.FacilityLimitID).Return(domain.UserFacilityLimit{}, dbError).Once()
+			err := fn(ctx)
+			assert.Equal(t, dbError, err)
+		}).Once()
+
+		_, err := uc.SubmitFinancing(ctx, req)
+
+		assert.Equal(t, dbError, err)
+		mockFacilityLimitRepo.AssertExpectations(t)
+	})
+
+	t.Run("Failure - Facility limit owned by another user", func(t *testing.T) {
+		mockFacilityLimitRepo := new(mocks.UserFacilityLimitRepository)
+		mockTxManager := new(mocks.TransactionManager)
+		uc := usecase.NewFinancingUsecase(usecase.FinancingDeps{
+			TenorRepo:         pricedTenorRepo(),
+			ProductRepo:       flatProductRepo(),
+			UserRepo:          verifiedUserRepo(),
+			FacilityLimitRepo: mockFacilityLimitRepo,
+			TxManager:         mockTxManager,
+			SchedulePolicy:    domain.DefaultSchedulePolicy(),
+		})
+		ctx := context.Background()
+
+		req := dto.SubmitFinancingRequest{UserID: 1, FacilityLimitID: 10, Amount: 1000, Tenor: 12, StartDate: "2025-01-01"}
+		mockLimit := domain.UserFacilityLimit{FacilityLimitID: 10, UserID: 2, LimitAmount: domain.NewMoney(10000000)}
+
+		mockTxManager.On("WithTransaction", ctx, mock.AnythingOfType("func(context.Context) error")).Return(domain.ErrForbidden).Run(func(args mock.Arguments) {
+			fn := args.Get(1).(func(txCtx context.Context) error)
+			mockFacilityLimitRepo.On("GetByIDForUpdate", mock.Anything, req.FacilityLimitID).Return(mockLimit, nil).Once()
+			err := fn(ctx)
+			assert.ErrorIs(t, err, domain.ErrForbidden)
+			assert.EqualError(t, err, "facility limit does not belong to user")
+		}).Once()
+
+		_, err := uc.SubmitFinancing(ctx, req)
+
+		assert.ErrorIs(t, err, domain.ErrForbidden)
+		mockFacilityLimitRepo.AssertExpectations(t)
+	})
+
 	t.Run("Failure - Database transaction fails on Create UserFacility", func(t *testing.T) {
 		mockUserFacilityRepo := new(mocks.UserFacilityRepository)
 		mockFacilityLimitRepo := new(mocks.UserFacilityLimitRepository)
 		mockTxManager := new(mocks.TransactionManager)
-		uc := usecase.NewFinancingUsecase(nil, nil, mockUserFacilityRepo, mockFacilityLimitRepo, mockTxManager)
+		uc := usecase.NewFinancingUsecase(usecase.FinancingDeps{
+			TenorRepo:         pricedTenorRepo(),
+			ProductRepo:       flatProductRepo(),
+			UserFacilityRepo:  mockUserFacilityRepo,
+			UserRepo:          verifiedUserRepo(),
+			FacilityLimitRepo: mockFacilityLimitRepo,
+			TxManager:         mockTxManager,
+			SchedulePolicy:    domain.DefaultSchedulePolicy(),
+		})
 		ctx := context.Background()
 
 		req := dto.SubmitFinancingRequest{UserID: 1, FacilityLimitID: 10, Amount: 1000, Tenor: 12, StartDate: "2025-01-01"}
 		mockLimit := domain.UserFacilityLimit{UserID: 1, LimitAmount: 5000}
 		dbError := errors.New("DB write error")
 
-		mockFacilityLimitRepo.On("GetByID", ctx, req.FacilityLimitID).Return(mockLimit, nil).Once()
-
 		// Simulate a transaction failure
 		mockTxManager.On("WithTransaction", ctx, mock.AnythingOfType("func(context.Context) error")).Return(dbError).Run(func(args mock.Arguments) {
 			fn := args.Get(1).(func(txCtx context.Context) error)
+			mockFacilityLimitRepo.On("GetByIDForUpdate", mock.Anything, req.FacilityLimitID).Return(mockLimit, nil).Once()
 			mockUserFacilityRepo.On("Create", mock.Anything, mock.Anything).Return(dbError).Once()
 			err := fn(ctx)
 			assert.Equal(t, dbError, err)
@@ -258,3 +788,407 @@ func TestFinancingUsecase_SubmitFinancing(t *testing.T) {
 		mockTxManager.AssertExpectations(t)
 	})
 }
+
+func TestFinancingUsecase_GetFacility(t *testing.T) {
+	ctx := context.Background()
+	startDate := time.Date(2025, 8, 10, 0, 0, 0, 0, time.UTC)
+
+	t.Run("success - returns facility with its schedule", func(t *testing.T) {
+		mockUserFacilityRepo := new(mocks.UserFacilityRepository)
+		mockUserFacilityDetailRepo := new(mocks.UserFacilityDetailRepository)
+		mockStatusHistoryRepo := new(mocks.FacilityStatusHistoryRepository)
+		uc := usecase.NewFinancingUsecase(usecase.FinancingDeps{
+			UserFacilityDetailRepo: mockUserFacilityDetailRepo,
+			UserFacilityRepo:       mockUserFacilityRepo,
+			StatusHistoryRepo:      mockStatusHistoryRepo,
+			SchedulePolicy:         domain.DefaultSchedulePolicy(),
+		})
+
+		facility := domain.UserFacility{
+			UserFacilityID:     100,
+			UserID:             1,
+			FacilityLimitID:    10,
+			ProductCode:        domain.DefaultProductCode,
+			CalculationMethod:  domain.CalculationFlat,
+			Amount:             domain.NewMoney(12000000),
+			Tenor:              12,
+			MarginRate:         2000,
+			StartDate:          startDate,
+			MonthlyInstallment: domain.NewMoney(1200000),
+			TotalMargin:        domain.NewMoney(2400000),
+			TotalPayment:       domain.NewMoney(14400000),
+			Status:             domain.FacilityStatusApproved,
+		}
+		histories := []domain.FacilityStatusHistory{
+			{UserFacilityID: 100, ToStatus: domain.FacilityStatusSubmitted, Actor: "user:1"},
+			{UserFacilityID: 100, FromStatus: domain.FacilityStatusSubmitted, ToStatus: domain.FacilityStatusApproved, Actor: "analyst"},
+		}
+		details := []domain.UserFacilityDetail{
+			{DetailID: 1, UserFacilityID: 100, DueDate: startDate.AddDate(0, 1, 0), InstallmentAmount: domain.NewMoney(1200000), PrincipalAmount: domain.NewMoney(1000000), MarginAmount: domain.NewMoney(200000), OutstandingAfter: domain.NewMoney(11000000)},
+			{DetailID: 2, UserFacilityID: 100, DueDate: startDate.AddDate(0, 2, 0), InstallmentAmount: domain.NewMoney(1200000), PrincipalAmount: domain.NewMoney(1000000), MarginAmount: domain.NewMoney(200000), OutstandingAfter: domain.NewMoney(10000000)},
+		}
+
+		mockUserFacilityRepo.On("GetByID", ctx, int64(100)).Return(facility, nil).Once()
+		mockUserFacilityDetailRepo.On("ListByFacilityID", ctx, int64(100)).Return(details, nil).Once()
+		mockStatusHistoryRepo.On("ListByFacilityID", ctx, int64(100)).Return(histories, nil).Once()
+
+		res, err := uc.GetFacility(ctx, 100)
+
+		assert.NoError(t, err)
+		assert.Equal(t, domain.FacilityStatusApproved, res.Status)
+		assert.Len(t, res.StatusHistory, 2)
+		assert.Equal(t, "analyst", res.StatusHistory[1].Actor)
+		assert.Equal(t, int64(100), res.UserFacilityID)
+		assert.Equal(t, "2025-08-10", res.StartDate)
+		assert.Equal(t, domain.NewMoney(14400000), res.TotalPayment)
+		assert.Len(t, res.Schedule, 2)
+		assert.Equal(t, int64(2), res.Schedule[1].DetailID)
+		assert.Equal(t, "2025-10-10", res.Schedule[1].DueDate)
+		assert.Equal(t, domain.NewMoney(10000000), res.Schedule[1].OutstandingAfter)
+		mockUserFacilityRepo.AssertExpectations(t)
+		mockUserFacilityDetailRepo.AssertExpectations(t)
+	})
+
+	t.Run("Failure - facility not found", func(t *testing.T) {
+		mockUserFacilityRepo := new(mocks.UserFacilityRepository)
+		uc := usecase.NewFinancingUsecase(usecase.FinancingDeps{
+			UserFacilityRepo: mockUserFacilityRepo,
+			SchedulePolicy:   domain.DefaultSchedulePolicy(),
+		})
+
+		mockUserFacilityRepo.On("GetByID", ctx, int64(404)).Return(domain.UserFacility{}, domain.ErrNotFound).Once()
+
+		_, err := uc.GetFacility(ctx, 404)
+
+		assert.EqualError(t, err, "facility not found")
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+		mockUserFacilityRepo.AssertExpectations(t)
+	})
+
+	t.Run("Failure - schedule cannot be loaded", func(t *testing.T) {
+		mockUserFacilityRepo := new(mocks.UserFacilityRepository)
+		mockUserFacilityDetailRepo := new(mocks.UserFacilityDetailRepository)
+		uc := usecase.NewFinancingUsecase(usecase.FinancingDeps{
+			UserFacilityDetailRepo: mockUserFacilityDetailRepo,
+			UserFacilityRepo:       mockUserFacilityRepo,
+			SchedulePolicy:         domain.DefaultSchedulePolicy(),
+		})
+
+		mockUserFacilityRepo.On("GetByID", ctx, int64(100)).Return(domain.UserFacility{UserFacilityID: 100}, nil).Once()
+		mockUserFacilityDetailRepo.On("ListByFacilityID", ctx, int64(100)).Return(nil, errors.New("db error")).Once()
+
+		_, err := uc.GetFacility(ctx, 100)
+
+		assert.EqualError(t, err, "db error")
+	})
+}
+
+func TestFinancingUsecase_SubmitFinancingIdempotency(t *testing.T) {
+	ctx := context.Background()
+	req := dto.SubmitFinancingRequest{UserID: 1, FacilityLimitID: 10, Amount: domain.NewMoney(12000000), Tenor: 12, StartDate: "2025-08-10", IdempotencyKey: "retry-1"}
+
+	body, _ := json.Marshal(req)
+	sum := sha256.Sum256(body)
+	requestHash := hex.EncodeToString(sum[:])
+
+	t.Run("success - first request stores its response under the key", func(t *testing.T) {
+		mockFacilityLimitRepo := new(mocks.UserFacilityLimitRepository)
+		mockUserFacilityRepo := new(mocks.UserFacilityRepository)
+		mockUserFacilityDetailRepo := new(mocks.UserFacilityDetailRepository)
+		mockIdempotencyKeyRepo := new(mocks.IdempotencyKeyRepository)
+		mockStatusHistoryRepo := new(mocks.FacilityStatusHistoryRepository)
+		mockTxManager := new(mocks.TransactionManager)
+		uc := usecase.NewFinancingUsecase(usecase.FinancingDeps{
+			TenorRepo:              pricedTenorRepo(),
+			ProductRepo:            flatProductRepo(),
+			UserFacilityDetailRepo: mockUserFacilityDetailRepo,
+			UserFacilityRepo:       mockUserFacilityRepo,
+			StatusHistoryRepo:      mockStatusHistoryRepo,
+			UserRepo:               verifiedUserRepo(),
+			FacilityLimitRepo:      mockFacilityLimitRepo,
+			IdempotencyKeyRepo:     mockIdempotencyKeyRepo,
+			TxManager:              mockTxManager,
+			SchedulePolicy:         domain.DefaultSchedulePolicy(),
+		})
+
+		var saved []byte
+		mockTxManager.On("WithTransaction", ctx, mock.AnythingOfType("func(context.Context) error")).Return(nil).Run(func(args mock.Arguments) {
+			fn := args.Get(1).(func(txCtx context.Context) error)
+			reserved := domain.IdempotencyKey{UserID: 1, Key: "retry-1", RequestHash: requestHash}
+			mockIdempotencyKeyRepo.On("Reserve", mock.Anything, reserved).Return(reserved, true, nil).Once()
+			mockFacilityLimitRepo.On("GetByIDForUpdate", mock.Anything, req.FacilityLimitID).Return(domain.UserFacilityLimit{FacilityLimitID: 10, UserID: 1, LimitAmount: domain.NewMoney(15000000)}, nil).Once()
+			mockUserFacilityRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.UserFacility")).Return(nil).Run(func(args mock.Arguments) {
+				args.Get(1).(*domain.UserFacility).UserFacilityID = 100
+			}).Once()
+			mockStatusHistoryRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.FacilityStatusHistory")).Return(nil).Once()
+			mockUserFacilityDetailRepo.On("BulkCreate", mock.Anything, mock.AnythingOfType("[]domain.UserFacilityDetail")).Return(nil).Once()
+			mockFacilityLimitRepo.On("UpdateUsedAmount", mock.Anything, req.FacilityLimitID, req.Amount).Return(nil).Once()
+			mockIdempotencyKeyRepo.On("SaveResponse", mock.Anything, int64(1), "retry-1", mock.AnythingOfType("[]uint8")).Return(nil).Run(func(args mock.Arguments) {
+				saved = args.Get(3).([]byte)
+			}).Once()
+			assert.NoError(t, fn(ctx))
+		}).Once()
+
+		res, err := uc.SubmitFinancing(ctx, req)
+
+		assert.NoError(t, err)
+		var stored dto.SubmitFinancingResponse
+		assert.NoError(t, json.Unmarshal(saved, &stored))
+		assert.Equal(t, res, stored)
+		mockIdempotencyKeyRepo.AssertExpectations(t)
+		mockFacilityLimitRepo.AssertExpectations(t)
+	})
+
+	t.Run("success - replay returns the original response without submitting again", func(t *testing.T) {
+		mockFacilityLimitRepo := new(mocks.UserFacilityLimitRepository)
+		mockIdempotencyKeyRepo := new(mocks.IdempotencyKeyRepository)
+		mockTxManager := new(mocks.TransactionManager)
+		uc := usecase.NewFinancingUsecase(usecase.FinancingDeps{
+			TenorRepo:          pricedTenorRepo(),
+			ProductRepo:        flatProductRepo(),
+			UserRepo:           verifiedUserRepo(),
+			FacilityLimitRepo:  mockFacilityLimitRepo,
+			IdempotencyKeyRepo: mockIdempotencyKeyRepo,
+			TxManager:          mockTxManager,
+			SchedulePolicy:     domain.DefaultSchedulePolicy(),
+		})
+
+		original := dto.SubmitFinancingResponse{UserFacilityID: 100, UserID: 1, FacilityLimitID: 10, Amount: req.Amount, Tenor: 12, StartDate: "2025-08-10"}
+		response, _ := json.Marshal(original)
+
+		mockTxManager.On("WithTransaction", ctx, mock.AnythingOfType("func(context.Context) error")).Return(nil).Run(func(args mock.Arguments) {
+			fn := args.Get(1).(func(txCtx context.Context) error)
+			mockIdempotencyKeyRepo.On("Reserve", mock.Anything, mock.AnythingOfType("domain.IdempotencyKey")).
+				Return(domain.IdempotencyKey{UserID: 1, Key: "retry-1", RequestHash: requestHash, Response: response}, false, nil).Once()
+			assert.NoError(t, fn(ctx))
+		}).Once()
+
+		res, err := uc.SubmitFinancing(ctx, req)
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(100), res.UserFacilityID)
+		mockFacilityLimitRepo.AssertNotCalled(t, "GetByIDForUpdate", mock.Anything, mock.Anything)
+		mockIdempotencyKeyRepo.AssertNotCalled(t, "SaveResponse", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("success - replay after the tenor was deactivated returns the original response", func(t *testing.T) {
+		mockTenorRepo := new(mocks.TenorRepository)
+		mockIdempotencyKeyRepo := new(mocks.IdempotencyKeyRepository)
+		uc := usecase.NewFinancingUsecase(usecase.FinancingDeps{
+			TenorRepo:          mockTenorRepo,
+			ProductRepo:        flatProductRepo(),
+			IdempotencyKeyRepo: mockIdempotencyKeyRepo,
+			TxManager:          passthroughTxManager(),
+			SchedulePolicy:     domain.DefaultSchedulePolicy(),
+		})
+
+		original := dto.SubmitFinancingResponse{UserFacilityID: 100, UserID: 1, FacilityLimitID: 10, Amount: req.Amount, Tenor: 12, StartDate: "2025-08-10"}
+		response, _ := json.Marshal(original)
+
+		// Checking the request again would now fail with INVALID_TENOR
+		mockTenorRepo.On("GetByValue", mock.Anything, 12).Return(domain.Tenor{TenorValue: 12, IsActive: false}, nil).Maybe()
+		mockIdempotencyKeyRepo.On("Reserve", mock.Anything, mock.AnythingOfType("domain.IdempotencyKey")).
+			Return(domain.IdempotencyKey{UserID: 1, Key: "retry-1", RequestHash: requestHash, Response: response}, false, nil).Once()
+
+		res, err := uc.SubmitFinancing(ctx, req)
+
+		assert.NoError(t, err)
+		assert.Equal(t, original, res)
+		mockTenorRepo.AssertNotCalled(t, "GetByValue", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Failure - unknown user with a key is not found, also on retry", func(t *testing.T) {
+		mockTenorRepo := new(mocks.TenorRepository)
+		mockIdempotencyKeyRepo := new(mocks.IdempotencyKeyRepository)
+		uc := usecase.NewFinancingUsecase(usecase.FinancingDeps{
+			TenorRepo:          mockTenorRepo,
+			IdempotencyKeyRepo: mockIdempotencyKeyRepo,
+			TxManager:          passthroughTxManager(),
+			SchedulePolicy:     domain.DefaultSchedulePolicy(),
+		})
+
+		// The key references the user, reserving it for an unknown user violates the foreign key
+		mockIdempotencyKeyRepo.On("Reserve", mock.Anything, mock.AnythingOfType("domain.IdempotencyKey")).
+			Return(domain.IdempotencyKey{}, false, domain.ErrNotFound).Twice()
+
+		for range 2 {
+			_, err := uc.SubmitFinancing(ctx, req)
+
+			assert.ErrorIs(t, err, domain.ErrNotFound)
+			var domainErr *domain.Error
+			assert.ErrorAs(t, err, &domainErr)
+			assert.Equal(t, "USER_NOT_FOUND", domainErr.Code)
+		}
+		mockIdempotencyKeyRepo.AssertExpectations(t)
+		mockTenorRepo.AssertNotCalled(t, "GetByValue", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Failure - key reused with a different body", func(t *testing.T) {
+		mockFacilityLimitRepo := new(mocks.UserFacilityLimitRepository)
+		mockIdempotencyKeyRepo := new(mocks.IdempotencyKeyRepository)
+		mockTxManager := new(mocks.TransactionManager)
+		uc := usecase.NewFinancingUsecase(usecase.FinancingDeps{
+			TenorRepo:          pricedTenorRepo(),
+			ProductRepo:        flatProductRepo(),
+			UserRepo:           verifiedUserRepo(),
+			FacilityLimitRepo:  mockFacilityLimitRepo,
+			IdempotencyKeyRepo: mockIdempotencyKeyRepo,
+			TxManager:          mockTxManager,
+			SchedulePolicy:     domain.DefaultSchedulePolicy(),
+		})
+
+		mockTxManager.On("WithTransaction", ctx, mock.AnythingOfType("func(context.Context) error")).Return(domain.ErrConflict).Run(func(args mock.Arguments) {
+			fn := args.Get(1).(func(txCtx context.Context) error)
+			mockIdempotencyKeyRepo.On("Reserve", mock.Anything, mock.AnythingOfType("domain.IdempotencyKey")).
+				Return(domain.IdempotencyKey{UserID: 1, Key: "retry-1", RequestHash: "another-request"}, false, nil).Once()
+			err := fn(ctx)
+			assert.ErrorIs(t, err, domain.ErrConflict)
+		}).Once()
+
+		_, err := uc.SubmitFinancing(ctx, req)
+
+		assert.ErrorIs(t, err, domain.ErrConflict)
+		mockFacilityLimitRepo.AssertNotCalled(t, "GetByIDForUpdate", mock.Anything, mock.Anything)
+	})
+}
+
+func TestFinancingUsecase_ListUserFacilities(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("success - applies default paging and sorting", func(t *testing.T) {
+		mockUserFacilityRepo := new(mocks.UserFacilityRepository)
+		uc := usecase.NewFinancingUsecase(usecase.FinancingDeps{
+			UserFacilityRepo: mockUserFacilityRepo,
+			SchedulePolicy:   domain.DefaultSchedulePolicy(),
+		})
+
+		expectedFilter := domain.UserFacilityFilter{UserID: 1, SortBy: domain.FacilitySortCreatedAt, SortDesc: true, Limit: 20}
+		facilities := []domain.UserFacility{{UserFacilityID: 2, UserID: 1}, {UserFacilityID: 1, UserID: 1}}
+		mockUserFacilityRepo.On("ListByUser", ctx, expectedFilter).Return(facilities, int64(2), nil).Once()
+
+		res, err := uc.ListUserFacilities(ctx, 1, dto.ListFacilitiesRequest{})
+
+		assert.NoError(t, err)
+		assert.Len(t, res.Facilities, 2)
+		assert.Equal(t, 1, res.Page)
+		assert.Equal(t, 20, res.PageSize)
+		assert.Equal(t, int64(2), res.Total)
+		assert.Equal(t, 1, res.TotalPages)
+		mockUserFacilityRepo.AssertExpectations(t)
+	})
+
+	t.Run("success - maps filters, sorting and page to the repository filter", func(t *testing.T) {
+		mockUserFacilityRepo := new(mocks.UserFacilityRepository)
+		uc := usecase.NewFinancingUsecase(usecase.FinancingDeps{
+			UserFacilityRepo: mockUserFacilityRepo,
+			SchedulePolicy:   domain.DefaultSchedulePolicy(),
+		})
+
+		from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+		to := time.Date(2025, 6, 30, 0, 0, 0, 0, time.UTC)
+		expectedFilter := domain.UserFacilityFilter{
+			UserID:        1,
+			Tenor:         12,
+			Status:        domain.FacilityStatusActive,
+			StartDateFrom: &from,
+			StartDateTo:   &to,
+			SortBy:        domain.FacilitySortAmount,
+			SortDesc:      false,
+			Limit:         100, // capped
+			Offset:        100,
+		}
+		mockUserFacilityRepo.On("ListByUser", ctx, expectedFilter).Return([]domain.UserFacility{}, int64(150), nil).Once()
+
+		res, err := uc.ListUserFacilities(ctx, 1, dto.ListFacilitiesRequest{
+			Page:          2,
+			PageSize:      500,
+			Tenor:         12,
+			Status:        "active",
+			StartDateFrom: "2025-01-01",
+			StartDateTo:   "2025-06-30",
+			SortBy:        "amount",
+			Order:         "ASC",
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, res.Page)
+		assert.Equal(t, 2, res.TotalPages)
+		assert.NotNil(t, res.Facilities)
+		mockUserFacilityRepo.AssertExpectations(t)
+	})
+
+	validationCases := []struct {
+		name    string
+		req     dto.ListFacilitiesRequest
+		wantErr string
+	}{
+		{name: "invalid sort_by", req: dto.ListFacilitiesRequest{SortBy: "name"}, wantErr: "invalid sort_by"},
+		{name: "invalid status", req: dto.ListFacilitiesRequest{Status: "OPEN"}, wantErr: "invalid status"},
+		{name: "invalid order", req: dto.ListFacilitiesRequest{Order: "up"}, wantErr: "invalid order"},
+		{name: "invalid start_date_from", req: dto.ListFacilitiesRequest{StartDateFrom: "01-01-2025"}, wantErr: "invalid start_date_from format"},
+		{name: "inverted date range", req: dto.ListFacilitiesRequest{StartDateFrom: "2025-06-01", StartDateTo: "2025-01-01"}, wantErr: "start_date_from must not be after start_date_to"},
+		{name: "negative page", req: dto.ListFacilitiesRequest{Page: -1}, wantErr: "page, page_size and tenor must not be negative"},
+	}
+	for _, tc := range validationCases {
+		t.Run("Failure - "+tc.name, func(t *testing.T) {
+			uc := usecase.NewFinancingUsecase(usecase.FinancingDeps{
+				SchedulePolicy: domain.DefaultSchedulePolicy(),
+			})
+
+			_, err := uc.ListUserFacilities(ctx, 1, tc.req)
+
+			assert.EqualError(t, err, tc.wantErr)
+			assert.ErrorIs(t, err, domain.ErrValidation)
+		})
+	}
+}
+
+// pricedTenorRepo returns a tenor repository where every tenor is active and priced at 20%.
+func pricedTenorRepo() *mocks.TenorRepository {
+	mockTenorRepo := new(mocks.TenorRepository)
+	mockTenorRepo.On("GetByValue", mock.Anything, mock.Anything).Return(func(_ context.Context, value int) (domain.Tenor, error) {
+		return domain.Tenor{TenorValue: value, IsActive: true}, nil
+	})
+	mockTenorRepo.On("GetMarginRate", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(domain.Rate(2000), nil)
+	return mockTenorRepo
+}
+
+// passthroughTxManager runs the transaction body with the caller's context, for tests that only
+// care about what happens inside it.
+func passthroughTxManager() *mocks.TransactionManager {
+	mockTxManager := new(mocks.TransactionManager)
+	mockTxManager.On("WithTransaction", mock.Anything, mock.Anything).Return(func(ctx context.Context, fn func(context.Context) error) error {
+		return fn(ctx)
+	})
+	return mockTxManager
+}
+
+// verifiedUserRepo returns a user repository where every user has passed KYC.
+func verifiedUserRepo() *mocks.UserRepository {
+	mockUserRepo := new(mocks.UserRepository)
+	verified := func(_ context.Context, id int64) (domain.User, error) {
+		return domain.User{UserID: id, KYCStatus: domain.KYCVerified}, nil
+	}
+	mockUserRepo.On("GetByID", mock.Anything, mock.Anything).Return(verified).Maybe()
+	mockUserRepo.On("GetByIDForUpdate", mock.Anything, mock.Anything).Return(verified).Maybe()
+	return mockUserRepo
+}
+
+// nonRevolvingLimitRepo returns a facility limit repository where every limit is non-revolving,
+// so repayments leave it untouched.
+func nonRevolvingLimitRepo() *mocks.UserFacilityLimitRepository {
+	mockFacilityLimitRepo := new(mocks.UserFacilityLimitRepository)
+	mockFacilityLimitRepo.On("GetByIDForUpdate", mock.Anything, mock.Anything).Return(func(_ context.Context, id int64) (domain.UserFacilityLimit, error) {
+		return domain.UserFacilityLimit{FacilityLimitID: id, LimitType: domain.LimitTypeNonRevolving}, nil
+	})
+	return mockFacilityLimitRepo
+}
+
+// flatProductRepo returns a product repository where every product uses the flat calculation.
+func flatProductRepo() *mocks.ProductRepository {
+	mockProductRepo := new(mocks.ProductRepository)
+	mockProductRepo.On("GetByCode", mock.Anything, mock.Anything).Return(func(_ context.Context, code string) (domain.Product, error) {
+		return domain.Product{ProductCode: code, CalculationMethod: domain.CalculationFlat}, nil
+	})
+	return mockProductRepo
+}
@@ -0,0 +1,165 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/elokanugrah/go-financing-btpns/internal/domain"
+	"github.com/elokanugrah/go-financing-btpns/internal/dto"
+)
+
+// HolidayCache is a domain.HolidayCalendar that serves the holidays table from memory, so due
+// date generation and aging do not query it for every date. It is loaded at startup and reloaded
+// whenever the holidays are changed through the HolidayUsecase.
+type HolidayCache struct {
+	repo     HolidayRepository
+	mu       sync.RWMutex
+	holidays domain.HolidayList
+}
+
+func NewHolidayCache(repo HolidayRepository) *HolidayCache {
+	return &HolidayCache{repo: repo, holidays: domain.HolidayList{}}
+}
+
+// Reload replaces the cached holidays with those in the repository.
+func (c *HolidayCache) Reload(ctx context.Context) error {
+	holidays, err := c.repo.List(ctx, 0)
+	if err != nil {
+		return err
+	}
+
+	list := make(domain.HolidayList, len(holidays))
+	for _, h := range holidays {
+		list[h.Date.Format("2006-01-02")] = h.Name
+	}
+
+	c.mu.Lock()
+	c.holidays = list
+	c.mu.Unlock()
+	return nil
+}
+
+func (c *HolidayCache) IsHoliday(date time.Time) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.holidays.IsHoliday(date)
+}
+
+type holidayUsecase struct {
+	holidayRepo HolidayRepository
+	calendar    *HolidayCache
+	txManager   TransactionManager
+}
+
+func NewHolidayUsecase(hr HolidayRepository, hc *HolidayCache, tm TransactionManager) HolidayUsecase {
+	return &holidayUsecase{
+		holidayRepo: hr,
+		calendar:    hc,
+		txManager:   tm,
+	}
+}
+
+func (u *holidayUsecase) ListHolidays(ctx context.Context, req dto.ListHolidaysRequest) (dto.HolidayListResponse, error) {
+	if req.Year < 0 {
+		return dto.HolidayListResponse{}, domain.ValidationError("INVALID_YEAR", "invalid year")
+	}
+
+	holidays, err := u.holidayRepo.List(ctx, req.Year)
+	if err != nil {
+		return dto.HolidayListResponse{}, err
+	}
+
+	resp := dto.HolidayListResponse{Holidays: make([]dto.HolidayResponse, 0, len(holidays))}
+	for _, h := range holidays {
+		resp.Holidays = append(resp.Holidays, toHolidayResponse(h))
+	}
+	return resp, nil
+}
+
+// SaveHoliday adds a holiday to the calendar, or renames it when its date is already a holiday.
+func (u *holidayUsecase) SaveHoliday(ctx context.Context, req dto.HolidayRequest) (dto.HolidayResponse, error) {
+	date, err := parseHolidayDate(req.Date)
+	if err != nil {
+		return dto.HolidayResponse{}, err
+	}
+	holiday := domain.Holiday{Date: date, Name: strings.TrimSpace(req.Name)}
+	if err := domain.ValidateHolidayName(holiday.Name); err != nil {
+		return dto.HolidayResponse{}, err
+	}
+
+	if err := u.holidayRepo.Save(ctx, []domain.Holiday{holiday}); err != nil {
+		return dto.HolidayResponse{}, err
+	}
+	if err := u.calendar.Reload(ctx); err != nil {
+		return dto.HolidayResponse{}, err
+	}
+	return toHolidayResponse(holiday), nil
+}
+
+// ImportHolidays adds every holiday of a CSV or iCalendar file in one transaction, renaming the
+// holidays already on the calendar.
+func (u *holidayUsecase) ImportHolidays(ctx context.Context, req dto.HolidayImportRequest) (dto.HolidayImportResponse, error) {
+	var list domain.HolidayList
+	var err error
+	switch req.Format {
+	case dto.HolidayFormatCSV:
+		list, err = domain.ParseHolidayCSV(req.File)
+	case dto.HolidayFormatICS:
+		list, err = domain.ParseHolidayICS(req.File)
+	default:
+		return dto.HolidayImportResponse{}, domain.ValidationError("UNSUPPORTED_HOLIDAY_FORMAT", "holidays can be imported from text/csv or text/calendar")
+	}
+	if err != nil {
+		return dto.HolidayImportResponse{}, domain.ValidationError("INVALID_HOLIDAY_FILE", err.Error())
+	}
+
+	holidays := list.Holidays()
+	if len(holidays) == 0 {
+		return dto.HolidayImportResponse{}, domain.ValidationError("INVALID_HOLIDAY_FILE", "no holidays in the file")
+	}
+	if err := list.Validate(); err != nil {
+		return dto.HolidayImportResponse{}, err
+	}
+
+	err = u.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		return u.holidayRepo.Save(txCtx, holidays)
+	})
+	if err != nil {
+		return dto.HolidayImportResponse{}, err
+	}
+	if err := u.calendar.Reload(ctx); err != nil {
+		return dto.HolidayImportResponse{}, err
+	}
+	return dto.HolidayImportResponse{Imported: len(holidays)}, nil
+}
+
+func (u *holidayUsecase) DeleteHoliday(ctx context.Context, value string) error {
+	date, err := parseHolidayDate(value)
+	if err != nil {
+		return err
+	}
+
+	err = u.holidayRepo.Delete(ctx, date)
+	if errors.Is(err, domain.ErrNotFound) {
+		return domain.NotFoundError("HOLIDAY_NOT_FOUND", "holiday not found")
+	}
+	if err != nil {
+		return err
+	}
+	return u.calendar.Reload(ctx)
+}
+
+func parseHolidayDate(value string) (time.Time, error) {
+	date, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return time.Time{}, domain.ValidationError("INVALID_DATE", "invalid date format")
+	}
+	return date, nil
+}
+
+func toHolidayResponse(h domain.Holiday) dto.HolidayResponse {
+	return dto.HolidayResponse{Date: h.Date.Format("2006-01-02"), Name: h.Name}
+}
@@ -0,0 +1,111 @@
+package usecase_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/elokanugrah/go-financing-btpns/internal/domain"
+	"github.com/elokanugrah/go-financing-btpns/internal/dto"
+	"github.com/elokanugrah/go-financing-btpns/internal/usecase"
+	"github.com/elokanugrah/go-financing-btpns/internal/usecase/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestHolidayCache(t *testing.T) {
+	ctx := context.Background()
+	independenceDay := time.Date(2025, 8, 17, 0, 0, 0, 0, time.UTC)
+
+	mockHolidayRepo := new(mocks.HolidayRepository)
+	mockHolidayRepo.On("List", ctx, 0).Return([]domain.Holiday{{Date: independenceDay, Name: "Hari Kemerdekaan"}}, nil).Once()
+	cache := usecase.NewHolidayCache(mockHolidayRepo)
+
+	assert.False(t, cache.IsHoliday(independenceDay))
+	assert.NoError(t, cache.Reload(ctx))
+	assert.True(t, cache.IsHoliday(independenceDay))
+	assert.False(t, cache.IsHoliday(independenceDay.AddDate(0, 0, 1)))
+	mockHolidayRepo.AssertExpectations(t)
+}
+
+func TestHolidayUsecase(t *testing.T) {
+	ctx := context.Background()
+	christmas := time.Date(2025, 12, 25, 0, 0, 0, 0, time.UTC)
+
+	t.Run("success - saves a holiday and reloads the calendar", func(t *testing.T) {
+		mockHolidayRepo := new(mocks.HolidayRepository)
+		cache := usecase.NewHolidayCache(mockHolidayRepo)
+		uc := usecase.NewHolidayUsecase(mockHolidayRepo, cache, nil)
+
+		mockHolidayRepo.On("Save", ctx, []domain.Holiday{{Date: christmas, Name: "Hari Raya Natal"}}).Return(nil).Once()
+		mockHolidayRepo.On("List", ctx, 0).Return([]domain.Holiday{{Date: christmas, Name: "Hari Raya Natal"}}, nil).Once()
+
+		resp, err := uc.SaveHoliday(ctx, dto.HolidayRequest{Date: "2025-12-25", Name: " Hari Raya Natal "})
+
+		assert.NoError(t, err)
+		assert.Equal(t, dto.HolidayResponse{Date: "2025-12-25", Name: "Hari Raya Natal"}, resp)
+		assert.True(t, cache.IsHoliday(christmas))
+		mockHolidayRepo.AssertExpectations(t)
+	})
+
+	t.Run("Failure - invalid date or missing name", func(t *testing.T) {
+		uc := usecase.NewHolidayUsecase(nil, nil, nil)
+
+		_, err := uc.SaveHoliday(ctx, dto.HolidayRequest{Date: "25-12-2025", Name: "Hari Raya Natal"})
+		assert.ErrorIs(t, err, domain.ErrValidation)
+		assert.EqualError(t, err, "invalid date format")
+
+		_, err = uc.SaveHoliday(ctx, dto.HolidayRequest{Date: "2025-12-25"})
+		assert.ErrorIs(t, err, domain.ErrValidation)
+		assert.EqualError(t, err, "name is required")
+	})
+
+	t.Run("success - imports a CSV file in one transaction", func(t *testing.T) {
+		mockHolidayRepo := new(mocks.HolidayRepository)
+		mockTxManager := new(mocks.TransactionManager)
+		uc := usecase.NewHolidayUsecase(mockHolidayRepo, usecase.NewHolidayCache(mockHolidayRepo), mockTxManager)
+
+		mockTxManager.On("WithTransaction", ctx, mock.AnythingOfType("func(context.Context) error")).Return(nil).Run(func(args mock.Arguments) {
+			fn := args.Get(1).(func(txCtx context.Context) error)
+			mockHolidayRepo.On("Save", mock.Anything, mock.MatchedBy(func(holidays []domain.Holiday) bool {
+				return len(holidays) == 2 && holidays[0].Name == "Hari Kemerdekaan" && holidays[1].Date.Equal(christmas)
+			})).Return(nil).Once()
+			assert.NoError(t, fn(ctx))
+		}).Once()
+		mockHolidayRepo.On("List", ctx, 0).Return(nil, nil).Once()
+
+		file := strings.NewReader("date,name\n2025-12-25,Hari Raya Natal\n2025-08-17,Hari Kemerdekaan\n")
+		resp, err := uc.ImportHolidays(ctx, dto.HolidayImportRequest{Format: dto.HolidayFormatCSV, File: file})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, resp.Imported)
+		mockTxManager.AssertExpectations(t)
+		mockHolidayRepo.AssertExpectations(t)
+	})
+
+	t.Run("Failure - unsupported format or invalid file", func(t *testing.T) {
+		uc := usecase.NewHolidayUsecase(nil, nil, nil)
+
+		_, err := uc.ImportHolidays(ctx, dto.HolidayImportRequest{Format: "", File: strings.NewReader("")})
+		assert.ErrorIs(t, err, domain.ErrValidation)
+
+		_, err = uc.ImportHolidays(ctx, dto.HolidayImportRequest{Format: dto.HolidayFormatCSV, File: strings.NewReader("2025-12-25,\n")})
+		assert.ErrorIs(t, err, domain.ErrValidation)
+		assert.EqualError(t, err, "2025-12-25: name is required")
+	})
+
+	t.Run("Failure - deleting a date that is not a holiday", func(t *testing.T) {
+		mockHolidayRepo := new(mocks.HolidayRepository)
+		uc := usecase.NewHolidayUsecase(mockHolidayRepo, usecase.NewHolidayCache(mockHolidayRepo), nil)
+
+		mockHolidayRepo.On("Delete", ctx, christmas).Return(domain.ErrNotFound).Once()
+
+		err := uc.DeleteHoliday(ctx, "2025-12-25")
+
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+		assert.EqualError(t, err, "holiday not found")
+		mockHolidayRepo.AssertNotCalled(t, "List", mock.Anything, mock.Anything)
+	})
+}
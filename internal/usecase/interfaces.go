@@ -2,6 +2,7 @@ package usecase
 
 import (
 	"context"
+	"time"
 
 	"github.com/elokanugrah/go-financing-btpns/internal/domain"
 	"github.com/elokanugrah/go-financing-btpns/internal/dto"
@@ -10,26 +11,148 @@ import (
 //go:generate mockery --name TenorRepository --output ./mocks --case=snake
 type TenorRepository interface {
 	GetAll(ctx context.Context) ([]domain.Tenor, error)
+	GetByValue(ctx context.Context, tenorValue int) (domain.Tenor, error)
+	GetAllPriced(ctx context.Context, productCode string, at time.Time) ([]domain.Tenor, error)
+	GetMarginRate(ctx context.Context, productCode string, tenorValue int, at time.Time) (domain.Rate, error)
+}
+
+//go:generate mockery --name ProductRepository --output ./mocks --case=snake
+type ProductRepository interface {
+	GetByCode(ctx context.Context, productCode string) (domain.Product, error)
 }
 
 //go:generate mockery --name UserFacilityRepository --output ./mocks --case=snake
 type UserFacilityRepository interface {
 	Create(ctx context.Context, uf *domain.UserFacility) error
+	GetByID(ctx context.Context, id int64) (domain.UserFacility, error)
+	GetByIDForUpdate(ctx context.Context, id int64) (domain.UserFacility, error)
+	UpdateStatus(ctx context.Context, id int64, status domain.FacilityStatus) error
+	UpdateCreditBalance(ctx context.Context, id int64, creditBalance domain.Money) error
+	UpdateScheduleVersion(ctx context.Context, id int64, version int) error
+	UpdateTerms(ctx context.Context, uf domain.UserFacility) error
+	ListByUser(ctx context.Context, filter domain.UserFacilityFilter) ([]domain.UserFacility, int64, error)
+	ListByStatus(ctx context.Context, statuses []domain.FacilityStatus, afterID int64, limit int) ([]domain.UserFacility, error)
+	CountByUser(ctx context.Context, userID int64, statuses []domain.FacilityStatus) (int, error)
+}
+
+//go:generate mockery --name FacilityStatusHistoryRepository --output ./mocks --case=snake
+type FacilityStatusHistoryRepository interface {
+	Create(ctx context.Context, h *domain.FacilityStatusHistory) error
+	ListByFacilityID(ctx context.Context, userFacilityID int64) ([]domain.FacilityStatusHistory, error)
 }
 
 //go:generate mockery --name UserFacilityLimitRepository --output ./mocks --case=snake
 type UserFacilityLimitRepository interface {
+	Create(ctx context.Context, l *domain.UserFacilityLimit) error
 	GetByID(ctx context.Context, id int64) (domain.UserFacilityLimit, error)
+	GetByIDForUpdate(ctx context.Context, id int64) (domain.UserFacilityLimit, error)
+	UpdateUsedAmount(ctx context.Context, id int64, usedAmount domain.Money) error
+	UpdateLimit(ctx context.Context, id int64, limitAmount domain.Money, expiresAt *time.Time) error
+}
+
+//go:generate mockery --name FacilityLimitHistoryRepository --output ./mocks --case=snake
+type FacilityLimitHistoryRepository interface {
+	Create(ctx context.Context, h *domain.FacilityLimitHistory) error
+	ListByFacilityLimitID(ctx context.Context, facilityLimitID int64) ([]domain.FacilityLimitHistory, error)
 }
 
 //go:generate mockery --name UserFacilityDetailRepository --output ./mocks --case=snake
 type UserFacilityDetailRepository interface {
 	BulkCreate(ctx context.Context, details []domain.UserFacilityDetail) error
+	ListByFacilityID(ctx context.Context, userFacilityID int64) ([]domain.UserFacilityDetail, error)
+	UpdatePayment(ctx context.Context, d domain.UserFacilityDetail) error
+	Supersede(ctx context.Context, detailIDs []int64) error
+}
+
+//go:generate mockery --name PaymentRepository --output ./mocks --case=snake
+type PaymentRepository interface {
+	Create(ctx context.Context, p *domain.Payment) error
+	PaidByDetail(ctx context.Context, userFacilityID int64, asOf time.Time) (map[int64]domain.Money, error)
+}
+
+//go:generate mockery --name IdempotencyKeyRepository --output ./mocks --case=snake
+type IdempotencyKeyRepository interface {
+	Reserve(ctx context.Context, key domain.IdempotencyKey) (domain.IdempotencyKey, bool, error)
+	SaveResponse(ctx context.Context, userID int64, key string, response []byte) error
+}
+
+//go:generate mockery --name SettlementRepository --output ./mocks --case=snake
+type SettlementRepository interface {
+	Create(ctx context.Context, s *domain.Settlement) error
+}
+
+//go:generate mockery --name CollectibilityRepository --output ./mocks --case=snake
+type CollectibilityRepository interface {
+	Save(ctx context.Context, a domain.Aging) error
+}
+
+//go:generate mockery --name LateChargeRepository --output ./mocks --case=snake
+type LateChargeRepository interface {
+	ListByFacilityID(ctx context.Context, userFacilityID int64) ([]domain.LateCharge, error)
+	Save(ctx context.Context, c *domain.LateCharge) error
+	UpdatePayment(ctx context.Context, c domain.LateCharge) error
+}
+
+//go:generate mockery --name RestructuringRepository --output ./mocks --case=snake
+type RestructuringRepository interface {
+	Create(ctx context.Context, r *domain.Restructuring) error
+}
+
+//go:generate mockery --name HolidayRepository --output ./mocks --case=snake
+type HolidayRepository interface {
+	List(ctx context.Context, year int) ([]domain.Holiday, error)
+	Save(ctx context.Context, holidays []domain.Holiday) error
+	Delete(ctx context.Context, date time.Time) error
+}
+
+//go:generate mockery --name UserRepository --output ./mocks --case=snake
+type UserRepository interface {
+	Create(ctx context.Context, u *domain.User) error
+	GetByID(ctx context.Context, id int64) (domain.User, error)
+	GetByIDForUpdate(ctx context.Context, id int64) (domain.User, error)
+	Update(ctx context.Context, u *domain.User) error
+	Search(ctx context.Context, filter domain.UserFilter) ([]domain.User, int64, error)
 }
 
 type FinancingUsecase interface {
-	CalculateAllTenors(ctx context.Context, amount float64) (dto.CalculateResponse, error)
+	CalculateAllTenors(ctx context.Context, req dto.CalculateRequest) (dto.CalculateResponse, error)
 	SubmitFinancing(ctx context.Context, req dto.SubmitFinancingRequest) (dto.SubmitFinancingResponse, error)
+	CheckEligibility(ctx context.Context, req dto.SubmitFinancingRequest) (dto.EligibilityResponse, error)
+	GetFacility(ctx context.Context, id int64) (dto.FacilityResponse, error)
+	ListUserFacilities(ctx context.Context, userID int64, req dto.ListFacilitiesRequest) (dto.FacilityListResponse, error)
+	ApproveFacility(ctx context.Context, id int64, req dto.FacilityTransitionRequest) (dto.FacilityResponse, error)
+	RejectFacility(ctx context.Context, id int64, req dto.FacilityTransitionRequest) (dto.FacilityResponse, error)
+	DisburseFacility(ctx context.Context, id int64, req dto.FacilityTransitionRequest) (dto.FacilityResponse, error)
+	CancelFacility(ctx context.Context, id int64, req dto.FacilityTransitionRequest) (dto.FacilityResponse, error)
+	RecordPayment(ctx context.Context, facilityID int64, req dto.PaymentRequest) (dto.PaymentResponse, error)
+	QuoteSettlement(ctx context.Context, facilityID int64, req dto.SettlementQuoteRequest) (dto.SettlementQuoteResponse, error)
+	SettleFacility(ctx context.Context, facilityID int64, req dto.SettlementRequest) (dto.SettlementResponse, error)
+	RestructureFacility(ctx context.Context, facilityID int64, req dto.RestructureRequest) (dto.RestructureResponse, error)
+	GetCollectibility(ctx context.Context, facilityID int64, req dto.CollectibilityRequest) (dto.CollectibilityResponse, error)
+	RunCollectibilityBatch(ctx context.Context, req dto.CollectibilityBatchRequest) (dto.CollectibilityBatchResponse, error)
+}
+
+type HolidayUsecase interface {
+	ListHolidays(ctx context.Context, req dto.ListHolidaysRequest) (dto.HolidayListResponse, error)
+	SaveHoliday(ctx context.Context, req dto.HolidayRequest) (dto.HolidayResponse, error)
+	ImportHolidays(ctx context.Context, req dto.HolidayImportRequest) (dto.HolidayImportResponse, error)
+	DeleteHoliday(ctx context.Context, date string) error
+}
+
+type UserUsecase interface {
+	CreateUser(ctx context.Context, req dto.CreateUserRequest) (dto.UserResponse, error)
+	GetUser(ctx context.Context, id int64) (dto.UserResponse, error)
+	UpdateUser(ctx context.Context, id int64, req dto.UpdateUserRequest) (dto.UserResponse, error)
+	SearchUsers(ctx context.Context, req dto.SearchUsersRequest) (dto.UserListResponse, error)
+	UpdateKYC(ctx context.Context, id int64, req dto.KYCRequest) (dto.UserResponse, error)
+}
+
+type FacilityLimitUsecase interface {
+	GrantLimit(ctx context.Context, req dto.GrantLimitRequest) (dto.FacilityLimitResponse, error)
+	GetLimit(ctx context.Context, id int64) (dto.FacilityLimitResponse, error)
+	TopUpLimit(ctx context.Context, id int64, req dto.LimitAdjustmentRequest) (dto.FacilityLimitResponse, error)
+	ReduceLimit(ctx context.Context, id int64, req dto.LimitAdjustmentRequest) (dto.FacilityLimitResponse, error)
+	ExpireLimit(ctx context.Context, id int64, req dto.LimitExpiryRequest) (dto.FacilityLimitResponse, error)
 }
 
 //go:generate mockery --name TransactionManager --output ./mocks --case=snake
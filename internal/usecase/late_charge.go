@@ -0,0 +1,61 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/elokanugrah/go-financing-btpns/internal/domain"
+)
+
+// currentLateCharges returns the late charges of the facility brought up to date on asOf, with the
+// indexes of the ones that changed and still have to be stored.
+func (u *financingUsecase) currentLateCharges(ctx context.Context, facilityID int64, details []domain.UserFacilityDetail, asOf time.Time) ([]domain.LateCharge, []int, error) {
+	charges, err := u.lateChargeRepo.ListByFacilityID(ctx, facilityID)
+	if err != nil {
+		return nil, nil, err
+	}
+	charges, changed := u.lateChargePolicy.Assess(details, charges, asOf, u.schedulePolicy.DueDates)
+	return charges, changed, nil
+}
+
+// assessLateCharges brings the late charges of the facility up to date on asOf and stores them,
+// so every charge has an id before a payment is allocated to it.
+func (u *financingUsecase) assessLateCharges(txCtx context.Context, facilityID int64, details []domain.UserFacilityDetail, asOf time.Time) ([]domain.LateCharge, error) {
+	charges, changed, err := u.currentLateCharges(txCtx, facilityID, details, asOf)
+	if err != nil {
+		return nil, err
+	}
+	for _, i := range changed {
+		if err := u.lateChargeRepo.Save(txCtx, &charges[i]); err != nil {
+			return nil, err
+		}
+	}
+	return charges, nil
+}
+
+// updatePaidLateCharges stores the charges a payment was allocated to.
+func (u *financingUsecase) updatePaidLateCharges(txCtx context.Context, charges []domain.LateCharge, allocations []domain.PaymentAllocation) error {
+	paid := make(map[int64]bool, len(allocations))
+	for _, a := range allocations {
+		if a.LateChargeID != 0 {
+			paid[a.LateChargeID] = true
+		}
+	}
+	for _, c := range charges {
+		if !paid[c.LateChargeID] {
+			continue
+		}
+		if err := u.lateChargeRepo.UpdatePayment(txCtx, c); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func unpaidLateCharges(charges []domain.LateCharge) domain.Money {
+	var total domain.Money
+	for _, c := range charges {
+		total += c.Unpaid()
+	}
+	return total
+}
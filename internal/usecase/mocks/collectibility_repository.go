@@ -0,0 +1,47 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/elokanugrah/go-financing-btpns/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// CollectibilityRepository is an autogenerated mock type for the CollectibilityRepository type
+type CollectibilityRepository struct {
+	mock.Mock
+}
+
+// Save provides a mock function with given fields: ctx, a
+func (_m *CollectibilityRepository) Save(ctx context.Context, a domain.Aging) error {
+	ret := _m.Called(ctx, a)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Save")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Aging) error); ok {
+		r0 = rf(ctx, a)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewCollectibilityRepository creates a new instance of CollectibilityRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewCollectibilityRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *CollectibilityRepository {
+	mock := &CollectibilityRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
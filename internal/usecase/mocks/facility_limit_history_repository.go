@@ -0,0 +1,77 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/elokanugrah/go-financing-btpns/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// FacilityLimitHistoryRepository is an autogenerated mock type for the FacilityLimitHistoryRepository type
+type FacilityLimitHistoryRepository struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: ctx, h
+func (_m *FacilityLimitHistoryRepository) Create(ctx context.Context, h *domain.FacilityLimitHistory) error {
+	ret := _m.Called(ctx, h)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.FacilityLimitHistory) error); ok {
+		r0 = rf(ctx, h)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ListByFacilityLimitID provides a mock function with given fields: ctx, facilityLimitID
+func (_m *FacilityLimitHistoryRepository) ListByFacilityLimitID(ctx context.Context, facilityLimitID int64) ([]domain.FacilityLimitHistory, error) {
+	ret := _m.Called(ctx, facilityLimitID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListByFacilityLimitID")
+	}
+
+	var r0 []domain.FacilityLimitHistory
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) ([]domain.FacilityLimitHistory, error)); ok {
+		return rf(ctx, facilityLimitID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []domain.FacilityLimitHistory); ok {
+		r0 = rf(ctx, facilityLimitID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.FacilityLimitHistory)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, facilityLimitID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewFacilityLimitHistoryRepository creates a new instance of FacilityLimitHistoryRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewFacilityLimitHistoryRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *FacilityLimitHistoryRepository {
+	mock := &FacilityLimitHistoryRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
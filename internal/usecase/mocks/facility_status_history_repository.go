@@ -0,0 +1,77 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/elokanugrah/go-financing-btpns/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// FacilityStatusHistoryRepository is an autogenerated mock type for the FacilityStatusHistoryRepository type
+type FacilityStatusHistoryRepository struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: ctx, h
+func (_m *FacilityStatusHistoryRepository) Create(ctx context.Context, h *domain.FacilityStatusHistory) error {
+	ret := _m.Called(ctx, h)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.FacilityStatusHistory) error); ok {
+		r0 = rf(ctx, h)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ListByFacilityID provides a mock function with given fields: ctx, userFacilityID
+func (_m *FacilityStatusHistoryRepository) ListByFacilityID(ctx context.Context, userFacilityID int64) ([]domain.FacilityStatusHistory, error) {
+	ret := _m.Called(ctx, userFacilityID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListByFacilityID")
+	}
+
+	var r0 []domain.FacilityStatusHistory
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) ([]domain.FacilityStatusHistory, error)); ok {
+		return rf(ctx, userFacilityID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []domain.FacilityStatusHistory); ok {
+		r0 = rf(ctx, userFacilityID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.FacilityStatusHistory)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, userFacilityID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewFacilityStatusHistoryRepository creates a new instance of FacilityStatusHistoryRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewFacilityStatusHistoryRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *FacilityStatusHistoryRepository {
+	mock := &FacilityStatusHistoryRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,97 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/elokanugrah/go-financing-btpns/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// HolidayRepository is an autogenerated mock type for the HolidayRepository type
+type HolidayRepository struct {
+	mock.Mock
+}
+
+// Delete provides a mock function with given fields: ctx, date
+func (_m *HolidayRepository) Delete(ctx context.Context, date time.Time) error {
+	ret := _m.Called(ctx, date)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) error); ok {
+		r0 = rf(ctx, date)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// List provides a mock function with given fields: ctx, year
+func (_m *HolidayRepository) List(ctx context.Context, year int) ([]domain.Holiday, error) {
+	ret := _m.Called(ctx, year)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []domain.Holiday
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]domain.Holiday, error)); ok {
+		return rf(ctx, year)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) []domain.Holiday); ok {
+		r0 = rf(ctx, year)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Holiday)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, year)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Save provides a mock function with given fields: ctx, holidays
+func (_m *HolidayRepository) Save(ctx context.Context, holidays []domain.Holiday) error {
+	ret := _m.Called(ctx, holidays)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Save")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []domain.Holiday) error); ok {
+		r0 = rf(ctx, holidays)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewHolidayRepository creates a new instance of HolidayRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewHolidayRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *HolidayRepository {
+	mock := &HolidayRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,82 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/elokanugrah/go-financing-btpns/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// IdempotencyKeyRepository is an autogenerated mock type for the IdempotencyKeyRepository type
+type IdempotencyKeyRepository struct {
+	mock.Mock
+}
+
+// Reserve provides a mock function with given fields: ctx, key
+func (_m *IdempotencyKeyRepository) Reserve(ctx context.Context, key domain.IdempotencyKey) (domain.IdempotencyKey, bool, error) {
+	ret := _m.Called(ctx, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Reserve")
+	}
+
+	var r0 domain.IdempotencyKey
+	var r1 bool
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.IdempotencyKey) (domain.IdempotencyKey, bool, error)); ok {
+		return rf(ctx, key)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.IdempotencyKey) domain.IdempotencyKey); ok {
+		r0 = rf(ctx, key)
+	} else {
+		r0 = ret.Get(0).(domain.IdempotencyKey)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.IdempotencyKey) bool); ok {
+		r1 = rf(ctx, key)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, domain.IdempotencyKey) error); ok {
+		r2 = rf(ctx, key)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// SaveResponse provides a mock function with given fields: ctx, userID, key, response
+func (_m *IdempotencyKeyRepository) SaveResponse(ctx context.Context, userID int64, key string, response []byte) error {
+	ret := _m.Called(ctx, userID, key, response)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveResponse")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string, []byte) error); ok {
+		r0 = rf(ctx, userID, key, response)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewIdempotencyKeyRepository creates a new instance of IdempotencyKeyRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIdempotencyKeyRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IdempotencyKeyRepository {
+	mock := &IdempotencyKeyRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,95 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/elokanugrah/go-financing-btpns/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// LateChargeRepository is an autogenerated mock type for the LateChargeRepository type
+type LateChargeRepository struct {
+	mock.Mock
+}
+
+// ListByFacilityID provides a mock function with given fields: ctx, userFacilityID
+func (_m *LateChargeRepository) ListByFacilityID(ctx context.Context, userFacilityID int64) ([]domain.LateCharge, error) {
+	ret := _m.Called(ctx, userFacilityID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListByFacilityID")
+	}
+
+	var r0 []domain.LateCharge
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) ([]domain.LateCharge, error)); ok {
+		return rf(ctx, userFacilityID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []domain.LateCharge); ok {
+		r0 = rf(ctx, userFacilityID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.LateCharge)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, userFacilityID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Save provides a mock function with given fields: ctx, c
+func (_m *LateChargeRepository) Save(ctx context.Context, c *domain.LateCharge) error {
+	ret := _m.Called(ctx, c)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Save")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.LateCharge) error); ok {
+		r0 = rf(ctx, c)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdatePayment provides a mock function with given fields: ctx, c
+func (_m *LateChargeRepository) UpdatePayment(ctx context.Context, c domain.LateCharge) error {
+	ret := _m.Called(ctx, c)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdatePayment")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.LateCharge) error); ok {
+		r0 = rf(ctx, c)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewLateChargeRepository creates a new instance of LateChargeRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewLateChargeRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *LateChargeRepository {
+	mock := &LateChargeRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,79 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/elokanugrah/go-financing-btpns/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// PaymentRepository is an autogenerated mock type for the PaymentRepository type
+type PaymentRepository struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: ctx, p
+func (_m *PaymentRepository) Create(ctx context.Context, p *domain.Payment) error {
+	ret := _m.Called(ctx, p)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Payment) error); ok {
+		r0 = rf(ctx, p)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// PaidByDetail provides a mock function with given fields: ctx, userFacilityID, asOf
+func (_m *PaymentRepository) PaidByDetail(ctx context.Context, userFacilityID int64, asOf time.Time) (map[int64]domain.Money, error) {
+	ret := _m.Called(ctx, userFacilityID, asOf)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PaidByDetail")
+	}
+
+	var r0 map[int64]domain.Money
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, time.Time) (map[int64]domain.Money, error)); ok {
+		return rf(ctx, userFacilityID, asOf)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, time.Time) map[int64]domain.Money); ok {
+		r0 = rf(ctx, userFacilityID, asOf)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[int64]domain.Money)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, time.Time) error); ok {
+		r1 = rf(ctx, userFacilityID, asOf)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewPaymentRepository creates a new instance of PaymentRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewPaymentRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *PaymentRepository {
+	mock := &PaymentRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -7,6 +7,8 @@ import (
 
 	domain "github.com/elokanugrah/go-financing-btpns/internal/domain"
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
 )
 
 // TenorRepository is an autogenerated mock type for the TenorRepository type
@@ -44,6 +46,92 @@ func (_m *TenorRepository) GetAll(ctx context.Context) ([]domain.Tenor, error) {
 	return r0, r1
 }
 
+// GetAllPriced provides a mock function with given fields: ctx, productCode, at
+func (_m *TenorRepository) GetAllPriced(ctx context.Context, productCode string, at time.Time) ([]domain.Tenor, error) {
+	ret := _m.Called(ctx, productCode, at)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAllPriced")
+	}
+
+	var r0 []domain.Tenor
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) ([]domain.Tenor, error)); ok {
+		return rf(ctx, productCode, at)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) []domain.Tenor); ok {
+		r0 = rf(ctx, productCode, at)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Tenor)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Time) error); ok {
+		r1 = rf(ctx, productCode, at)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByValue provides a mock function with given fields: ctx, tenorValue
+func (_m *TenorRepository) GetByValue(ctx context.Context, tenorValue int) (domain.Tenor, error) {
+	ret := _m.Called(ctx, tenorValue)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByValue")
+	}
+
+	var r0 domain.Tenor
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (domain.Tenor, error)); ok {
+		return rf(ctx, tenorValue)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) domain.Tenor); ok {
+		r0 = rf(ctx, tenorValue)
+	} else {
+		r0 = ret.Get(0).(domain.Tenor)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, tenorValue)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetMarginRate provides a mock function with given fields: ctx, productCode, tenorValue, at
+func (_m *TenorRepository) GetMarginRate(ctx context.Context, productCode string, tenorValue int, at time.Time) (domain.Rate, error) {
+	ret := _m.Called(ctx, productCode, tenorValue, at)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMarginRate")
+	}
+
+	var r0 domain.Rate
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, time.Time) (domain.Rate, error)); ok {
+		return rf(ctx, productCode, tenorValue, at)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, time.Time) domain.Rate); ok {
+		r0 = rf(ctx, productCode, tenorValue, at)
+	} else {
+		r0 = ret.Get(0).(domain.Rate)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int, time.Time) error); ok {
+		r1 = rf(ctx, productCode, tenorValue, at)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewTenorRepository creates a new instance of TenorRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewTenorRepository(t interface {
@@ -32,6 +32,72 @@ func (_m *UserFacilityDetailRepository) BulkCreate(ctx context.Context, details
 	return r0
 }
 
+// ListByFacilityID provides a mock function with given fields: ctx, userFacilityID
+func (_m *UserFacilityDetailRepository) ListByFacilityID(ctx context.Context, userFacilityID int64) ([]domain.UserFacilityDetail, error) {
+	ret := _m.Called(ctx, userFacilityID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListByFacilityID")
+	}
+
+	var r0 []domain.UserFacilityDetail
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) ([]domain.UserFacilityDetail, error)); ok {
+		return rf(ctx, userFacilityID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []domain.UserFacilityDetail); ok {
+		r0 = rf(ctx, userFacilityID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.UserFacilityDetail)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, userFacilityID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Supersede provides a mock function with given fields: ctx, detailIDs
+func (_m *UserFacilityDetailRepository) Supersede(ctx context.Context, detailIDs []int64) error {
+	ret := _m.Called(ctx, detailIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Supersede")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []int64) error); ok {
+		r0 = rf(ctx, detailIDs)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdatePayment provides a mock function with given fields: ctx, d
+func (_m *UserFacilityDetailRepository) UpdatePayment(ctx context.Context, d domain.UserFacilityDetail) error {
+	ret := _m.Called(ctx, d)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdatePayment")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.UserFacilityDetail) error); ok {
+		r0 = rf(ctx, d)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewUserFacilityDetailRepository creates a new instance of UserFacilityDetailRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewUserFacilityDetailRepository(t interface {
@@ -7,6 +7,8 @@ import (
 
 	domain "github.com/elokanugrah/go-financing-btpns/internal/domain"
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
 )
 
 // UserFacilityLimitRepository is an autogenerated mock type for the UserFacilityLimitRepository type
@@ -14,6 +16,24 @@ type UserFacilityLimitRepository struct {
 	mock.Mock
 }
 
+// Create provides a mock function with given fields: ctx, l
+func (_m *UserFacilityLimitRepository) Create(ctx context.Context, l *domain.UserFacilityLimit) error {
+	ret := _m.Called(ctx, l)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.UserFacilityLimit) error); ok {
+		r0 = rf(ctx, l)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // GetByID provides a mock function with given fields: ctx, id
 func (_m *UserFacilityLimitRepository) GetByID(ctx context.Context, id int64) (domain.UserFacilityLimit, error) {
 	ret := _m.Called(ctx, id)
@@ -42,6 +62,70 @@ func (_m *UserFacilityLimitRepository) GetByID(ctx context.Context, id int64) (d
 	return r0, r1
 }
 
+// GetByIDForUpdate provides a mock function with given fields: ctx, id
+func (_m *UserFacilityLimitRepository) GetByIDForUpdate(ctx context.Context, id int64) (domain.UserFacilityLimit, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByIDForUpdate")
+	}
+
+	var r0 domain.UserFacilityLimit
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (domain.UserFacilityLimit, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) domain.UserFacilityLimit); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(domain.UserFacilityLimit)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateLimit provides a mock function with given fields: ctx, id, limitAmount, expiresAt
+func (_m *UserFacilityLimitRepository) UpdateLimit(ctx context.Context, id int64, limitAmount domain.Money, expiresAt *time.Time) error {
+	ret := _m.Called(ctx, id, limitAmount, expiresAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateLimit")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, domain.Money, *time.Time) error); ok {
+		r0 = rf(ctx, id, limitAmount, expiresAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateUsedAmount provides a mock function with given fields: ctx, id, usedAmount
+func (_m *UserFacilityLimitRepository) UpdateUsedAmount(ctx context.Context, id int64, usedAmount domain.Money) error {
+	ret := _m.Called(ctx, id, usedAmount)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateUsedAmount")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, domain.Money) error); ok {
+		r0 = rf(ctx, id, usedAmount)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewUserFacilityLimitRepository creates a new instance of UserFacilityLimitRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewUserFacilityLimitRepository(t interface {
@@ -14,6 +14,34 @@ type UserFacilityRepository struct {
 	mock.Mock
 }
 
+// CountByUser provides a mock function with given fields: ctx, userID, statuses
+func (_m *UserFacilityRepository) CountByUser(ctx context.Context, userID int64, statuses []domain.FacilityStatus) (int, error) {
+	ret := _m.Called(ctx, userID, statuses)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountByUser")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, []domain.FacilityStatus) (int, error)); ok {
+		return rf(ctx, userID, statuses)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, []domain.FacilityStatus) int); ok {
+		r0 = rf(ctx, userID, statuses)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, []domain.FacilityStatus) error); ok {
+		r1 = rf(ctx, userID, statuses)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Create provides a mock function with given fields: ctx, uf
 func (_m *UserFacilityRepository) Create(ctx context.Context, uf *domain.UserFacility) error {
 	ret := _m.Called(ctx, uf)
@@ -32,6 +60,201 @@ func (_m *UserFacilityRepository) Create(ctx context.Context, uf *domain.UserFac
 	return r0
 }
 
+// GetByID provides a mock function with given fields: ctx, id
+func (_m *UserFacilityRepository) GetByID(ctx context.Context, id int64) (domain.UserFacility, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByID")
+	}
+
+	var r0 domain.UserFacility
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (domain.UserFacility, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) domain.UserFacility); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(domain.UserFacility)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByIDForUpdate provides a mock function with given fields: ctx, id
+func (_m *UserFacilityRepository) GetByIDForUpdate(ctx context.Context, id int64) (domain.UserFacility, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByIDForUpdate")
+	}
+
+	var r0 domain.UserFacility
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (domain.UserFacility, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) domain.UserFacility); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(domain.UserFacility)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListByStatus provides a mock function with given fields: ctx, statuses, afterID, limit
+func (_m *UserFacilityRepository) ListByStatus(ctx context.Context, statuses []domain.FacilityStatus, afterID int64, limit int) ([]domain.UserFacility, error) {
+	ret := _m.Called(ctx, statuses, afterID, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListByStatus")
+	}
+
+	var r0 []domain.UserFacility
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []domain.FacilityStatus, int64, int) ([]domain.UserFacility, error)); ok {
+		return rf(ctx, statuses, afterID, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []domain.FacilityStatus, int64, int) []domain.UserFacility); ok {
+		r0 = rf(ctx, statuses, afterID, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.UserFacility)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []domain.FacilityStatus, int64, int) error); ok {
+		r1 = rf(ctx, statuses, afterID, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListByUser provides a mock function with given fields: ctx, filter
+func (_m *UserFacilityRepository) ListByUser(ctx context.Context, filter domain.UserFacilityFilter) ([]domain.UserFacility, int64, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListByUser")
+	}
+
+	var r0 []domain.UserFacility
+	var r1 int64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.UserFacilityFilter) ([]domain.UserFacility, int64, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.UserFacilityFilter) []domain.UserFacility); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.UserFacility)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.UserFacilityFilter) int64); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, domain.UserFacilityFilter) error); ok {
+		r2 = rf(ctx, filter)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// UpdateCreditBalance provides a mock function with given fields: ctx, id, creditBalance
+func (_m *UserFacilityRepository) UpdateCreditBalance(ctx context.Context, id int64, creditBalance domain.Money) error {
+	ret := _m.Called(ctx, id, creditBalance)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateCreditBalance")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, domain.Money) error); ok {
+		r0 = rf(ctx, id, creditBalance)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateScheduleVersion provides a mock function with given fields: ctx, id, version
+func (_m *UserFacilityRepository) UpdateScheduleVersion(ctx context.Context, id int64, version int) error {
+	ret := _m.Called(ctx, id, version)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateScheduleVersion")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int) error); ok {
+		r0 = rf(ctx, id, version)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateStatus provides a mock function with given fields: ctx, id, status
+func (_m *UserFacilityRepository) UpdateStatus(ctx context.Context, id int64, status domain.FacilityStatus) error {
+	ret := _m.Called(ctx, id, status)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateStatus")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, domain.FacilityStatus) error); ok {
+		r0 = rf(ctx, id, status)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateTerms provides a mock function with given fields: ctx, uf
+func (_m *UserFacilityRepository) UpdateTerms(ctx context.Context, uf domain.UserFacility) error {
+	ret := _m.Called(ctx, uf)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateTerms")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.UserFacility) error); ok {
+		r0 = rf(ctx, uf)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewUserFacilityRepository creates a new instance of UserFacilityRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewUserFacilityRepository(t interface {
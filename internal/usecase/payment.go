@@ -0,0 +1,228 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/elokanugrah/go-financing-btpns/internal/domain"
+	"github.com/elokanugrah/go-financing-btpns/internal/dto"
+)
+
+// RecordPayment stores a payment for the facility. Late charges are assessed as of the payment
+// date first, then the payment is allocated to the installments already due, the late charges and
+// the installments not due yet, oldest due date first. Anything left after everything is paid is
+// held as credit.
+func (u *financingUsecase) RecordPayment(ctx context.Context, facilityID int64, req dto.PaymentRequest) (dto.PaymentResponse, error) {
+	if req.Amount <= 0 {
+		return dto.PaymentResponse{}, errAmountNotPositive
+	}
+	paidAt, err := u.pastDate(req.PaidAt, "paid_at")
+	if err != nil {
+		return dto.PaymentResponse{}, err
+	}
+
+	var (
+		payment  domain.Payment
+		facility domain.UserFacility
+		touched  = map[int64]domain.UserFacilityDetail{}
+		charges  = map[int64]domain.LateCharge{}
+	)
+	err = u.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		f, err := u.lockRepayableFacility(txCtx, facilityID)
+		if err != nil {
+			return err
+		}
+
+		details, err := u.userFacilityDetailRepo.ListByFacilityID(txCtx, f.UserFacilityID)
+		if err != nil {
+			return err
+		}
+
+		lateCharges, err := u.assessLateCharges(txCtx, f.UserFacilityID, details, paidAt)
+		if err != nil {
+			return err
+		}
+
+		repaidBefore := repaidPrincipal(details)
+		allocations, credit := domain.AllocatePayment(details, lateCharges, req.Amount, paidAt, u.schedulePolicy.DueDates)
+		for _, a := range allocations {
+			if a.LateChargeID == 0 {
+				touched[a.DetailID] = domain.UserFacilityDetail{}
+			}
+		}
+		for _, d := range details {
+			if _, ok := touched[d.DetailID]; !ok {
+				continue
+			}
+			if err := u.userFacilityDetailRepo.UpdatePayment(txCtx, d); err != nil {
+				return err
+			}
+			touched[d.DetailID] = d
+		}
+		if err := u.restoreRevolvingLimit(txCtx, f.FacilityLimitID, repaidPrincipal(details)-repaidBefore); err != nil {
+			return err
+		}
+		if err := u.updatePaidLateCharges(txCtx, lateCharges, allocations); err != nil {
+			return err
+		}
+		for _, c := range lateCharges {
+			charges[c.LateChargeID] = c
+		}
+
+		payment = domain.Payment{
+			UserFacilityID: f.UserFacilityID,
+			Amount:         req.Amount,
+			CreditAmount:   credit,
+			Reference:      strings.TrimSpace(req.Reference),
+			PaidAt:         paidAt,
+			Allocations:    allocations,
+		}
+		if err := u.paymentRepo.Create(txCtx, &payment); err != nil {
+			return err
+		}
+
+		if credit > 0 {
+			f.CreditBalance += credit
+			if err := u.userFacilityRepo.UpdateCreditBalance(txCtx, f.UserFacilityID, f.CreditBalance); err != nil {
+				return err
+			}
+		}
+
+		reason := fmt.Sprintf("payment %d", payment.PaymentID)
+		if f.Status == domain.FacilityStatusDisbursed {
+			if err := u.changeStatus(txCtx, &f, domain.FacilityStatusActive, systemActor, reason); err != nil {
+				return err
+			}
+		}
+		if len(details) > 0 && allPaid(details) && unpaidLateCharges(lateCharges) == 0 {
+			if err := u.changeStatus(txCtx, &f, domain.FacilityStatusPaidOff, systemActor, reason); err != nil {
+				return err
+			}
+		}
+
+		facility = f
+		return nil
+	})
+	if err != nil {
+		return dto.PaymentResponse{}, err
+	}
+
+	items := make([]dto.PaymentAllocationItem, 0, len(payment.Allocations))
+	for _, a := range payment.Allocations {
+		if a.LateChargeID != 0 {
+			c := charges[a.LateChargeID]
+			items = append(items, dto.PaymentAllocationItem{
+				DetailID:         a.DetailID,
+				LateChargeID:     a.LateChargeID,
+				DueDate:          c.DueDate.Format("2006-01-02"),
+				Amount:           a.Amount,
+				LateChargeStatus: c.Status,
+				RemainingAmount:  c.Unpaid(),
+			})
+			continue
+		}
+		d := touched[a.DetailID]
+		items = append(items, dto.PaymentAllocationItem{
+			DetailID:          a.DetailID,
+			DueDate:           d.DueDate.Format("2006-01-02"),
+			Amount:            a.Amount,
+			InstallmentStatus: d.Status,
+			RemainingAmount:   d.Unpaid(),
+		})
+	}
+
+	return dto.PaymentResponse{
+		PaymentID:      payment.PaymentID,
+		UserFacilityID: facility.UserFacilityID,
+		Amount:         payment.Amount,
+		Reference:      payment.Reference,
+		PaidAt:         payment.PaidAt.Format("2006-01-02"),
+		Allocations:    items,
+		CreditAmount:   payment.CreditAmount,
+		CreditBalance:  facility.CreditBalance,
+		FacilityStatus: facility.Status,
+	}, nil
+}
+
+// lockRepayableFacility locks the facility so concurrent repayments are applied one after the other,
+// and checks that it is disbursed and not paid off yet.
+func (u *financingUsecase) lockRepayableFacility(txCtx context.Context, id int64) (domain.UserFacility, error) {
+	f, err := u.userFacilityRepo.GetByIDForUpdate(txCtx, id)
+	if errors.Is(err, domain.ErrNotFound) {
+		return f, errFacilityNotFound
+	}
+	if err != nil {
+		return f, err
+	}
+	if !isRepayable(f.Status) {
+		return f, domain.ConflictError("FACILITY_NOT_REPAYABLE", fmt.Sprintf("facility does not accept payments while %s", f.Status))
+	}
+	return f, nil
+}
+
+// restoreRevolvingLimit gives the principal a repayment covered back to the facility's limit when
+// the limit is revolving. The facility must already be locked, the limit is locked after it as
+// everywhere else.
+func (u *financingUsecase) restoreRevolvingLimit(txCtx context.Context, facilityLimitID int64, repaid domain.Money) error {
+	if repaid <= 0 {
+		return nil
+	}
+	limit, err := u.facilityLimitRepo.GetByIDForUpdate(txCtx, facilityLimitID)
+	if err != nil {
+		return err
+	}
+	if limit.LimitType != domain.LimitTypeRevolving {
+		return nil
+	}
+	return u.facilityLimitRepo.UpdateUsedAmount(txCtx, limit.FacilityLimitID, max(limit.UsedAmount-repaid, 0))
+}
+
+// repaidPrincipal returns the principal the installments no longer owe.
+func repaidPrincipal(details []domain.UserFacilityDetail) domain.Money {
+	var total domain.Money
+	for _, d := range details {
+		total += d.RepaidPrincipal()
+	}
+	return total
+}
+
+func isRepayable(status domain.FacilityStatus) bool {
+	return status == domain.FacilityStatusDisbursed || status == domain.FacilityStatusActive
+}
+
+// systemActor is recorded in the status history for transitions the service makes on its own.
+const systemActor = "system"
+
+func allPaid(details []domain.UserFacilityDetail) bool {
+	for _, d := range details {
+		if d.Unpaid() > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// pastDate parses an optional YYYY-MM-DD request field that defaults to today and must not be in the future.
+func (u *financingUsecase) pastDate(value, field string) (time.Time, error) {
+	today := truncateToDate(u.now())
+	if value == "" {
+		return today, nil
+	}
+	date, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return time.Time{}, domain.ValidationError("INVALID_"+strings.ToUpper(field), fmt.Sprintf("invalid %s format", field))
+	}
+	if date.After(today) {
+		return time.Time{}, domain.ValidationError("INVALID_"+strings.ToUpper(field), fmt.Sprintf("%s must not be in the future", field))
+	}
+	return date, nil
+}
+
+// truncateToDate drops the time of day, payments and due dates are compared by calendar date.
+func truncateToDate(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
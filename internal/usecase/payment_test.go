@@ -0,0 +1,303 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/elokanugrah/go-financing-btpns/internal/domain"
+	"github.com/elokanugrah/go-financing-btpns/internal/dto"
+	"github.com/elokanugrah/go-financing-btpns/internal/usecase"
+	"github.com/elokanugrah/go-financing-btpns/internal/usecase/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestFinancingUsecase_RecordPayment(t *testing.T) {
+	ctx := context.Background()
+	start := time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC)
+
+	schedule := func() []domain.UserFacilityDetail {
+		return []domain.UserFacilityDetail{
+			{DetailID: 1, UserFacilityID: 100, DueDate: start.AddDate(0, 1, 0), InstallmentAmount: domain.NewMoney(1000000), Status: domain.InstallmentUnpaid},
+			{DetailID: 2, UserFacilityID: 100, DueDate: start.AddDate(0, 2, 0), InstallmentAmount: domain.NewMoney(1000000), Status: domain.InstallmentUnpaid},
+		}
+	}
+
+	t.Run("success - first payment is allocated oldest first and activates the facility", func(t *testing.T) {
+		mockUserFacilityRepo := new(mocks.UserFacilityRepository)
+		mockUserFacilityDetailRepo := new(mocks.UserFacilityDetailRepository)
+		mockLateChargeRepo := new(mocks.LateChargeRepository)
+		mockStatusHistoryRepo := new(mocks.FacilityStatusHistoryRepository)
+		mockPaymentRepo := new(mocks.PaymentRepository)
+		mockTxManager := new(mocks.TransactionManager)
+		uc := usecase.NewFinancingUsecase(usecase.FinancingDeps{
+			UserFacilityDetailRepo: mockUserFacilityDetailRepo,
+			UserFacilityRepo:       mockUserFacilityRepo,
+			StatusHistoryRepo:      mockStatusHistoryRepo,
+			PaymentRepo:            mockPaymentRepo,
+			LateChargeRepo:         mockLateChargeRepo,
+			TxManager:              mockTxManager,
+			SchedulePolicy:         domain.DefaultSchedulePolicy(),
+		})
+
+		facility := domain.UserFacility{UserFacilityID: 100, Status: domain.FacilityStatusDisbursed}
+
+		mockTxManager.On("WithTransaction", ctx, mock.AnythingOfType("func(context.Context) error")).Return(nil).Run(func(args mock.Arguments) {
+			fn := args.Get(1).(func(txCtx context.Context) error)
+			mockUserFacilityRepo.On("GetByIDForUpdate", mock.Anything, int64(100)).Return(facility, nil).Once()
+			mockUserFacilityDetailRepo.On("ListByFacilityID", mock.Anything, int64(100)).Return(schedule(), nil).Once()
+			mockLateChargeRepo.On("ListByFacilityID", mock.Anything, int64(100)).Return(nil, nil).Once()
+			mockUserFacilityDetailRepo.On("UpdatePayment", mock.Anything, mock.MatchedBy(func(d domain.UserFacilityDetail) bool {
+				return d.DetailID == 1 && d.Status == domain.InstallmentPaid
+			})).Return(nil).Once()
+			mockUserFacilityDetailRepo.On("UpdatePayment", mock.Anything, mock.MatchedBy(func(d domain.UserFacilityDetail) bool {
+				return d.DetailID == 2 && d.Status == domain.InstallmentPartiallyPaid && d.PaidAmount == domain.NewMoney(500000)
+			})).Return(nil).Once()
+			mockPaymentRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.Payment")).Return(nil).Run(func(args mock.Arguments) {
+				p := args.Get(1).(*domain.Payment)
+				assert.Len(t, p.Allocations, 2)
+				assert.Equal(t, "TRX-1", p.Reference)
+				p.PaymentID = 7
+			}).Once()
+			mockUserFacilityRepo.On("UpdateStatus", mock.Anything, int64(100), domain.FacilityStatusActive).Return(nil).Once()
+			mockStatusHistoryRepo.On("Create", mock.Anything, mock.MatchedBy(func(h *domain.FacilityStatusHistory) bool {
+				return h.ToStatus == domain.FacilityStatusActive && h.Actor == "system" && h.Reason == "payment 7"
+			})).Return(nil).Once()
+			assert.NoError(t, fn(ctx))
+		}).Once()
+
+		res, err := uc.RecordPayment(ctx, 100, dto.PaymentRequest{Amount: domain.NewMoney(1500000), PaidAt: "2025-02-12", Reference: "TRX-1"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(7), res.PaymentID)
+		assert.Equal(t, domain.FacilityStatusActive, res.FacilityStatus)
+		assert.Len(t, res.Allocations, 2)
+		assert.Equal(t, domain.NewMoney(500000), res.Allocations[1].RemainingAmount)
+		assert.Equal(t, domain.Money(0), res.CreditAmount)
+		mockUserFacilityDetailRepo.AssertExpectations(t)
+		mockUserFacilityRepo.AssertNotCalled(t, "UpdateCreditBalance", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("success - overpayment pays off the facility and is held as credit", func(t *testing.T) {
+		mockUserFacilityRepo := new(mocks.UserFacilityRepository)
+		mockUserFacilityDetailRepo := new(mocks.UserFacilityDetailRepository)
+		mockLateChargeRepo := new(mocks.LateChargeRepository)
+		mockStatusHistoryRepo := new(mocks.FacilityStatusHistoryRepository)
+		mockPaymentRepo := new(mocks.PaymentRepository)
+		mockTxManager := new(mocks.TransactionManager)
+		uc := usecase.NewFinancingUsecase(usecase.FinancingDeps{
+			UserFacilityDetailRepo: mockUserFacilityDetailRepo,
+			UserFacilityRepo:       mockUserFacilityRepo,
+			StatusHistoryRepo:      mockStatusHistoryRepo,
+			PaymentRepo:            mockPaymentRepo,
+			LateChargeRepo:         mockLateChargeRepo,
+			TxManager:              mockTxManager,
+			SchedulePolicy:         domain.DefaultSchedulePolicy(),
+		})
+
+		facility := domain.UserFacility{UserFacilityID: 100, Status: domain.FacilityStatusActive, CreditBalance: domain.NewMoney(1000)}
+
+		mockTxManager.On("WithTransaction", ctx, mock.AnythingOfType("func(context.Context) error")).Return(nil).Run(func(args mock.Arguments) {
+			fn := args.Get(1).(func(txCtx context.Context) error)
+			mockUserFacilityRepo.On("GetByIDForUpdate", mock.Anything, int64(100)).Return(facility, nil).Once()
+			mockUserFacilityDetailRepo.On("ListByFacilityID", mock.Anything, int64(100)).Return(schedule(), nil).Once()
+			mockLateChargeRepo.On("ListByFacilityID", mock.Anything, int64(100)).Return(nil, nil).Once()
+			mockUserFacilityDetailRepo.On("UpdatePayment", mock.Anything, mock.AnythingOfType("domain.UserFacilityDetail")).Return(nil).Twice()
+			mockPaymentRepo.On("Create", mock.Anything, mock.MatchedBy(func(p *domain.Payment) bool {
+				return p.CreditAmount == domain.NewMoney(250000)
+			})).Return(nil).Once()
+			mockUserFacilityRepo.On("UpdateCreditBalance", mock.Anything, int64(100), domain.NewMoney(251000)).Return(nil).Once()
+			mockUserFacilityRepo.On("UpdateStatus", mock.Anything, int64(100), domain.FacilityStatusPaidOff).Return(nil).Once()
+			mockStatusHistoryRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.FacilityStatusHistory")).Return(nil).Once()
+			assert.NoError(t, fn(ctx))
+		}).Once()
+
+		res, err := uc.RecordPayment(ctx, 100, dto.PaymentRequest{Amount: domain.NewMoney(2250000), PaidAt: "2025-03-01"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, domain.FacilityStatusPaidOff, res.FacilityStatus)
+		assert.Equal(t, domain.NewMoney(250000), res.CreditAmount)
+		assert.Equal(t, domain.NewMoney(251000), res.CreditBalance)
+		mockUserFacilityRepo.AssertExpectations(t)
+	})
+
+	t.Run("success - late payment is charged and pays the charge before the next installment", func(t *testing.T) {
+		mockUserFacilityRepo := new(mocks.UserFacilityRepository)
+		mockUserFacilityDetailRepo := new(mocks.UserFacilityDetailRepository)
+		mockLateChargeRepo := new(mocks.LateChargeRepository)
+		mockPaymentRepo := new(mocks.PaymentRepository)
+		mockTxManager := new(mocks.TransactionManager)
+		policy := domain.LateChargePolicy{GraceDays: 3, DailyAmount: domain.NewMoney(1000)}
+		uc := usecase.NewFinancingUsecase(usecase.FinancingDeps{
+			UserFacilityDetailRepo: mockUserFacilityDetailRepo,
+			UserFacilityRepo:       mockUserFacilityRepo,
+			PaymentRepo:            mockPaymentRepo,
+			LateChargeRepo:         mockLateChargeRepo,
+			TxManager:              mockTxManager,
+			SchedulePolicy:         domain.DefaultSchedulePolicy(),
+			LateChargePolicy:       policy,
+		})
+
+		facility := domain.UserFacility{UserFacilityID: 100, Status: domain.FacilityStatusActive}
+
+		mockTxManager.On("WithTransaction", ctx, mock.AnythingOfType("func(context.Context) error")).Return(nil).Run(func(args mock.Arguments) {
+			fn := args.Get(1).(func(txCtx context.Context) error)
+			mockUserFacilityRepo.On("GetByIDForUpdate", mock.Anything, int64(100)).Return(facility, nil).Once()
+			mockUserFacilityDetailRepo.On("ListByFacilityID", mock.Anything, int64(100)).Return(schedule(), nil).Once()
+			mockLateChargeRepo.On("ListByFacilityID", mock.Anything, int64(100)).Return(nil, nil).Once()
+			// 10 days late, 7 of them after the grace days
+			mockLateChargeRepo.On("Save", mock.Anything, mock.MatchedBy(func(c *domain.LateCharge) bool {
+				return c.DetailID == 1 && c.DaysLate == 10 && c.Amount == domain.NewMoney(7000)
+			})).Return(nil).Run(func(args mock.Arguments) {
+				args.Get(1).(*domain.LateCharge).LateChargeID = 9
+			}).Once()
+			mockUserFacilityDetailRepo.On("UpdatePayment", mock.Anything, mock.AnythingOfType("domain.UserFacilityDetail")).Return(nil).Twice()
+			mockLateChargeRepo.On("UpdatePayment", mock.Anything, mock.MatchedBy(func(c domain.LateCharge) bool {
+				return c.LateChargeID == 9 && c.Status == domain.InstallmentPaid
+			})).Return(nil).Once()
+			mockPaymentRepo.On("Create", mock.Anything, mock.MatchedBy(func(p *domain.Payment) bool {
+				return len(p.Allocations) == 3 && p.Allocations[1].LateChargeID == 9
+			})).Return(nil).Once()
+			assert.NoError(t, fn(ctx))
+		}).Once()
+
+		res, err := uc.RecordPayment(ctx, 100, dto.PaymentRequest{Amount: domain.NewMoney(1107000), PaidAt: "2025-02-20"})
+
+		assert.NoError(t, err)
+		assert.Len(t, res.Allocations, 3)
+		assert.Equal(t, int64(9), res.Allocations[1].LateChargeID)
+		assert.Equal(t, domain.NewMoney(7000), res.Allocations[1].Amount)
+		assert.Equal(t, domain.InstallmentPaid, res.Allocations[1].LateChargeStatus)
+		assert.Equal(t, domain.NewMoney(100000), res.Allocations[2].Amount)
+		assert.Equal(t, domain.FacilityStatusActive, res.FacilityStatus)
+		mockLateChargeRepo.AssertExpectations(t)
+	})
+
+	t.Run("success - repaid principal is given back to a revolving limit", func(t *testing.T) {
+		mockUserFacilityRepo := new(mocks.UserFacilityRepository)
+		mockUserFacilityDetailRepo := new(mocks.UserFacilityDetailRepository)
+		mockLateChargeRepo := new(mocks.LateChargeRepository)
+		mockPaymentRepo := new(mocks.PaymentRepository)
+		mockFacilityLimitRepo := new(mocks.UserFacilityLimitRepository)
+		mockTxManager := new(mocks.TransactionManager)
+		uc := usecase.NewFinancingUsecase(usecase.FinancingDeps{
+			UserFacilityDetailRepo: mockUserFacilityDetailRepo,
+			UserFacilityRepo:       mockUserFacilityRepo,
+			PaymentRepo:            mockPaymentRepo,
+			LateChargeRepo:         mockLateChargeRepo,
+			FacilityLimitRepo:      mockFacilityLimitRepo,
+			TxManager:              mockTxManager,
+			SchedulePolicy:         domain.DefaultSchedulePolicy(),
+		})
+
+		facility := domain.UserFacility{UserFacilityID: 100, FacilityLimitID: 10, Status: domain.FacilityStatusActive}
+		details := schedule()
+		for i := range details {
+			details[i].PrincipalAmount = domain.NewMoney(800000)
+			details[i].MarginAmount = domain.NewMoney(200000)
+		}
+		limit := domain.UserFacilityLimit{FacilityLimitID: 10, LimitType: domain.LimitTypeRevolving, LimitAmount: domain.NewMoney(10000000), UsedAmount: domain.NewMoney(5000000)}
+
+		mockTxManager.On("WithTransaction", ctx, mock.AnythingOfType("func(context.Context) error")).Return(nil).Run(func(args mock.Arguments) {
+			fn := args.Get(1).(func(txCtx context.Context) error)
+			mockUserFacilityRepo.On("GetByIDForUpdate", mock.Anything, int64(100)).Return(facility, nil).Once()
+			mockUserFacilityDetailRepo.On("ListByFacilityID", mock.Anything, int64(100)).Return(details, nil).Once()
+			mockLateChargeRepo.On("ListByFacilityID", mock.Anything, int64(100)).Return(nil, nil).Once()
+			mockUserFacilityDetailRepo.On("UpdatePayment", mock.Anything, mock.Anything).Return(nil).Twice()
+			// All 800rb principal of the first installment and 300rb of the second, after its 200rb margin
+			mockFacilityLimitRepo.On("GetByIDForUpdate", mock.Anything, int64(10)).Return(limit, nil).Once()
+			mockFacilityLimitRepo.On("UpdateUsedAmount", mock.Anything, int64(10), domain.NewMoney(3900000)).Return(nil).Once()
+			mockPaymentRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.Payment")).Return(nil).Once()
+			assert.NoError(t, fn(ctx))
+		}).Once()
+
+		_, err := uc.RecordPayment(ctx, 100, dto.PaymentRequest{Amount: domain.NewMoney(1500000), PaidAt: "2025-02-10"})
+
+		assert.NoError(t, err)
+		mockFacilityLimitRepo.AssertExpectations(t)
+	})
+
+	t.Run("success - non-revolving limit is not given back", func(t *testing.T) {
+		mockUserFacilityRepo := new(mocks.UserFacilityRepository)
+		mockUserFacilityDetailRepo := new(mocks.UserFacilityDetailRepository)
+		mockLateChargeRepo := new(mocks.LateChargeRepository)
+		mockPaymentRepo := new(mocks.PaymentRepository)
+		mockFacilityLimitRepo := nonRevolvingLimitRepo()
+		mockTxManager := new(mocks.TransactionManager)
+		uc := usecase.NewFinancingUsecase(usecase.FinancingDeps{
+			UserFacilityDetailRepo: mockUserFacilityDetailRepo,
+			UserFacilityRepo:       mockUserFacilityRepo,
+			PaymentRepo:            mockPaymentRepo,
+			LateChargeRepo:         mockLateChargeRepo,
+			FacilityLimitRepo:      mockFacilityLimitRepo,
+			TxManager:              mockTxManager,
+			SchedulePolicy:         domain.DefaultSchedulePolicy(),
+		})
+
+		facility := domain.UserFacility{UserFacilityID: 100, FacilityLimitID: 10, Status: domain.FacilityStatusActive}
+		details := schedule()
+		details[0].PrincipalAmount = domain.NewMoney(800000)
+		details[0].MarginAmount = domain.NewMoney(200000)
+
+		mockTxManager.On("WithTransaction", ctx, mock.AnythingOfType("func(context.Context) error")).Return(nil).Run(func(args mock.Arguments) {
+			fn := args.Get(1).(func(txCtx context.Context) error)
+			mockUserFacilityRepo.On("GetByIDForUpdate", mock.Anything, int64(100)).Return(facility, nil).Once()
+			mockUserFacilityDetailRepo.On("ListByFacilityID", mock.Anything, int64(100)).Return(details, nil).Once()
+			mockLateChargeRepo.On("ListByFacilityID", mock.Anything, int64(100)).Return(nil, nil).Once()
+			mockUserFacilityDetailRepo.On("UpdatePayment", mock.Anything, mock.Anything).Return(nil).Once()
+			mockPaymentRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.Payment")).Return(nil).Once()
+			assert.NoError(t, fn(ctx))
+		}).Once()
+
+		_, err := uc.RecordPayment(ctx, 100, dto.PaymentRequest{Amount: domain.NewMoney(1000000), PaidAt: "2025-02-10"})
+
+		assert.NoError(t, err)
+		mockFacilityLimitRepo.AssertNotCalled(t, "UpdateUsedAmount", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Failure - facility not disbursed yet", func(t *testing.T) {
+		mockUserFacilityRepo := new(mocks.UserFacilityRepository)
+		mockTxManager := new(mocks.TransactionManager)
+		uc := usecase.NewFinancingUsecase(usecase.FinancingDeps{
+			UserFacilityRepo: mockUserFacilityRepo,
+			TxManager:        mockTxManager,
+			SchedulePolicy:   domain.DefaultSchedulePolicy(),
+		})
+
+		mockTxManager.On("WithTransaction", ctx, mock.AnythingOfType("func(context.Context) error")).Return(domain.ErrConflict).Run(func(args mock.Arguments) {
+			fn := args.Get(1).(func(txCtx context.Context) error)
+			mockUserFacilityRepo.On("GetByIDForUpdate", mock.Anything, int64(100)).Return(domain.UserFacility{UserFacilityID: 100, Status: domain.FacilityStatusApproved}, nil).Once()
+			err := fn(ctx)
+			assert.EqualError(t, err, "facility does not accept payments while APPROVED")
+			assert.ErrorIs(t, err, domain.ErrConflict)
+		}).Once()
+
+		_, err := uc.RecordPayment(ctx, 100, dto.PaymentRequest{Amount: domain.NewMoney(1000)})
+
+		assert.ErrorIs(t, err, domain.ErrConflict)
+	})
+
+	validationCases := []struct {
+		name    string
+		req     dto.PaymentRequest
+		wantErr string
+	}{
+		{name: "amount not positive", req: dto.PaymentRequest{Amount: 0}, wantErr: "amount must be greater than 0"},
+		{name: "invalid paid_at", req: dto.PaymentRequest{Amount: domain.NewMoney(1000), PaidAt: "10-02-2025"}, wantErr: "invalid paid_at format"},
+		{name: "paid_at in the future", req: dto.PaymentRequest{Amount: domain.NewMoney(1000), PaidAt: time.Now().AddDate(0, 0, 2).Format("2006-01-02")}, wantErr: "paid_at must not be in the future"},
+	}
+	for _, tc := range validationCases {
+		t.Run("Failure - "+tc.name, func(t *testing.T) {
+			uc := usecase.NewFinancingUsecase(usecase.FinancingDeps{
+				SchedulePolicy: domain.DefaultSchedulePolicy(),
+			})
+
+			_, err := uc.RecordPayment(ctx, 100, tc.req)
+
+			assert.EqualError(t, err, tc.wantErr)
+			assert.ErrorIs(t, err, domain.ErrValidation)
+		})
+	}
+}
@@ -0,0 +1,128 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/elokanugrah/go-financing-btpns/internal/domain"
+	"github.com/elokanugrah/go-financing-btpns/internal/dto"
+)
+
+// RestructureFacility reschedules the unpaid part of a facility. The outstanding principal and
+// margin are spread over the new tenor from the new start date without adding margin. Paid
+// installments stay in the schedule, the unpaid ones are superseded and kept for audit under the
+// previous schedule version.
+func (u *financingUsecase) RestructureFacility(ctx context.Context, facilityID int64, req dto.RestructureRequest) (dto.RestructureResponse, error) {
+	actor := strings.TrimSpace(req.Actor)
+	if actor == "" {
+		return dto.RestructureResponse{}, domain.ValidationError("ACTOR_REQUIRED", "actor is required")
+	}
+	reason := strings.TrimSpace(req.Reason)
+	if reason == "" {
+		return dto.RestructureResponse{}, domain.ValidationError("REASON_REQUIRED", "reason is required")
+	}
+	startDate, err := time.Parse("2006-01-02", req.StartDate)
+	if err != nil {
+		return dto.RestructureResponse{}, domain.ValidationError("INVALID_START_DATE", "invalid start_date format")
+	}
+	if startDate.Before(truncateToDate(u.now())) {
+		return dto.RestructureResponse{}, domain.ValidationError("INVALID_START_DATE", "start_date must not be in the past")
+	}
+
+	tenor, err := u.tenorRepo.GetByValue(ctx, req.Tenor)
+	if errors.Is(err, domain.ErrNotFound) || (err == nil && !tenor.IsActive) {
+		return dto.RestructureResponse{}, domain.ValidationError("INVALID_TENOR", "invalid tenor")
+	}
+	if err != nil {
+		return dto.RestructureResponse{}, err
+	}
+
+	var (
+		restructuring domain.Restructuring
+		schedule      []domain.UserFacilityDetail
+	)
+	err = u.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		f, err := u.lockRepayableFacility(txCtx, facilityID)
+		if err != nil {
+			return err
+		}
+
+		details, err := u.userFacilityDetailRepo.ListByFacilityID(txCtx, f.UserFacilityID)
+		if err != nil {
+			return err
+		}
+		principal, margin := domain.Outstanding(details)
+		if principal+margin <= 0 {
+			return domain.ConflictError("NOTHING_TO_RESTRUCTURE", "facility has no unpaid installments")
+		}
+
+		var superseded []int64
+		for _, d := range details {
+			if d.Unpaid() > 0 {
+				superseded = append(superseded, d.DetailID)
+			}
+		}
+		if err := u.userFacilityDetailRepo.Supersede(txCtx, superseded); err != nil {
+			return err
+		}
+
+		fromVersion := f.ScheduleVersion
+		f.ScheduleVersion++
+		if err := u.userFacilityRepo.UpdateScheduleVersion(txCtx, f.UserFacilityID, f.ScheduleVersion); err != nil {
+			return err
+		}
+
+		plan := u.schedulePolicy.Reschedule(principal, margin, tenor.TenorValue)
+		newDetails := plan.Details(f, startDate, u.schedulePolicy.DueDates)
+		if err := u.userFacilityDetailRepo.BulkCreate(txCtx, newDetails); err != nil {
+			return err
+		}
+
+		// The facility shows the terms of the new schedule, the margin already paid stays in its totals
+		f.Tenor = tenor.TenorValue
+		f.MonthlyInstallment = plan.MonthlyInstallment
+		f.TotalMargin += plan.TotalMargin - margin
+		f.TotalPayment = f.Amount + f.TotalMargin
+		if err := u.userFacilityRepo.UpdateTerms(txCtx, f); err != nil {
+			return err
+		}
+
+		restructuring = domain.Restructuring{
+			UserFacilityID:       f.UserFacilityID,
+			FromVersion:          fromVersion,
+			ToVersion:            f.ScheduleVersion,
+			OutstandingPrincipal: principal,
+			RemainingMargin:      margin,
+			Tenor:                tenor.TenorValue,
+			StartDate:            startDate,
+			MonthlyInstallment:   plan.MonthlyInstallment,
+			Reason:               reason,
+			Actor:                actor,
+		}
+		if err := u.restructuringRepo.Create(txCtx, &restructuring); err != nil {
+			return err
+		}
+
+		// Read the schedule back so the new installments come with their ids.
+		schedule, err = u.userFacilityDetailRepo.ListByFacilityID(txCtx, f.UserFacilityID)
+		return err
+	})
+	if err != nil {
+		return dto.RestructureResponse{}, err
+	}
+
+	return dto.RestructureResponse{
+		RestructuringID:      restructuring.RestructuringID,
+		UserFacilityID:       restructuring.UserFacilityID,
+		ScheduleVersion:      restructuring.ToVersion,
+		OutstandingPrincipal: restructuring.OutstandingPrincipal,
+		RemainingMargin:      restructuring.RemainingMargin,
+		Tenor:                restructuring.Tenor,
+		StartDate:            restructuring.StartDate.Format("2006-01-02"),
+		MonthlyInstallment:   restructuring.MonthlyInstallment,
+		Reason:               restructuring.Reason,
+		Schedule:             toScheduleItems(schedule),
+	}, nil
+}
@@ -0,0 +1,155 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/elokanugrah/go-financing-btpns/internal/domain"
+	"github.com/elokanugrah/go-financing-btpns/internal/dto"
+	"github.com/elokanugrah/go-financing-btpns/internal/usecase"
+	"github.com/elokanugrah/go-financing-btpns/internal/usecase/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestFinancingUsecase_RestructureFacility(t *testing.T) {
+	ctx := context.Background()
+	start := time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC)
+	newStart := time.Now().UTC().AddDate(0, 0, 7).Format("2006-01-02")
+
+	facility := domain.UserFacility{
+		UserFacilityID: 100, Amount: domain.NewMoney(3000000), Tenor: 3, MonthlyInstallment: domain.NewMoney(1200000),
+		TotalMargin: domain.NewMoney(600000), TotalPayment: domain.NewMoney(3600000), StartDate: start,
+		Status: domain.FacilityStatusActive, ScheduleVersion: 1,
+	}
+	schedule := []domain.UserFacilityDetail{
+		{DetailID: 1, DueDate: start.AddDate(0, 1, 0), InstallmentAmount: domain.NewMoney(1200000), PrincipalAmount: domain.NewMoney(1000000), MarginAmount: domain.NewMoney(200000), PaidAmount: domain.NewMoney(1200000), Status: domain.InstallmentPaid},
+		{DetailID: 2, DueDate: start.AddDate(0, 2, 0), InstallmentAmount: domain.NewMoney(1200000), PrincipalAmount: domain.NewMoney(1000000), MarginAmount: domain.NewMoney(200000), PaidAmount: domain.NewMoney(300000), Status: domain.InstallmentPartiallyPaid},
+		{DetailID: 3, DueDate: start.AddDate(0, 3, 0), InstallmentAmount: domain.NewMoney(1200000), PrincipalAmount: domain.NewMoney(1000000), MarginAmount: domain.NewMoney(200000), Status: domain.InstallmentUnpaid},
+	}
+
+	t.Run("success - supersedes the unpaid installments and spreads the balance over the new tenor", func(t *testing.T) {
+		mockTenorRepo := new(mocks.TenorRepository)
+		mockUserFacilityRepo := new(mocks.UserFacilityRepository)
+		mockUserFacilityDetailRepo := new(mocks.UserFacilityDetailRepository)
+		mockRestructuringRepo := new(mocks.RestructuringRepository)
+		mockTxManager := new(mocks.TransactionManager)
+		uc := usecase.NewFinancingUsecase(usecase.FinancingDeps{
+			TenorRepo:              mockTenorRepo,
+			UserFacilityDetailRepo: mockUserFacilityDetailRepo,
+			UserFacilityRepo:       mockUserFacilityRepo,
+			RestructuringRepo:      mockRestructuringRepo,
+			TxManager:              mockTxManager,
+			SchedulePolicy:         domain.DefaultSchedulePolicy(),
+		})
+
+		mockTenorRepo.On("GetByValue", ctx, 6).Return(domain.Tenor{TenorValue: 6, IsActive: true}, nil).Once()
+		mockTxManager.On("WithTransaction", ctx, mock.AnythingOfType("func(context.Context) error")).Return(nil).Run(func(args mock.Arguments) {
+			fn := args.Get(1).(func(txCtx context.Context) error)
+			mockUserFacilityRepo.On("GetByIDForUpdate", mock.Anything, int64(100)).Return(facility, nil).Once()
+			mockUserFacilityDetailRepo.On("ListByFacilityID", mock.Anything, int64(100)).Return(schedule, nil).Once()
+			mockUserFacilityDetailRepo.On("Supersede", mock.Anything, []int64{2, 3}).Return(nil).Once()
+			mockUserFacilityRepo.On("UpdateScheduleVersion", mock.Anything, int64(100), 2).Return(nil).Once()
+			mockUserFacilityDetailRepo.On("BulkCreate", mock.Anything, mock.MatchedBy(func(details []domain.UserFacilityDetail) bool {
+				var total domain.Money
+				for _, d := range details {
+					total += d.InstallmentAmount
+				}
+				return len(details) == 6 && details[0].ScheduleVersion == 2 && total == domain.NewMoney(2100000)
+			})).Return(nil).Once()
+			mockUserFacilityRepo.On("UpdateTerms", mock.Anything, mock.MatchedBy(func(uf domain.UserFacility) bool {
+				return uf.UserFacilityID == 100 && uf.Tenor == 6 && uf.MonthlyInstallment == domain.NewMoney(350000) &&
+					uf.TotalMargin == domain.NewMoney(600000) && uf.TotalPayment == domain.NewMoney(3600000)
+			})).Return(nil).Once()
+			mockRestructuringRepo.On("Create", mock.Anything, mock.MatchedBy(func(r *domain.Restructuring) bool {
+				return r.FromVersion == 1 && r.ToVersion == 2 && r.OutstandingPrincipal == domain.NewMoney(1900000) &&
+					r.RemainingMargin == domain.NewMoney(200000) && r.Reason == "income dropped" && r.Actor == "officer"
+			})).Return(nil).Run(func(args mock.Arguments) {
+				args.Get(1).(*domain.Restructuring).RestructuringID = 4
+			}).Once()
+			mockUserFacilityDetailRepo.On("ListByFacilityID", mock.Anything, int64(100)).Return(schedule[:1], nil).Once()
+			assert.NoError(t, fn(ctx))
+		}).Once()
+
+		res, err := uc.RestructureFacility(ctx, 100, dto.RestructureRequest{Tenor: 6, StartDate: newStart, Reason: " income dropped ", Actor: "officer"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(4), res.RestructuringID)
+		assert.Equal(t, 2, res.ScheduleVersion)
+		assert.Equal(t, domain.NewMoney(1900000), res.OutstandingPrincipal)
+		assert.Equal(t, domain.NewMoney(200000), res.RemainingMargin)
+		assert.Equal(t, domain.NewMoney(350000), res.MonthlyInstallment)
+		assert.Equal(t, newStart, res.StartDate)
+		mockUserFacilityRepo.AssertExpectations(t)
+		mockUserFacilityDetailRepo.AssertExpectations(t)
+		mockRestructuringRepo.AssertExpectations(t)
+	})
+
+	t.Run("Failure - nothing left to restructure", func(t *testing.T) {
+		mockTenorRepo := new(mocks.TenorRepository)
+		mockUserFacilityRepo := new(mocks.UserFacilityRepository)
+		mockUserFacilityDetailRepo := new(mocks.UserFacilityDetailRepository)
+		mockTxManager := new(mocks.TransactionManager)
+		uc := usecase.NewFinancingUsecase(usecase.FinancingDeps{
+			TenorRepo:              mockTenorRepo,
+			UserFacilityDetailRepo: mockUserFacilityDetailRepo,
+			UserFacilityRepo:       mockUserFacilityRepo,
+			TxManager:              mockTxManager,
+			SchedulePolicy:         domain.DefaultSchedulePolicy(),
+		})
+
+		mockTenorRepo.On("GetByValue", ctx, 6).Return(domain.Tenor{TenorValue: 6, IsActive: true}, nil).Once()
+		mockTxManager.On("WithTransaction", ctx, mock.AnythingOfType("func(context.Context) error")).Return(domain.ErrConflict).Run(func(args mock.Arguments) {
+			fn := args.Get(1).(func(txCtx context.Context) error)
+			mockUserFacilityRepo.On("GetByIDForUpdate", mock.Anything, int64(100)).Return(facility, nil).Once()
+			mockUserFacilityDetailRepo.On("ListByFacilityID", mock.Anything, int64(100)).Return(schedule[:1], nil).Once()
+			err := fn(ctx)
+			assert.EqualError(t, err, "facility has no unpaid installments")
+		}).Once()
+
+		_, err := uc.RestructureFacility(ctx, 100, dto.RestructureRequest{Tenor: 6, StartDate: newStart, Reason: "income dropped", Actor: "officer"})
+
+		assert.ErrorIs(t, err, domain.ErrConflict)
+		mockUserFacilityDetailRepo.AssertNotCalled(t, "Supersede", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Failure - unknown tenor", func(t *testing.T) {
+		mockTenorRepo := new(mocks.TenorRepository)
+		uc := usecase.NewFinancingUsecase(usecase.FinancingDeps{
+			TenorRepo:      mockTenorRepo,
+			SchedulePolicy: domain.DefaultSchedulePolicy(),
+		})
+
+		mockTenorRepo.On("GetByValue", ctx, 7).Return(domain.Tenor{}, domain.ErrNotFound).Once()
+
+		_, err := uc.RestructureFacility(ctx, 100, dto.RestructureRequest{Tenor: 7, StartDate: newStart, Reason: "income dropped", Actor: "officer"})
+
+		assert.EqualError(t, err, "invalid tenor")
+		assert.ErrorIs(t, err, domain.ErrValidation)
+	})
+
+	validationCases := []struct {
+		name    string
+		req     dto.RestructureRequest
+		wantErr string
+	}{
+		{name: "missing actor", req: dto.RestructureRequest{Tenor: 6, StartDate: newStart, Reason: "income dropped"}, wantErr: "actor is required"},
+		{name: "missing reason", req: dto.RestructureRequest{Tenor: 6, StartDate: newStart, Actor: "officer"}, wantErr: "reason is required"},
+		{name: "invalid start date", req: dto.RestructureRequest{Tenor: 6, StartDate: "2025/01/01", Reason: "income dropped", Actor: "officer"}, wantErr: "invalid start_date format"},
+		{name: "start date in the past", req: dto.RestructureRequest{Tenor: 6, StartDate: "2025-01-01", Reason: "income dropped", Actor: "officer"}, wantErr: "start_date must not be in the past"},
+	}
+	for _, tc := range validationCases {
+		t.Run("Failure - "+tc.name, func(t *testing.T) {
+			uc := usecase.NewFinancingUsecase(usecase.FinancingDeps{
+				SchedulePolicy: domain.DefaultSchedulePolicy(),
+			})
+
+			_, err := uc.RestructureFacility(ctx, 100, tc.req)
+
+			assert.EqualError(t, err, tc.wantErr)
+			assert.ErrorIs(t, err, domain.ErrValidation)
+		})
+	}
+}
@@ -0,0 +1,200 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/elokanugrah/go-financing-btpns/internal/domain"
+	"github.com/elokanugrah/go-financing-btpns/internal/dto"
+)
+
+// QuoteSettlement computes what it takes to pay the facility off early on the requested date.
+// The quote can be for a future date, it is not stored.
+func (u *financingUsecase) QuoteSettlement(ctx context.Context, facilityID int64, req dto.SettlementQuoteRequest) (dto.SettlementQuoteResponse, error) {
+	settlementDate := truncateToDate(u.now())
+	if req.SettlementDate != "" {
+		var err error
+		settlementDate, err = time.Parse("2006-01-02", req.SettlementDate)
+		if err != nil {
+			return dto.SettlementQuoteResponse{}, domain.ValidationError("INVALID_SETTLEMENT_DATE", "invalid settlement_date format")
+		}
+	}
+
+	facility, err := u.userFacilityRepo.GetByID(ctx, facilityID)
+	if errors.Is(err, domain.ErrNotFound) {
+		return dto.SettlementQuoteResponse{}, errFacilityNotFound
+	}
+	if err != nil {
+		return dto.SettlementQuoteResponse{}, err
+	}
+	if !isRepayable(facility.Status) {
+		return dto.SettlementQuoteResponse{}, domain.ConflictError("FACILITY_NOT_REPAYABLE", fmt.Sprintf("facility does not accept payments while %s", facility.Status))
+	}
+
+	details, err := u.userFacilityDetailRepo.ListByFacilityID(ctx, facility.UserFacilityID)
+	if err != nil {
+		return dto.SettlementQuoteResponse{}, err
+	}
+
+	lateCharges, _, err := u.currentLateCharges(ctx, facility.UserFacilityID, details, settlementDate)
+	if err != nil {
+		return dto.SettlementQuoteResponse{}, err
+	}
+
+	quote, err := u.quoteSettlement(facility, details, lateCharges, settlementDate)
+	if err != nil {
+		return dto.SettlementQuoteResponse{}, err
+	}
+	return toSettlementQuoteResponse(facility.UserFacilityID, quote), nil
+}
+
+// SettleFacility pays the facility off with the quoted amount, closes every remaining installment
+// and moves the facility to PAID_OFF. The quote is recomputed, a stale payoff amount is refused.
+func (u *financingUsecase) SettleFacility(ctx context.Context, facilityID int64, req dto.SettlementRequest) (dto.SettlementResponse, error) {
+	actor := strings.TrimSpace(req.Actor)
+	if actor == "" {
+		return dto.SettlementResponse{}, domain.ValidationError("ACTOR_REQUIRED", "actor is required")
+	}
+	if req.PayoffAmount <= 0 {
+		return dto.SettlementResponse{}, domain.ValidationError("INVALID_PAYOFF_AMOUNT", "payoff_amount must be greater than 0")
+	}
+	settlementDate, err := u.pastDate(req.SettlementDate, "settlement_date")
+	if err != nil {
+		return dto.SettlementResponse{}, err
+	}
+
+	var (
+		settlement domain.Settlement
+		facility   domain.UserFacility
+	)
+	err = u.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		f, err := u.lockRepayableFacility(txCtx, facilityID)
+		if err != nil {
+			return err
+		}
+
+		details, err := u.userFacilityDetailRepo.ListByFacilityID(txCtx, f.UserFacilityID)
+		if err != nil {
+			return err
+		}
+		lateCharges, err := u.assessLateCharges(txCtx, f.UserFacilityID, details, settlementDate)
+		if err != nil {
+			return err
+		}
+		quote, err := u.quoteSettlement(f, details, lateCharges, settlementDate)
+		if err != nil {
+			return err
+		}
+		if quote.PayoffAmount != req.PayoffAmount {
+			return domain.ConflictError("SETTLEMENT_QUOTE_CHANGED", fmt.Sprintf("payoff_amount does not match the current quote of %s", quote.PayoffAmount))
+		}
+
+		// The payoff pays the late charges and the installments oldest first, whatever it leaves
+		// unpaid on the installments is the rebate.
+		var open []int
+		for i, d := range details {
+			if d.Unpaid() > 0 {
+				open = append(open, i)
+			}
+		}
+		repaidBefore := repaidPrincipal(details)
+		allocations, _ := domain.AllocatePayment(details, lateCharges, quote.PayoffAmount+quote.CreditApplied, settlementDate, u.schedulePolicy.DueDates)
+		if err := u.updatePaidLateCharges(txCtx, lateCharges, allocations); err != nil {
+			return err
+		}
+		for _, i := range open {
+			details[i].Settle(settlementDate)
+			if err := u.userFacilityDetailRepo.UpdatePayment(txCtx, details[i]); err != nil {
+				return err
+			}
+		}
+		if err := u.restoreRevolvingLimit(txCtx, f.FacilityLimitID, repaidPrincipal(details)-repaidBefore); err != nil {
+			return err
+		}
+
+		payment := domain.Payment{
+			UserFacilityID: f.UserFacilityID,
+			Amount:         quote.PayoffAmount,
+			CreditAmount:   -quote.CreditApplied,
+			Reference:      strings.TrimSpace(req.Reference),
+			PaidAt:         settlementDate,
+			Allocations:    allocations,
+		}
+		if err := u.paymentRepo.Create(txCtx, &payment); err != nil {
+			return err
+		}
+
+		if quote.CreditApplied > 0 {
+			f.CreditBalance -= quote.CreditApplied
+			if err := u.userFacilityRepo.UpdateCreditBalance(txCtx, f.UserFacilityID, f.CreditBalance); err != nil {
+				return err
+			}
+		}
+
+		settlement = domain.Settlement{
+			UserFacilityID:  f.UserFacilityID,
+			PaymentID:       payment.PaymentID,
+			SettlementQuote: quote,
+			Actor:           actor,
+		}
+		if err := u.settlementRepo.Create(txCtx, &settlement); err != nil {
+			return err
+		}
+
+		reason := fmt.Sprintf("early settlement %d", settlement.SettlementID)
+		if f.Status == domain.FacilityStatusDisbursed {
+			if err := u.changeStatus(txCtx, &f, domain.FacilityStatusActive, actor, reason); err != nil {
+				return err
+			}
+		}
+		if err := u.changeStatus(txCtx, &f, domain.FacilityStatusPaidOff, actor, reason); err != nil {
+			return err
+		}
+
+		facility = f
+		return nil
+	})
+	if err != nil {
+		return dto.SettlementResponse{}, err
+	}
+
+	return dto.SettlementResponse{
+		SettlementQuoteResponse: toSettlementQuoteResponse(facility.UserFacilityID, settlement.SettlementQuote),
+		SettlementID:            settlement.SettlementID,
+		PaymentID:               settlement.PaymentID,
+		FacilityStatus:          facility.Status,
+	}, nil
+}
+
+func (u *financingUsecase) quoteSettlement(f domain.UserFacility, details []domain.UserFacilityDetail, lateCharges []domain.LateCharge, settlementDate time.Time) (domain.SettlementQuote, error) {
+	if settlementDate.Before(f.StartDate) {
+		return domain.SettlementQuote{}, domain.ValidationError("INVALID_SETTLEMENT_DATE", "settlement_date must not be before the facility start_date")
+	}
+	quote := u.settlementPolicy.Quote(details, settlementDate)
+	quote.LateCharges = unpaidLateCharges(lateCharges)
+	quote.PayoffAmount += quote.LateCharges
+	if quote.PayoffAmount <= 0 {
+		return domain.SettlementQuote{}, domain.ConflictError("NOTHING_TO_SETTLE", "facility has no unpaid installments")
+	}
+	// Credit held from earlier overpayments goes towards the payoff first
+	quote.CreditApplied = min(f.CreditBalance, quote.PayoffAmount)
+	quote.PayoffAmount -= quote.CreditApplied
+	return quote, nil
+}
+
+func toSettlementQuoteResponse(facilityID int64, q domain.SettlementQuote) dto.SettlementQuoteResponse {
+	return dto.SettlementQuoteResponse{
+		UserFacilityID:       facilityID,
+		SettlementDate:       q.SettlementDate.Format("2006-01-02"),
+		OutstandingPrincipal: q.OutstandingPrincipal,
+		RemainingMargin:      q.RemainingMargin,
+		UnearnedMargin:       q.UnearnedMargin,
+		Rebate:               q.Rebate,
+		LateCharges:          q.LateCharges,
+		CreditApplied:        q.CreditApplied,
+		PayoffAmount:         q.PayoffAmount,
+	}
+}
@@ -0,0 +1,264 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/elokanugrah/go-financing-btpns/internal/domain"
+	"github.com/elokanugrah/go-financing-btpns/internal/dto"
+	"github.com/elokanugrah/go-financing-btpns/internal/usecase"
+	"github.com/elokanugrah/go-financing-btpns/internal/usecase/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestFinancingUsecase_Settlement(t *testing.T) {
+	ctx := context.Background()
+	start := time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC)
+	halfRebate := domain.SettlementPolicy{MarginRebate: 5000}
+
+	facility := domain.UserFacility{UserFacilityID: 100, StartDate: start, Status: domain.FacilityStatusActive}
+	schedule := func() []domain.UserFacilityDetail {
+		return []domain.UserFacilityDetail{
+			{DetailID: 1, DueDate: start.AddDate(0, 1, 0), InstallmentAmount: domain.NewMoney(1200000), PrincipalAmount: domain.NewMoney(1000000), MarginAmount: domain.NewMoney(200000), PaidAmount: domain.NewMoney(1200000), Status: domain.InstallmentPaid},
+			{DetailID: 2, DueDate: start.AddDate(0, 2, 0), InstallmentAmount: domain.NewMoney(1200000), PrincipalAmount: domain.NewMoney(1000000), MarginAmount: domain.NewMoney(200000), Status: domain.InstallmentUnpaid},
+			{DetailID: 3, DueDate: start.AddDate(0, 3, 0), InstallmentAmount: domain.NewMoney(1200000), PrincipalAmount: domain.NewMoney(1000000), MarginAmount: domain.NewMoney(200000), Status: domain.InstallmentUnpaid},
+		}
+	}
+
+	t.Run("success - quote waives half of the margin not due yet", func(t *testing.T) {
+		mockUserFacilityRepo := new(mocks.UserFacilityRepository)
+		mockUserFacilityDetailRepo := new(mocks.UserFacilityDetailRepository)
+		mockLateChargeRepo := new(mocks.LateChargeRepository)
+		uc := usecase.NewFinancingUsecase(usecase.FinancingDeps{
+			UserFacilityDetailRepo: mockUserFacilityDetailRepo,
+			UserFacilityRepo:       mockUserFacilityRepo,
+			LateChargeRepo:         mockLateChargeRepo,
+			SchedulePolicy:         domain.DefaultSchedulePolicy(),
+			SettlementPolicy:       halfRebate,
+		})
+
+		mockUserFacilityRepo.On("GetByID", ctx, int64(100)).Return(facility, nil).Once()
+		mockUserFacilityDetailRepo.On("ListByFacilityID", ctx, int64(100)).Return(schedule(), nil).Once()
+		mockLateChargeRepo.On("ListByFacilityID", ctx, int64(100)).Return(nil, nil).Once()
+
+		res, err := uc.QuoteSettlement(ctx, 100, dto.SettlementQuoteRequest{SettlementDate: "2025-03-15"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "2025-03-15", res.SettlementDate)
+		assert.Equal(t, domain.NewMoney(2000000), res.OutstandingPrincipal)
+		assert.Equal(t, domain.NewMoney(400000), res.RemainingMargin)
+		assert.Equal(t, domain.NewMoney(200000), res.UnearnedMargin)
+		assert.Equal(t, domain.NewMoney(100000), res.Rebate)
+		assert.Equal(t, domain.NewMoney(2300000), res.PayoffAmount)
+	})
+
+	t.Run("Failure - quote before the facility starts", func(t *testing.T) {
+		mockUserFacilityRepo := new(mocks.UserFacilityRepository)
+		mockUserFacilityDetailRepo := new(mocks.UserFacilityDetailRepository)
+		mockLateChargeRepo := new(mocks.LateChargeRepository)
+		uc := usecase.NewFinancingUsecase(usecase.FinancingDeps{
+			UserFacilityDetailRepo: mockUserFacilityDetailRepo,
+			UserFacilityRepo:       mockUserFacilityRepo,
+			LateChargeRepo:         mockLateChargeRepo,
+			SchedulePolicy:         domain.DefaultSchedulePolicy(),
+			SettlementPolicy:       halfRebate,
+		})
+
+		mockUserFacilityRepo.On("GetByID", ctx, int64(100)).Return(facility, nil).Once()
+		mockUserFacilityDetailRepo.On("ListByFacilityID", ctx, int64(100)).Return(schedule(), nil).Once()
+		mockLateChargeRepo.On("ListByFacilityID", ctx, int64(100)).Return(nil, nil).Once()
+
+		_, err := uc.QuoteSettlement(ctx, 100, dto.SettlementQuoteRequest{SettlementDate: "2024-12-31"})
+
+		assert.EqualError(t, err, "settlement_date must not be before the facility start_date")
+	})
+
+	t.Run("Failure - facility not repayable", func(t *testing.T) {
+		mockUserFacilityRepo := new(mocks.UserFacilityRepository)
+		uc := usecase.NewFinancingUsecase(usecase.FinancingDeps{
+			UserFacilityRepo: mockUserFacilityRepo,
+			SchedulePolicy:   domain.DefaultSchedulePolicy(),
+			SettlementPolicy: halfRebate,
+		})
+
+		mockUserFacilityRepo.On("GetByID", ctx, int64(100)).Return(domain.UserFacility{UserFacilityID: 100, Status: domain.FacilityStatusPaidOff}, nil).Once()
+
+		_, err := uc.QuoteSettlement(ctx, 100, dto.SettlementQuoteRequest{})
+
+		assert.ErrorIs(t, err, domain.ErrConflict)
+	})
+
+	t.Run("success - settle closes the remaining installments and pays the facility off", func(t *testing.T) {
+		mockUserFacilityRepo := new(mocks.UserFacilityRepository)
+		mockUserFacilityDetailRepo := new(mocks.UserFacilityDetailRepository)
+		mockLateChargeRepo := new(mocks.LateChargeRepository)
+		mockStatusHistoryRepo := new(mocks.FacilityStatusHistoryRepository)
+		mockPaymentRepo := new(mocks.PaymentRepository)
+		mockSettlementRepo := new(mocks.SettlementRepository)
+		mockTxManager := new(mocks.TransactionManager)
+		uc := usecase.NewFinancingUsecase(usecase.FinancingDeps{
+			UserFacilityDetailRepo: mockUserFacilityDetailRepo,
+			UserFacilityRepo:       mockUserFacilityRepo,
+			StatusHistoryRepo:      mockStatusHistoryRepo,
+			PaymentRepo:            mockPaymentRepo,
+			SettlementRepo:         mockSettlementRepo,
+			LateChargeRepo:         mockLateChargeRepo,
+			FacilityLimitRepo:      nonRevolvingLimitRepo(),
+			TxManager:              mockTxManager,
+			SchedulePolicy:         domain.DefaultSchedulePolicy(),
+			SettlementPolicy:       halfRebate,
+		})
+
+		mockTxManager.On("WithTransaction", ctx, mock.AnythingOfType("func(context.Context) error")).Return(nil).Run(func(args mock.Arguments) {
+			fn := args.Get(1).(func(txCtx context.Context) error)
+			mockUserFacilityRepo.On("GetByIDForUpdate", mock.Anything, int64(100)).Return(facility, nil).Once()
+			mockUserFacilityDetailRepo.On("ListByFacilityID", mock.Anything, int64(100)).Return(schedule(), nil).Once()
+			mockLateChargeRepo.On("ListByFacilityID", mock.Anything, int64(100)).Return(nil, nil).Once()
+			mockUserFacilityDetailRepo.On("UpdatePayment", mock.Anything, mock.MatchedBy(func(d domain.UserFacilityDetail) bool {
+				return d.DetailID == 2 && d.Status == domain.InstallmentPaid
+			})).Return(nil).Once()
+			// 1.1jt of the payoff is left for the last installment, the other 100rb is the rebate
+			mockUserFacilityDetailRepo.On("UpdatePayment", mock.Anything, mock.MatchedBy(func(d domain.UserFacilityDetail) bool {
+				return d.DetailID == 3 && d.Status == domain.InstallmentSettled && d.PaidAmount == domain.NewMoney(1100000)
+			})).Return(nil).Once()
+			mockPaymentRepo.On("Create", mock.Anything, mock.MatchedBy(func(p *domain.Payment) bool {
+				return p.Amount == domain.NewMoney(2300000) && len(p.Allocations) == 2
+			})).Return(nil).Run(func(args mock.Arguments) {
+				args.Get(1).(*domain.Payment).PaymentID = 7
+			}).Once()
+			mockSettlementRepo.On("Create", mock.Anything, mock.MatchedBy(func(s *domain.Settlement) bool {
+				return s.PaymentID == 7 && s.Rebate == domain.NewMoney(100000) && s.Actor == "teller"
+			})).Return(nil).Run(func(args mock.Arguments) {
+				args.Get(1).(*domain.Settlement).SettlementID = 3
+			}).Once()
+			mockUserFacilityRepo.On("UpdateStatus", mock.Anything, int64(100), domain.FacilityStatusPaidOff).Return(nil).Once()
+			mockStatusHistoryRepo.On("Create", mock.Anything, mock.MatchedBy(func(h *domain.FacilityStatusHistory) bool {
+				return h.ToStatus == domain.FacilityStatusPaidOff && h.Reason == "early settlement 3"
+			})).Return(nil).Once()
+			assert.NoError(t, fn(ctx))
+		}).Once()
+
+		res, err := uc.SettleFacility(ctx, 100, dto.SettlementRequest{SettlementDate: "2025-03-15", PayoffAmount: domain.NewMoney(2300000), Actor: "teller"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), res.SettlementID)
+		assert.Equal(t, int64(7), res.PaymentID)
+		assert.Equal(t, domain.FacilityStatusPaidOff, res.FacilityStatus)
+		mockUserFacilityDetailRepo.AssertExpectations(t)
+		mockSettlementRepo.AssertExpectations(t)
+		mockStatusHistoryRepo.AssertExpectations(t)
+	})
+
+	t.Run("success - credit balance is taken off the quote and used up on settlement", func(t *testing.T) {
+		mockUserFacilityRepo := new(mocks.UserFacilityRepository)
+		mockUserFacilityDetailRepo := new(mocks.UserFacilityDetailRepository)
+		mockLateChargeRepo := new(mocks.LateChargeRepository)
+		mockStatusHistoryRepo := new(mocks.FacilityStatusHistoryRepository)
+		mockPaymentRepo := new(mocks.PaymentRepository)
+		mockSettlementRepo := new(mocks.SettlementRepository)
+		uc := usecase.NewFinancingUsecase(usecase.FinancingDeps{
+			UserFacilityDetailRepo: mockUserFacilityDetailRepo,
+			UserFacilityRepo:       mockUserFacilityRepo,
+			StatusHistoryRepo:      mockStatusHistoryRepo,
+			PaymentRepo:            mockPaymentRepo,
+			SettlementRepo:         mockSettlementRepo,
+			LateChargeRepo:         mockLateChargeRepo,
+			FacilityLimitRepo:      nonRevolvingLimitRepo(),
+			TxManager:              passthroughTxManager(),
+			SchedulePolicy:         domain.DefaultSchedulePolicy(),
+			SettlementPolicy:       halfRebate,
+		})
+
+		withCredit := facility
+		withCredit.CreditBalance = domain.NewMoney(50000)
+		mockUserFacilityRepo.On("GetByID", ctx, int64(100)).Return(withCredit, nil).Once()
+		mockUserFacilityRepo.On("GetByIDForUpdate", ctx, int64(100)).Return(withCredit, nil).Once()
+		mockUserFacilityDetailRepo.On("ListByFacilityID", ctx, int64(100)).Return(func(context.Context, int64) ([]domain.UserFacilityDetail, error) {
+			return schedule(), nil
+		}).Twice()
+		mockLateChargeRepo.On("ListByFacilityID", ctx, int64(100)).Return(nil, nil).Twice()
+
+		quote, err := uc.QuoteSettlement(ctx, 100, dto.SettlementQuoteRequest{SettlementDate: "2025-03-15"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, domain.NewMoney(50000), quote.CreditApplied)
+		assert.Equal(t, domain.NewMoney(2250000), quote.PayoffAmount)
+
+		mockUserFacilityDetailRepo.On("UpdatePayment", ctx, mock.Anything).Return(nil).Twice()
+		mockPaymentRepo.On("Create", ctx, mock.MatchedBy(func(p *domain.Payment) bool {
+			return p.Amount == domain.NewMoney(2250000) && p.CreditAmount == -domain.NewMoney(50000)
+		})).Return(nil).Once()
+		mockUserFacilityRepo.On("UpdateCreditBalance", ctx, int64(100), domain.Money(0)).Return(nil).Once()
+		mockSettlementRepo.On("Create", ctx, mock.MatchedBy(func(s *domain.Settlement) bool {
+			return s.CreditApplied == domain.NewMoney(50000) && s.PayoffAmount == domain.NewMoney(2250000)
+		})).Return(nil).Once()
+		mockUserFacilityRepo.On("UpdateStatus", ctx, int64(100), domain.FacilityStatusPaidOff).Return(nil).Once()
+		mockStatusHistoryRepo.On("Create", ctx, mock.Anything).Return(nil).Once()
+
+		res, err := uc.SettleFacility(ctx, 100, dto.SettlementRequest{SettlementDate: "2025-03-15", PayoffAmount: quote.PayoffAmount, Actor: "teller"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, domain.NewMoney(50000), res.CreditApplied)
+		assert.Equal(t, domain.FacilityStatusPaidOff, res.FacilityStatus)
+		mockUserFacilityRepo.AssertExpectations(t)
+		mockPaymentRepo.AssertExpectations(t)
+		mockSettlementRepo.AssertExpectations(t)
+	})
+
+	t.Run("Failure - payoff amount from an outdated quote", func(t *testing.T) {
+		mockUserFacilityRepo := new(mocks.UserFacilityRepository)
+		mockUserFacilityDetailRepo := new(mocks.UserFacilityDetailRepository)
+		mockLateChargeRepo := new(mocks.LateChargeRepository)
+		mockPaymentRepo := new(mocks.PaymentRepository)
+		mockTxManager := new(mocks.TransactionManager)
+		uc := usecase.NewFinancingUsecase(usecase.FinancingDeps{
+			UserFacilityDetailRepo: mockUserFacilityDetailRepo,
+			UserFacilityRepo:       mockUserFacilityRepo,
+			PaymentRepo:            mockPaymentRepo,
+			LateChargeRepo:         mockLateChargeRepo,
+			TxManager:              mockTxManager,
+			SchedulePolicy:         domain.DefaultSchedulePolicy(),
+			SettlementPolicy:       halfRebate,
+		})
+
+		mockTxManager.On("WithTransaction", ctx, mock.AnythingOfType("func(context.Context) error")).Return(domain.ErrConflict).Run(func(args mock.Arguments) {
+			fn := args.Get(1).(func(txCtx context.Context) error)
+			mockUserFacilityRepo.On("GetByIDForUpdate", mock.Anything, int64(100)).Return(facility, nil).Once()
+			mockUserFacilityDetailRepo.On("ListByFacilityID", mock.Anything, int64(100)).Return(schedule(), nil).Once()
+			mockLateChargeRepo.On("ListByFacilityID", mock.Anything, int64(100)).Return(nil, nil).Once()
+			err := fn(ctx)
+			assert.EqualError(t, err, "payoff_amount does not match the current quote of 2300000.00")
+		}).Once()
+
+		_, err := uc.SettleFacility(ctx, 100, dto.SettlementRequest{SettlementDate: "2025-03-15", PayoffAmount: domain.NewMoney(2200000), Actor: "teller"})
+
+		assert.ErrorIs(t, err, domain.ErrConflict)
+		mockPaymentRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	validationCases := []struct {
+		name    string
+		req     dto.SettlementRequest
+		wantErr string
+	}{
+		{name: "missing actor", req: dto.SettlementRequest{PayoffAmount: 1}, wantErr: "actor is required"},
+		{name: "missing payoff amount", req: dto.SettlementRequest{Actor: "teller"}, wantErr: "payoff_amount must be greater than 0"},
+		{name: "settlement date in the future", req: dto.SettlementRequest{Actor: "teller", PayoffAmount: 1, SettlementDate: time.Now().AddDate(0, 1, 0).Format("2006-01-02")}, wantErr: "settlement_date must not be in the future"},
+	}
+	for _, tc := range validationCases {
+		t.Run("Failure - "+tc.name, func(t *testing.T) {
+			uc := usecase.NewFinancingUsecase(usecase.FinancingDeps{
+				SchedulePolicy:   domain.DefaultSchedulePolicy(),
+				SettlementPolicy: halfRebate,
+			})
+
+			_, err := uc.SettleFacility(ctx, 100, tc.req)
+
+			assert.EqualError(t, err, tc.wantErr)
+			assert.ErrorIs(t, err, domain.ErrValidation)
+		})
+	}
+}
@@ -0,0 +1,278 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/elokanugrah/go-financing-btpns/internal/domain"
+	"github.com/elokanugrah/go-financing-btpns/internal/dto"
+)
+
+type userUsecase struct {
+	userRepo  UserRepository
+	txManager TransactionManager
+	now       func() time.Time
+}
+
+func NewUserUsecase(ur UserRepository, tm TransactionManager) UserUsecase {
+	return &userUsecase{
+		userRepo:  ur,
+		txManager: tm,
+		now:       time.Now,
+	}
+}
+
+const (
+	// maxUserNameLength bounds the customer name.
+	maxUserNameLength = 255
+	// maxAddressLength bounds the customer address.
+	maxAddressLength = 500
+)
+
+var errUserNotFound = domain.NotFoundError("USER_NOT_FOUND", "user not found")
+
+func (u *userUsecase) CreateUser(ctx context.Context, req dto.CreateUserRequest) (dto.UserResponse, error) {
+	name, err := userName(req.Name)
+	if err != nil {
+		return dto.UserResponse{}, err
+	}
+	phone, err := userPhone(req.Phone)
+	if err != nil {
+		return dto.UserResponse{}, err
+	}
+
+	user := domain.User{Name: name, Phone: phone, KYCStatus: domain.KYCPending}
+	if err := u.applyIdentity(&user, &req.NIK, &req.DateOfBirth, &req.Address); err != nil {
+		return dto.UserResponse{}, err
+	}
+
+	if err := u.userRepo.Create(ctx, &user); err != nil {
+		return dto.UserResponse{}, err
+	}
+	return toUserResponse(user), nil
+}
+
+func (u *userUsecase) GetUser(ctx context.Context, id int64) (dto.UserResponse, error) {
+	user, err := u.userRepo.GetByID(ctx, id)
+	if errors.Is(err, domain.ErrNotFound) {
+		return dto.UserResponse{}, errUserNotFound
+	}
+	if err != nil {
+		return dto.UserResponse{}, err
+	}
+	return toUserResponse(user), nil
+}
+
+// UpdateUser changes the fields sent in the request, leaving the others as they are. Changing
+// the NIK, date of birth or address sends the user back to KYC PENDING.
+func (u *userUsecase) UpdateUser(ctx context.Context, id int64, req dto.UpdateUserRequest) (dto.UserResponse, error) {
+	var user domain.User
+	err := u.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		// Lock the user so a concurrent KYC decision is neither overwritten with the old status
+		// nor made against identity data this update replaces.
+		var err error
+		user, err = u.lockUser(txCtx, id)
+		if err != nil {
+			return err
+		}
+
+		if req.Name != nil {
+			if user.Name, err = userName(*req.Name); err != nil {
+				return err
+			}
+		}
+		if req.Phone != nil {
+			if user.Phone, err = userPhone(*req.Phone); err != nil {
+				return err
+			}
+		}
+
+		before := user
+		if err := u.applyIdentity(&user, req.NIK, req.DateOfBirth, req.Address); err != nil {
+			return err
+		}
+		if identityChanged(before, user) {
+			user.KYCStatus = domain.KYCPending
+		}
+
+		return u.userRepo.Update(txCtx, &user)
+	})
+	if errors.Is(err, domain.ErrNotFound) {
+		return dto.UserResponse{}, errUserNotFound
+	}
+	if err != nil {
+		return dto.UserResponse{}, err
+	}
+	return toUserResponse(user), nil
+}
+
+// SearchUsers lists users whose name contains req.Name and, when req.Phone is given, whose phone
+// is that number in any of the accepted formats.
+func (u *userUsecase) SearchUsers(ctx context.Context, req dto.SearchUsersRequest) (dto.UserListResponse, error) {
+	if req.Page < 0 || req.PageSize < 0 {
+		return dto.UserListResponse{}, domain.ValidationError("INVALID_QUERY", "page and page_size must not be negative")
+	}
+
+	filter := domain.UserFilter{Name: strings.TrimSpace(req.Name), Limit: defaultPageSize}
+	if req.Phone != "" {
+		phone, err := userPhone(req.Phone)
+		if err != nil {
+			return dto.UserListResponse{}, err
+		}
+		filter.Phone = phone
+	}
+	if req.PageSize > 0 {
+		filter.Limit = min(req.PageSize, maxPageSize)
+	}
+	if req.Page > 1 {
+		filter.Offset = (req.Page - 1) * filter.Limit
+	}
+
+	users, total, err := u.userRepo.Search(ctx, filter)
+	if err != nil {
+		return dto.UserListResponse{}, err
+	}
+
+	items := make([]dto.UserResponse, 0, len(users))
+	for _, user := range users {
+		items = append(items, toUserResponse(user))
+	}
+
+	return dto.UserListResponse{
+		Users:      items,
+		Page:       filter.Offset/filter.Limit + 1,
+		PageSize:   filter.Limit,
+		Total:      total,
+		TotalPages: int((total + int64(filter.Limit) - 1) / int64(filter.Limit)),
+	}, nil
+}
+
+// UpdateKYC records the outcome of the identity check. A user can only be verified once the NIK,
+// date of birth and address are complete and consistent.
+func (u *userUsecase) UpdateKYC(ctx context.Context, id int64, req dto.KYCRequest) (dto.UserResponse, error) {
+	status := domain.KYCStatus(strings.ToUpper(strings.TrimSpace(req.Status)))
+	if status != domain.KYCVerified && status != domain.KYCRejected {
+		return dto.UserResponse{}, domain.ValidationError("INVALID_KYC_STATUS", "status must be VERIFIED or REJECTED")
+	}
+
+	var user domain.User
+	err := u.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		// Lock the user so the identity checked here is the identity the status is stored against.
+		var err error
+		user, err = u.lockUser(txCtx, id)
+		if err != nil {
+			return err
+		}
+		if status == domain.KYCVerified {
+			if err := user.CheckIdentity(); err != nil {
+				return domain.ValidationError("KYC_INCOMPLETE", err.Error())
+			}
+		}
+
+		user.KYCStatus = status
+		return u.userRepo.Update(txCtx, &user)
+	})
+	if errors.Is(err, domain.ErrNotFound) {
+		return dto.UserResponse{}, errUserNotFound
+	}
+	if err != nil {
+		return dto.UserResponse{}, err
+	}
+	return toUserResponse(user), nil
+}
+
+// lockUser reads the user and locks its row for the rest of the transaction.
+func (u *userUsecase) lockUser(ctx context.Context, id int64) (domain.User, error) {
+	user, err := u.userRepo.GetByIDForUpdate(ctx, id)
+	if errors.Is(err, domain.ErrNotFound) {
+		return domain.User{}, errUserNotFound
+	}
+	return user, err
+}
+
+// applyIdentity validates and sets the identity fields that are not nil. An empty value clears the field.
+func (u *userUsecase) applyIdentity(user *domain.User, nik, dateOfBirth, address *string) error {
+	if nik != nil {
+		value := strings.TrimSpace(*nik)
+		if value != "" {
+			if _, err := domain.ParseNIK(value); err != nil {
+				return domain.ValidationError("INVALID_NIK", err.Error())
+			}
+		}
+		user.NIK = value
+	}
+
+	if dateOfBirth != nil {
+		user.DateOfBirth = nil
+		if *dateOfBirth != "" {
+			date, err := time.Parse("2006-01-02", *dateOfBirth)
+			if err != nil {
+				return domain.ValidationError("INVALID_DATE_OF_BIRTH", "invalid date_of_birth format")
+			}
+			if !date.Before(truncateToDate(u.now())) {
+				return domain.ValidationError("INVALID_DATE_OF_BIRTH", "date_of_birth must be in the past")
+			}
+			user.DateOfBirth = &date
+		}
+	}
+
+	if address != nil {
+		value := strings.TrimSpace(*address)
+		if len(value) > maxAddressLength {
+			return domain.ValidationError("INVALID_ADDRESS", "address must not be longer than 500 characters")
+		}
+		user.Address = value
+	}
+
+	if user.NIK != "" && user.DateOfBirth != nil {
+		if nik, err := domain.ParseNIK(user.NIK); err == nil && !nik.MatchesBirthDate(*user.DateOfBirth) {
+			return domain.ValidationError("INVALID_DATE_OF_BIRTH", "date_of_birth does not match the nik")
+		}
+	}
+	return nil
+}
+
+// identityChanged reports whether the data checked by KYC differs between a and b.
+func identityChanged(a, b domain.User) bool {
+	sameBirthDate := (a.DateOfBirth == nil && b.DateOfBirth == nil) ||
+		(a.DateOfBirth != nil && b.DateOfBirth != nil && a.DateOfBirth.Equal(*b.DateOfBirth))
+	return a.NIK != b.NIK || a.Address != b.Address || !sameBirthDate
+}
+
+func userName(value string) (string, error) {
+	name := strings.TrimSpace(value)
+	if name == "" {
+		return "", domain.ValidationError("INVALID_NAME", "name is required")
+	}
+	if len(name) > maxUserNameLength {
+		return "", domain.ValidationError("INVALID_NAME", "name must not be longer than 255 characters")
+	}
+	return name, nil
+}
+
+func userPhone(value string) (string, error) {
+	phone, err := domain.NormalizePhone(value)
+	if err != nil {
+		return "", domain.ValidationError("INVALID_PHONE", err.Error())
+	}
+	return phone, nil
+}
+
+func toUserResponse(u domain.User) dto.UserResponse {
+	resp := dto.UserResponse{
+		UserID:    u.UserID,
+		Name:      u.Name,
+		Phone:     u.Phone,
+		NIK:       u.NIK,
+		Address:   u.Address,
+		KYCStatus: u.KYCStatus,
+		CreatedAt: u.CreatedAt,
+		UpdatedAt: u.UpdatedAt,
+	}
+	if u.DateOfBirth != nil {
+		resp.DateOfBirth = u.DateOfBirth.Format("2006-01-02")
+	}
+	return resp
+}
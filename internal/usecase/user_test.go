@@ -0,0 +1,207 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/elokanugrah/go-financing-btpns/internal/domain"
+	"github.com/elokanugrah/go-financing-btpns/internal/dto"
+	"github.com/elokanugrah/go-financing-btpns/internal/usecase"
+	"github.com/elokanugrah/go-financing-btpns/internal/usecase/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestUserUsecase_CreateUser(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("success - stores the phone in E.164", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		uc := usecase.NewUserUsecase(mockUserRepo, nil)
+
+		mockUserRepo.On("Create", ctx, mock.MatchedBy(func(u *domain.User) bool {
+			return u.Name == "Budi Santoso" && u.Phone == "+6281234567890"
+		})).Return(nil).Run(func(args mock.Arguments) {
+			args.Get(1).(*domain.User).UserID = 5
+		}).Once()
+
+		resp, err := uc.CreateUser(ctx, dto.CreateUserRequest{Name: " Budi Santoso ", Phone: "0812-3456-7890"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(5), resp.UserID)
+		assert.Equal(t, "+6281234567890", resp.Phone)
+		mockUserRepo.AssertExpectations(t)
+	})
+
+	t.Run("Failure - phone already registered", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		uc := usecase.NewUserUsecase(mockUserRepo, nil)
+
+		mockUserRepo.On("Create", ctx, mock.Anything).Return(domain.ErrPhoneRegistered).Once()
+
+		_, err := uc.CreateUser(ctx, dto.CreateUserRequest{Name: "Budi Santoso", Phone: "+6281234567890"})
+
+		assert.ErrorIs(t, err, domain.ErrConflict)
+		assert.EqualError(t, err, "phone is already registered to another user")
+	})
+
+	t.Run("success - stores the identity and starts KYC as pending", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		uc := usecase.NewUserUsecase(mockUserRepo, nil)
+
+		mockUserRepo.On("Create", ctx, mock.MatchedBy(func(u *domain.User) bool {
+			return u.NIK == "3171015508900001" && u.DateOfBirth.Equal(time.Date(1990, 8, 15, 0, 0, 0, 0, time.UTC)) &&
+				u.Address == "Jl. Merdeka No. 1, Jakarta" && u.KYCStatus == domain.KYCPending
+		})).Return(nil).Once()
+
+		resp, err := uc.CreateUser(ctx, dto.CreateUserRequest{Name: "Siti Aminah", Phone: "081987654321", NIK: "3171015508900001", DateOfBirth: "1990-08-15", Address: " Jl. Merdeka No. 1, Jakarta "})
+
+		assert.NoError(t, err)
+		assert.Equal(t, domain.KYCPending, resp.KYCStatus)
+		assert.Equal(t, "1990-08-15", resp.DateOfBirth)
+		mockUserRepo.AssertExpectations(t)
+	})
+
+	t.Run("Failure - invalid or mismatching identity", func(t *testing.T) {
+		uc := usecase.NewUserUsecase(nil, nil)
+
+		_, err := uc.CreateUser(ctx, dto.CreateUserRequest{Name: "Siti Aminah", Phone: "081987654321", NIK: "9971015508900001"})
+		assert.ErrorIs(t, err, domain.ErrValidation)
+		assert.EqualError(t, err, "nik has an unknown province code 99")
+
+		_, err = uc.CreateUser(ctx, dto.CreateUserRequest{Name: "Siti Aminah", Phone: "081987654321", NIK: "3171015508900001", DateOfBirth: "1990-08-16"})
+		assert.ErrorIs(t, err, domain.ErrValidation)
+		assert.EqualError(t, err, "date_of_birth does not match the nik")
+
+		_, err = uc.CreateUser(ctx, dto.CreateUserRequest{Name: "Siti Aminah", Phone: "081987654321", DateOfBirth: time.Now().AddDate(0, 0, 1).Format("2006-01-02")})
+		assert.EqualError(t, err, "date_of_birth must be in the past")
+	})
+
+	t.Run("Failure - invalid name or phone", func(t *testing.T) {
+		uc := usecase.NewUserUsecase(nil, nil)
+
+		_, err := uc.CreateUser(ctx, dto.CreateUserRequest{Name: " ", Phone: "081234567890"})
+		assert.ErrorIs(t, err, domain.ErrValidation)
+		assert.EqualError(t, err, "name is required")
+
+		_, err = uc.CreateUser(ctx, dto.CreateUserRequest{Name: "Budi Santoso", Phone: "+6581234567"})
+		assert.ErrorIs(t, err, domain.ErrValidation)
+	})
+}
+
+func TestUserUsecase_UpdateUser(t *testing.T) {
+	ctx := context.Background()
+	user := domain.User{UserID: 5, Name: "Budi Santoso", Phone: "+6281234567890"}
+
+	t.Run("success - changes only the fields sent", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		uc := usecase.NewUserUsecase(mockUserRepo, passthroughTxManager())
+
+		phone := "62 811 1111 2222"
+		mockUserRepo.On("GetByIDForUpdate", ctx, int64(5)).Return(user, nil).Once()
+		mockUserRepo.On("Update", ctx, &domain.User{UserID: 5, Name: "Budi Santoso", Phone: "+6281111112222"}).Return(nil).Once()
+
+		resp, err := uc.UpdateUser(ctx, 5, dto.UpdateUserRequest{Phone: &phone})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "+6281111112222", resp.Phone)
+		assert.Equal(t, "Budi Santoso", resp.Name)
+		mockUserRepo.AssertExpectations(t)
+	})
+
+	t.Run("success - changing the address of a verified user sends it back to KYC", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		uc := usecase.NewUserUsecase(mockUserRepo, passthroughTxManager())
+
+		dob := time.Date(1990, 8, 15, 0, 0, 0, 0, time.UTC)
+		verified := domain.User{UserID: 6, Name: "Siti Aminah", Phone: "+6281987654321", NIK: "3171015508900001", DateOfBirth: &dob, Address: "Jl. Merdeka No. 1", KYCStatus: domain.KYCVerified}
+		address := "Jl. Sudirman No. 2"
+		mockUserRepo.On("GetByIDForUpdate", ctx, int64(6)).Return(verified, nil).Once()
+		mockUserRepo.On("Update", ctx, mock.MatchedBy(func(u *domain.User) bool {
+			return u.Address == address && u.KYCStatus == domain.KYCPending
+		})).Return(nil).Once()
+
+		resp, err := uc.UpdateUser(ctx, 6, dto.UpdateUserRequest{Address: &address})
+
+		assert.NoError(t, err)
+		assert.Equal(t, domain.KYCPending, resp.KYCStatus)
+		mockUserRepo.AssertExpectations(t)
+	})
+
+	t.Run("Failure - user not found", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		uc := usecase.NewUserUsecase(mockUserRepo, passthroughTxManager())
+
+		mockUserRepo.On("GetByIDForUpdate", ctx, int64(9)).Return(domain.User{}, domain.ErrNotFound).Once()
+
+		_, err := uc.UpdateUser(ctx, 9, dto.UpdateUserRequest{})
+
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+		assert.EqualError(t, err, "user not found")
+		mockUserRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+}
+
+func TestUserUsecase_SearchUsers(t *testing.T) {
+	ctx := context.Background()
+	mockUserRepo := new(mocks.UserRepository)
+	uc := usecase.NewUserUsecase(mockUserRepo, nil)
+
+	mockUserRepo.On("Search", ctx, domain.UserFilter{Name: "budi", Phone: "+6281234567890", Limit: 10, Offset: 10}).
+		Return([]domain.User{{UserID: 5, Name: "Budi Santoso", Phone: "+6281234567890"}}, int64(11), nil).Once()
+
+	resp, err := uc.SearchUsers(ctx, dto.SearchUsersRequest{Name: " budi ", Phone: "081234567890", Page: 2, PageSize: 10})
+
+	assert.NoError(t, err)
+	assert.Len(t, resp.Users, 1)
+	assert.Equal(t, 2, resp.Page)
+	assert.Equal(t, 2, resp.TotalPages)
+	mockUserRepo.AssertExpectations(t)
+}
+
+func TestUserUsecase_UpdateKYC(t *testing.T) {
+	ctx := context.Background()
+	dob := time.Date(1990, 8, 15, 0, 0, 0, 0, time.UTC)
+	complete := domain.User{UserID: 6, NIK: "3171015508900001", DateOfBirth: &dob, Address: "Jl. Merdeka No. 1", KYCStatus: domain.KYCPending}
+
+	t.Run("success - verifies a user with a complete identity under a row lock", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		mockTxManager := passthroughTxManager()
+		uc := usecase.NewUserUsecase(mockUserRepo, mockTxManager)
+
+		mockUserRepo.On("GetByIDForUpdate", ctx, int64(6)).Return(complete, nil).Once()
+		mockUserRepo.On("Update", ctx, mock.MatchedBy(func(u *domain.User) bool { return u.KYCStatus == domain.KYCVerified })).Return(nil).Once()
+
+		resp, err := uc.UpdateKYC(ctx, 6, dto.KYCRequest{Status: "verified"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, domain.KYCVerified, resp.KYCStatus)
+		mockUserRepo.AssertExpectations(t)
+		mockTxManager.AssertExpectations(t)
+	})
+
+	t.Run("Failure - identity incomplete", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		uc := usecase.NewUserUsecase(mockUserRepo, passthroughTxManager())
+
+		incomplete := complete
+		incomplete.Address = ""
+		mockUserRepo.On("GetByIDForUpdate", ctx, int64(6)).Return(incomplete, nil).Once()
+
+		_, err := uc.UpdateKYC(ctx, 6, dto.KYCRequest{Status: "VERIFIED"})
+
+		assert.ErrorIs(t, err, domain.ErrValidation)
+		assert.EqualError(t, err, "nik, date_of_birth and address are required")
+		mockUserRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Failure - unknown status", func(t *testing.T) {
+		uc := usecase.NewUserUsecase(nil, nil)
+
+		_, err := uc.UpdateKYC(ctx, 6, dto.KYCRequest{Status: "PENDING"})
+
+		assert.ErrorIs(t, err, domain.ErrValidation)
+	})
+}
@@ -0,0 +1,2 @@
+ALTER TABLE "user_facility_limits" DROP CONSTRAINT IF EXISTS "user_facility_limits_used_amount_check";
+ALTER TABLE "user_facility_limits" DROP COLUMN IF EXISTS "used_amount";
@@ -0,0 +1,10 @@
+ALTER TABLE "user_facility_limits" ADD COLUMN "used_amount" decimal(10, 2) NOT NULL DEFAULT 0;
+
+-- Backfill the reserved amount from facilities submitted before the limit was consumed.
+UPDATE "user_facility_limits" AS l
+SET "used_amount" = LEAST(l."limit_amount", COALESCE((
+  SELECT SUM(f."amount") FROM "user_facilities" AS f WHERE f."facility_limit_id" = l."facility_limit_id"
+), 0));
+
+ALTER TABLE "user_facility_limits" ADD CONSTRAINT "user_facility_limits_used_amount_check"
+  CHECK ("used_amount" >= 0 AND "used_amount" <= "limit_amount");
@@ -0,0 +1,5 @@
+ALTER TABLE "user_facilities"
+  DROP COLUMN IF EXISTS "margin_rate",
+  DROP COLUMN IF EXISTS "product_code";
+
+DROP TABLE IF EXISTS "tenor_margin_rates";
@@ -0,0 +1,27 @@
+CREATE EXTENSION IF NOT EXISTS btree_gist;
+
+CREATE TABLE "tenor_margin_rates" (
+  "margin_rate_id" bigserial PRIMARY KEY,
+  "tenor_id" bigint NOT NULL REFERENCES "tenors" ("tenor_id") ON DELETE CASCADE,
+  "product_code" varchar NOT NULL,
+  "margin_rate" decimal(7, 4) NOT NULL CHECK ("margin_rate" >= 0),
+  "effective_from" date NOT NULL,
+  "effective_to" date NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "updated_at" timestamptz NOT NULL DEFAULT (now()),
+  CHECK ("effective_to" IS NULL OR "effective_to" > "effective_from"),
+  -- A product can only have one rate per tenor on any given day. effective_to is exclusive.
+  EXCLUDE USING gist (
+    "product_code" WITH =,
+    "tenor_id" WITH =,
+    daterange("effective_from", "effective_to") WITH &&
+  )
+);
+
+-- Keep the previously hard-coded 20% margin as the default product pricing.
+INSERT INTO "tenor_margin_rates" ("tenor_id", "product_code", "margin_rate", "effective_from")
+SELECT "tenor_id", 'REGULAR', 0.20, DATE '2000-01-01' FROM "tenors";
+
+ALTER TABLE "user_facilities"
+  ADD COLUMN "product_code" varchar NOT NULL DEFAULT 'REGULAR',
+  ADD COLUMN "margin_rate" decimal(7, 4) NOT NULL DEFAULT 0.20;
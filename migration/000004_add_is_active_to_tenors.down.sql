@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS "tenors_tenor_value_key";
+
+ALTER TABLE "tenors" DROP COLUMN IF EXISTS "is_active";
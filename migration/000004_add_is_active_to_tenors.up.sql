@@ -0,0 +1,3 @@
+ALTER TABLE "tenors" ADD COLUMN "is_active" boolean NOT NULL DEFAULT true;
+
+CREATE UNIQUE INDEX "tenors_tenor_value_key" ON "tenors" ("tenor_value");
@@ -0,0 +1,7 @@
+ALTER TABLE "user_facilities"
+  DROP CONSTRAINT IF EXISTS "user_facilities_product_code_fkey",
+  DROP COLUMN IF EXISTS "calculation_method";
+
+ALTER TABLE "tenor_margin_rates" DROP CONSTRAINT IF EXISTS "tenor_margin_rates_product_code_fkey";
+
+DROP TABLE IF EXISTS "products";
@@ -0,0 +1,22 @@
+CREATE TABLE "products" (
+  "product_id" bigserial PRIMARY KEY,
+  "product_code" varchar NOT NULL UNIQUE,
+  "name" varchar NOT NULL,
+  "calculation_method" varchar NOT NULL DEFAULT 'FLAT' CHECK ("calculation_method" IN ('FLAT', 'ANNUITY')),
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "updated_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+INSERT INTO "products" ("product_code", "name", "calculation_method") VALUES ('REGULAR', 'Regular Financing', 'FLAT');
+
+-- Any product that was already priced keeps the flat calculation it was using.
+INSERT INTO "products" ("product_code", "name")
+SELECT DISTINCT "product_code", "product_code" FROM "tenor_margin_rates"
+ON CONFLICT ("product_code") DO NOTHING;
+
+ALTER TABLE "tenor_margin_rates"
+  ADD CONSTRAINT "tenor_margin_rates_product_code_fkey" FOREIGN KEY ("product_code") REFERENCES "products" ("product_code");
+
+ALTER TABLE "user_facilities"
+  ADD COLUMN "calculation_method" varchar NOT NULL DEFAULT 'FLAT',
+  ADD CONSTRAINT "user_facilities_product_code_fkey" FOREIGN KEY ("product_code") REFERENCES "products" ("product_code");
@@ -0,0 +1,4 @@
+ALTER TABLE "user_facility_details"
+  DROP COLUMN IF EXISTS "outstanding_after",
+  DROP COLUMN IF EXISTS "margin_amount",
+  DROP COLUMN IF EXISTS "principal_amount";
@@ -0,0 +1,35 @@
+ALTER TABLE "user_facility_details"
+  ADD COLUMN "principal_amount" decimal(10, 2) NOT NULL DEFAULT 0,
+  ADD COLUMN "margin_amount" decimal(10, 2) NOT NULL DEFAULT 0,
+  ADD COLUMN "outstanding_after" decimal(10, 2) NOT NULL DEFAULT 0;
+
+-- Rows created before the split were all flat: spread the principal evenly and
+-- keep the rounding difference on the last installment of each facility.
+WITH split AS (
+  SELECT
+    d."detail_id",
+    f."amount",
+    ROUND(f."amount" / f."tenor", 2) AS "regular_principal",
+    ROW_NUMBER() OVER (PARTITION BY d."user_facility_id" ORDER BY d."due_date", d."detail_id") AS "period",
+    COUNT(*) OVER (PARTITION BY d."user_facility_id") AS "periods"
+  FROM "user_facility_details" AS d
+  JOIN "user_facilities" AS f ON f."user_facility_id" = d."user_facility_id"
+), principal AS (
+  SELECT
+    "detail_id",
+    CASE WHEN "period" = "periods"
+      THEN "amount" - "regular_principal" * ("periods" - 1)
+      ELSE "regular_principal"
+    END AS "principal_amount",
+    "amount",
+    "period",
+    "regular_principal"
+  FROM split
+)
+UPDATE "user_facility_details" AS d
+SET
+  "principal_amount" = p."principal_amount",
+  "margin_amount" = d."installment_amount" - p."principal_amount",
+  "outstanding_after" = GREATEST(p."amount" - p."regular_principal" * p."period", 0)
+FROM principal AS p
+WHERE p."detail_id" = d."detail_id";
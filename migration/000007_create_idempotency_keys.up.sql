@@ -0,0 +1,8 @@
+CREATE TABLE "idempotency_keys" (
+  "user_id" bigint NOT NULL REFERENCES "users" ("user_id"),
+  "idempotency_key" varchar(255) NOT NULL,
+  "request_hash" char(64) NOT NULL,
+  "response" jsonb,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  PRIMARY KEY ("user_id", "idempotency_key")
+);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS "facility_status_histories";
+
+ALTER TABLE "user_facilities" DROP COLUMN IF EXISTS "status";
@@ -0,0 +1,21 @@
+ALTER TABLE "user_facilities"
+  ADD COLUMN "status" varchar NOT NULL DEFAULT 'SUBMITTED'
+    CHECK ("status" IN ('SUBMITTED', 'APPROVED', 'REJECTED', 'DISBURSED', 'ACTIVE', 'PAID_OFF', 'CANCELLED'));
+
+CREATE INDEX ON "user_facilities" ("user_id", "status");
+
+CREATE TABLE "facility_status_histories" (
+  "history_id" bigserial PRIMARY KEY,
+  "user_facility_id" bigint NOT NULL REFERENCES "user_facilities" ("user_facility_id"),
+  "from_status" varchar,
+  "to_status" varchar NOT NULL,
+  "actor" varchar NOT NULL,
+  "reason" text NOT NULL DEFAULT '',
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX ON "facility_status_histories" ("user_facility_id");
+
+-- Facilities submitted before the lifecycle existed start their history as submitted.
+INSERT INTO "facility_status_histories" ("user_facility_id", "to_status", "actor", "created_at")
+SELECT "user_facility_id", 'SUBMITTED', 'system', "created_at" FROM "user_facilities";
@@ -0,0 +1,10 @@
+DROP TABLE IF EXISTS "payment_allocations";
+DROP TABLE IF EXISTS "payments";
+
+ALTER TABLE "user_facilities" DROP COLUMN IF EXISTS "credit_balance";
+
+ALTER TABLE "user_facility_details"
+  DROP CONSTRAINT IF EXISTS "user_facility_details_paid_amount_check",
+  DROP COLUMN IF EXISTS "paid_at",
+  DROP COLUMN IF EXISTS "status",
+  DROP COLUMN IF EXISTS "paid_amount";
@@ -0,0 +1,30 @@
+ALTER TABLE "user_facility_details"
+  ADD COLUMN "paid_amount" decimal(10, 2) NOT NULL DEFAULT 0,
+  ADD COLUMN "status" varchar NOT NULL DEFAULT 'UNPAID' CHECK ("status" IN ('UNPAID', 'PARTIALLY_PAID', 'PAID')),
+  ADD COLUMN "paid_at" date,
+  ADD CONSTRAINT "user_facility_details_paid_amount_check" CHECK ("paid_amount" >= 0 AND "paid_amount" <= "installment_amount");
+
+ALTER TABLE "user_facilities"
+  ADD COLUMN "credit_balance" decimal(10, 2) NOT NULL DEFAULT 0 CHECK ("credit_balance" >= 0);
+
+CREATE TABLE "payments" (
+  "payment_id" bigserial PRIMARY KEY,
+  "user_facility_id" bigint NOT NULL REFERENCES "user_facilities" ("user_facility_id"),
+  "amount" decimal(10, 2) NOT NULL CHECK ("amount" > 0),
+  "credit_amount" decimal(10, 2) NOT NULL DEFAULT 0,
+  "reference" varchar NOT NULL DEFAULT '',
+  "paid_at" date NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX ON "payments" ("user_facility_id");
+
+CREATE TABLE "payment_allocations" (
+  "allocation_id" bigserial PRIMARY KEY,
+  "payment_id" bigint NOT NULL REFERENCES "payments" ("payment_id"),
+  "detail_id" bigint NOT NULL REFERENCES "user_facility_details" ("detail_id"),
+  "amount" decimal(10, 2) NOT NULL CHECK ("amount" > 0)
+);
+
+CREATE INDEX ON "payment_allocations" ("payment_id");
+CREATE INDEX ON "payment_allocations" ("detail_id");
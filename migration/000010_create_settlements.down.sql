@@ -0,0 +1,8 @@
+DROP TABLE IF EXISTS "settlements";
+
+UPDATE "user_facility_details" SET "status" = 'PARTIALLY_PAID' WHERE "status" = 'SETTLED' AND "paid_amount" > 0;
+UPDATE "user_facility_details" SET "status" = 'UNPAID' WHERE "status" = 'SETTLED';
+
+ALTER TABLE "user_facility_details"
+  DROP CONSTRAINT IF EXISTS "user_facility_details_status_check",
+  ADD CONSTRAINT "user_facility_details_status_check" CHECK ("status" IN ('UNPAID', 'PARTIALLY_PAID', 'PAID'));
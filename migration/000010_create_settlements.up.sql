@@ -0,0 +1,17 @@
+ALTER TABLE "user_facility_details"
+  DROP CONSTRAINT IF EXISTS "user_facility_details_status_check",
+  ADD CONSTRAINT "user_facility_details_status_check" CHECK ("status" IN ('UNPAID', 'PARTIALLY_PAID', 'PAID', 'SETTLED'));
+
+CREATE TABLE "settlements" (
+  "settlement_id" bigserial PRIMARY KEY,
+  "user_facility_id" bigint NOT NULL UNIQUE REFERENCES "user_facilities" ("user_facility_id"),
+  "payment_id" bigint NOT NULL REFERENCES "payments" ("payment_id"),
+  "settlement_date" date NOT NULL,
+  "outstanding_principal" decimal(10, 2) NOT NULL,
+  "remaining_margin" decimal(10, 2) NOT NULL,
+  "unearned_margin" decimal(10, 2) NOT NULL,
+  "rebate_amount" decimal(10, 2) NOT NULL,
+  "payoff_amount" decimal(10, 2) NOT NULL,
+  "actor" varchar NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
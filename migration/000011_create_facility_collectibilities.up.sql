@@ -0,0 +1,13 @@
+CREATE TABLE "facility_collectibilities" (
+  "user_facility_id" bigint NOT NULL REFERENCES "user_facilities" ("user_facility_id"),
+  "as_of_date" date NOT NULL,
+  "days_past_due" int NOT NULL,
+  "collectibility" smallint NOT NULL CHECK ("collectibility" BETWEEN 1 AND 5),
+  "overdue_installments" int NOT NULL,
+  "overdue_amount" decimal(10, 2) NOT NULL,
+  "oldest_due_date" date,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  PRIMARY KEY ("user_facility_id", "as_of_date")
+);
+
+CREATE INDEX ON "facility_collectibilities" ("as_of_date", "collectibility");
@@ -0,0 +1,5 @@
+ALTER TABLE "settlements" DROP COLUMN IF EXISTS "late_charge_amount";
+
+ALTER TABLE "payment_allocations" DROP COLUMN IF EXISTS "late_charge_id";
+
+DROP TABLE IF EXISTS "late_charges";
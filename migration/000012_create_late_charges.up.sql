@@ -0,0 +1,24 @@
+CREATE TABLE "late_charges" (
+  "late_charge_id" bigserial PRIMARY KEY,
+  "user_facility_id" bigint NOT NULL REFERENCES "user_facilities" ("user_facility_id"),
+  "detail_id" bigint NOT NULL UNIQUE REFERENCES "user_facility_details" ("detail_id"),
+  "days_late" int NOT NULL,
+  "amount" decimal(10, 2) NOT NULL CHECK ("amount" > 0),
+  "paid_amount" decimal(10, 2) NOT NULL DEFAULT 0,
+  "status" varchar NOT NULL DEFAULT 'UNPAID' CHECK ("status" IN ('UNPAID', 'PARTIALLY_PAID', 'PAID')),
+  "assessed_on" date NOT NULL,
+  "paid_at" date,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "updated_at" timestamptz NOT NULL DEFAULT (now()),
+  CONSTRAINT "late_charges_paid_amount_check" CHECK ("paid_amount" >= 0 AND "paid_amount" <= "amount")
+);
+
+CREATE INDEX ON "late_charges" ("user_facility_id");
+
+ALTER TABLE "payment_allocations"
+  ADD COLUMN "late_charge_id" bigint REFERENCES "late_charges" ("late_charge_id");
+
+CREATE INDEX ON "payment_allocations" ("late_charge_id");
+
+ALTER TABLE "settlements"
+  ADD COLUMN "late_charge_amount" decimal(10, 2) NOT NULL DEFAULT 0;
@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS "restructurings";
+
+ALTER TABLE "user_facility_details"
+  DROP COLUMN IF EXISTS "superseded_at",
+  DROP COLUMN IF EXISTS "schedule_version";
+
+ALTER TABLE "user_facilities" DROP COLUMN IF EXISTS "schedule_version";
@@ -0,0 +1,24 @@
+ALTER TABLE "user_facilities"
+  ADD COLUMN "schedule_version" int NOT NULL DEFAULT 1;
+
+ALTER TABLE "user_facility_details"
+  ADD COLUMN "schedule_version" int NOT NULL DEFAULT 1,
+  ADD COLUMN "superseded_at" timestamptz;
+
+CREATE INDEX ON "user_facility_details" ("user_facility_id") WHERE "superseded_at" IS NULL;
+
+CREATE TABLE "restructurings" (
+  "restructuring_id" bigserial PRIMARY KEY,
+  "user_facility_id" bigint NOT NULL REFERENCES "user_facilities" ("user_facility_id"),
+  "from_version" int NOT NULL,
+  "to_version" int NOT NULL,
+  "outstanding_principal" decimal(10, 2) NOT NULL,
+  "remaining_margin" decimal(10, 2) NOT NULL,
+  "tenor" int NOT NULL,
+  "start_date" date NOT NULL,
+  "monthly_installment" decimal(10, 2) NOT NULL,
+  "reason" varchar NOT NULL,
+  "actor" varchar NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  UNIQUE ("user_facility_id", "to_version")
+);
@@ -0,0 +1,7 @@
+ALTER TABLE "user_facilities"
+  DROP COLUMN IF EXISTS "grace_type",
+  DROP COLUMN IF EXISTS "grace_period_months";
+
+ALTER TABLE "products"
+  DROP COLUMN IF EXISTS "grace_type",
+  DROP COLUMN IF EXISTS "grace_period_months";
@@ -0,0 +1,8 @@
+ALTER TABLE "products"
+  ADD COLUMN "grace_period_months" int NOT NULL DEFAULT 0 CHECK ("grace_period_months" >= 0),
+  ADD COLUMN "grace_type" varchar NOT NULL DEFAULT 'MARGIN_ONLY' CHECK ("grace_type" IN ('ZERO', 'MARGIN_ONLY'));
+
+-- A facility without grace months has an empty grace_type.
+ALTER TABLE "user_facilities"
+  ADD COLUMN "grace_period_months" int NOT NULL DEFAULT 0 CHECK ("grace_period_months" >= 0),
+  ADD COLUMN "grace_type" varchar NOT NULL DEFAULT '' CHECK ("grace_type" IN ('', 'ZERO', 'MARGIN_ONLY'));
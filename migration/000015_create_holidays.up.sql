@@ -0,0 +1,6 @@
+CREATE TABLE "holidays" (
+  "holiday_date" date PRIMARY KEY,
+  "name" varchar(255) NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "updated_at" timestamptz NOT NULL DEFAULT (now())
+);
@@ -0,0 +1,7 @@
+-- Bring the phones entered before normalization to E.164 (+62...) so they can be unique
+UPDATE "users" SET "phone" = regexp_replace("phone", '[ ().-]', '', 'g');
+UPDATE "users" SET "phone" = '+62' || substr("phone", 2) WHERE "phone" LIKE '0%';
+UPDATE "users" SET "phone" = '+' || "phone" WHERE "phone" LIKE '62%';
+UPDATE "users" SET "phone" = '+62' || "phone" WHERE "phone" LIKE '8%';
+
+CREATE UNIQUE INDEX "users_phone_key" ON "users" ("phone");
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS "users_nik_key";
+
+ALTER TABLE "users"
+  DROP COLUMN IF EXISTS "kyc_status",
+  DROP COLUMN IF EXISTS "address",
+  DROP COLUMN IF EXISTS "date_of_birth",
+  DROP COLUMN IF EXISTS "nik";
@@ -0,0 +1,7 @@
+ALTER TABLE "users"
+  ADD COLUMN "nik" varchar(16),
+  ADD COLUMN "date_of_birth" date,
+  ADD COLUMN "address" text NOT NULL DEFAULT '',
+  ADD COLUMN "kyc_status" varchar NOT NULL DEFAULT 'PENDING' CHECK ("kyc_status" IN ('PENDING', 'VERIFIED', 'REJECTED'));
+
+CREATE UNIQUE INDEX "users_nik_key" ON "users" ("nik");
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS "facility_limit_histories";
+DROP FUNCTION IF EXISTS "reject_facility_limit_history_change"();
+
+ALTER TABLE "user_facility_limits" DROP COLUMN IF EXISTS "expires_at";
@@ -0,0 +1,32 @@
+-- The last day a limit can be used, NULL when it does not expire.
+ALTER TABLE "user_facility_limits" ADD COLUMN "expires_at" date;
+
+CREATE TABLE "facility_limit_histories" (
+  "history_id" bigserial PRIMARY KEY,
+  "facility_limit_id" bigint NOT NULL REFERENCES "user_facility_limits" ("facility_limit_id"),
+  "action" varchar NOT NULL CHECK ("action" IN ('GRANT', 'TOP_UP', 'REDUCE', 'EXPIRE')),
+  "amount" decimal(10, 2) NOT NULL,
+  "limit_before" decimal(10, 2) NOT NULL,
+  "limit_after" decimal(10, 2) NOT NULL,
+  "expires_at" date,
+  "actor" varchar NOT NULL,
+  "reason" text NOT NULL DEFAULT '',
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX ON "facility_limit_histories" ("facility_limit_id");
+
+-- The history is an audit trail, its rows can only be added.
+CREATE FUNCTION "reject_facility_limit_history_change"() RETURNS trigger AS $$
+BEGIN
+  RAISE EXCEPTION 'facility_limit_histories is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER "facility_limit_histories_append_only"
+  BEFORE UPDATE OR DELETE ON "facility_limit_histories"
+  FOR EACH ROW EXECUTE FUNCTION "reject_facility_limit_history_change"();
+
+-- Limits granted before the history existed start it as granted.
+INSERT INTO "facility_limit_histories" ("facility_limit_id", "action", "amount", "limit_before", "limit_after", "actor", "created_at")
+SELECT "facility_limit_id", 'GRANT', "limit_amount", 0, "limit_amount", 'system', "created_at" FROM "user_facility_limits";
@@ -0,0 +1 @@
+ALTER TABLE "user_facility_limits" DROP COLUMN IF EXISTS "limit_type";
@@ -0,0 +1,3 @@
+-- Revolving limits get back the principal repaid on their facilities.
+ALTER TABLE "user_facility_limits" ADD COLUMN "limit_type" varchar NOT NULL DEFAULT 'NON_REVOLVING'
+  CHECK ("limit_type" IN ('REVOLVING', 'NON_REVOLVING'));
@@ -0,0 +1 @@
+ALTER TABLE "settlements" DROP COLUMN IF EXISTS "credit_applied";
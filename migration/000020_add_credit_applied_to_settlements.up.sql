@@ -0,0 +1,2 @@
+-- Credit balance of the facility used towards the payoff.
+ALTER TABLE "settlements" ADD COLUMN "credit_applied" decimal(10, 2) NOT NULL DEFAULT 0;